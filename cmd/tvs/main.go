@@ -3,20 +3,46 @@ package main
 import (
 	"context"
 
+	"github.com/tuncanbit/tvs/internal/application/accountpolicyservice"
+	"github.com/tuncanbit/tvs/internal/application/addressbookservice"
 	authservice "github.com/tuncanbit/tvs/internal/application/auth"
+	"github.com/tuncanbit/tvs/internal/application/conversionservice"
+	"github.com/tuncanbit/tvs/internal/application/executorservice"
+	"github.com/tuncanbit/tvs/internal/application/feeservice"
+	"github.com/tuncanbit/tvs/internal/application/limitservice"
+	"github.com/tuncanbit/tvs/internal/application/playerprotectionservice"
+	"github.com/tuncanbit/tvs/internal/application/pricingservice"
+	"github.com/tuncanbit/tvs/internal/application/quoteservice"
+	"github.com/tuncanbit/tvs/internal/application/reviewservice"
+	"github.com/tuncanbit/tvs/internal/application/screeningservice"
+	"github.com/tuncanbit/tvs/internal/application/travelruleservice"
 	"github.com/tuncanbit/tvs/internal/application/verificationservice"
+	"github.com/tuncanbit/tvs/internal/application/withdrawalservice"
 	"github.com/tuncanbit/tvs/internal/infrastructure/clients"
 	"github.com/tuncanbit/tvs/internal/infrastructure/database"
 	"github.com/tuncanbit/tvs/internal/infrastructure/rpc"
+	"github.com/tuncanbit/tvs/internal/infrastructure/signer"
+	"github.com/tuncanbit/tvs/internal/repositories/addressbookrepo"
+	"github.com/tuncanbit/tvs/internal/repositories/auditrepo"
 	"github.com/tuncanbit/tvs/internal/repositories/authrepo"
 	"github.com/tuncanbit/tvs/internal/repositories/balancerepo"
+	"github.com/tuncanbit/tvs/internal/repositories/configrepo"
+	"github.com/tuncanbit/tvs/internal/repositories/conversionrepo"
+	"github.com/tuncanbit/tvs/internal/repositories/exchangeraterepo"
+	"github.com/tuncanbit/tvs/internal/repositories/feerepo"
+	"github.com/tuncanbit/tvs/internal/repositories/playerprotectionrepo"
+	"github.com/tuncanbit/tvs/internal/repositories/quoterepo"
 	"github.com/tuncanbit/tvs/internal/repositories/sessionrepo"
 	"github.com/tuncanbit/tvs/internal/repositories/transactionrepo"
+	"github.com/tuncanbit/tvs/internal/repositories/userrepo"
 	"github.com/tuncanbit/tvs/internal/repositories/withdrawalrepo"
 	"github.com/tuncanbit/tvs/internal/server"
 	"github.com/tuncanbit/tvs/internal/server/websocket"
+	"github.com/tuncanbit/tvs/pkg/circuitbreaker"
 	"github.com/tuncanbit/tvs/pkg/config"
 	"github.com/tuncanbit/tvs/pkg/logger"
+	"github.com/tuncanbit/tvs/pkg/ratelimit"
+	"github.com/tuncanbit/tvs/pkg/sealer"
 )
 
 func main() {
@@ -38,17 +64,64 @@ func main() {
 	balanceRepo := balancerepo.New(db.Db)
 	withdrawalRepo := withdrawalrepo.New(db.Db, logger)
 	authRepo := authrepo.NewAuthRepository(db.Db)
+	exchangeRateRepo := exchangeraterepo.New(db.Db, logger)
+	userRepo := userrepo.New(db.Db, logger)
+	configRepo := configrepo.New(db.Db, logger)
+	conversionRepo := conversionrepo.New(db.Db, logger)
+	quoteRepo := quoterepo.New(db.Db, logger)
+	auditRepo := auditrepo.New(db.Db, logger)
+	feeRepo := feerepo.New(db.Db, logger)
+	addressBookRepo := addressbookrepo.New(db.Db, logger)
+	playerProtectionRepo := playerprotectionrepo.New(db.Db, logger)
 
-	exchageApiClient := clients.NewExchangeAPIClient(&cfg.ExchangeAPIConfig, logger)
-	heliusClient := rpc.NewHeliusClient(cfg, logger)
+	rateLimiters := ratelimit.NewRegistry(cfg.RateLimits, logger)
+	circuitBreakers := circuitbreaker.NewRegistry(cfg.CircuitBreakers, logger)
+	exchageApiClient := clients.NewExchangeAPIClient(&cfg.ExchangeAPIConfig, rateLimiters, circuitBreakers, logger)
+	priceSources := clients.NewPriceSources(cfg, exchageApiClient, rateLimiters, circuitBreakers, logger)
+	var fxClient *clients.FXClient
+	if cfg.Pricing.FX.Enabled {
+		fxClient = clients.NewFXClient(cfg.Pricing.FX, rateLimiters, circuitBreakers, logger)
+	}
+	heliusClient := rpc.NewHeliusClient(cfg, rateLimiters, circuitBreakers, logger)
+	alchemyClient := rpc.NewAlchemyClient(cfg, rateLimiters, circuitBreakers, logger)
 	wsHub := websocket.NewWsHub(logger)
 	go wsHub.Run()
 
-	verificationSvc := verificationservice.New(sessionRepo, transactionRepo, balanceRepo, withdrawalRepo, cfg.Verification, logger, heliusClient, exchageApiClient, wsHub)
+	pricingSvc := pricingservice.New(priceSources, fxClient, exchangeRateRepo, cfg.Pricing, cfg.Verification, logger)
+	conversionSvc := conversionservice.New(configRepo, conversionRepo, logger)
+	feeSvc := feeservice.New(feeRepo, pricingSvc, conversionSvc, heliusClient, alchemyClient, cfg.Withdrawals, logger)
+	quoteSvc := quoteservice.New(quoteRepo, sessionRepo, userRepo, pricingSvc, cfg.Quotes, logger)
+	screeningSvc := screeningservice.New(cfg.Screening, logger)
+	screeningSvc.Start(context.Background())
+	policySvc := accountpolicyservice.New(userRepo, sessionRepo, pricingSvc, conversionSvc, cfg.AccountPolicy, logger)
+	playerProtectionSvc := playerprotectionservice.New(playerProtectionRepo, sessionRepo, auditRepo, pricingSvc, conversionSvc, cfg.PlayerProtection, logger)
+	limitSvc := limitservice.New(configRepo, withdrawalRepo, pricingSvc, screeningSvc, policySvc, cfg.Withdrawals, logger)
+	limitSvc.Start(context.Background())
+	reviewSvc := reviewservice.New(withdrawalRepo, sessionRepo, balanceRepo, auditRepo, limitSvc, wsHub, cfg.Withdrawals, logger)
+	reviewSvc.Start(context.Background())
+	addressBookSvc := addressbookservice.New(addressBookRepo, auditRepo, wsHub, cfg.Withdrawals, logger)
+	travelRuleSealer, err := sealer.New(cfg.Security.EncryptionKey, "travel_rule")
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to set up travel rule encryption")
+	}
+	travelRuleSvc := travelruleservice.New(configRepo, userRepo, withdrawalRepo, auditRepo, travelRuleSealer, cfg.TravelRule, logger)
+	withdrawalSvc := withdrawalservice.New(withdrawalRepo, balanceRepo, userRepo, pricingSvc, conversionSvc, feeSvc, limitSvc, policySvc, addressBookSvc, travelRuleSvc, heliusClient, wsHub, cfg.Withdrawals, logger)
+	if cfg.Withdrawals.Executor.Enabled {
+		if cfg.Withdrawals.Executor.Signer != "" && cfg.Withdrawals.Executor.Signer != "local" {
+			logger.Fatal().Str("signer", cfg.Withdrawals.Executor.Signer).Msg("Unsupported withdrawal signer")
+		}
+		keystore, err := signer.LoadKeystore(cfg.Withdrawals.Executor.KeystorePath, cfg.Security.EncryptionKey)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Failed to load withdrawal keystore")
+		}
+		executorSvc := executorservice.New(withdrawalRepo, limitSvc, heliusClient, keystore, cfg.Withdrawals.Executor, cfg.Verification.WithdrawalBroadcastTimeout, logger)
+		executorSvc.Start(context.Background())
+	}
+	verificationSvc := verificationservice.New(sessionRepo, transactionRepo, balanceRepo, withdrawalRepo, userRepo, cfg.Verification, logger, heliusClient, pricingSvc, conversionSvc, quoteSvc, limitSvc, reviewSvc, screeningSvc, policySvc, playerProtectionSvc, feeSvc, wsHub)
 	authSvc := authservice.NewAuthService(cfg, logger, authRepo)
 
 	go verificationSvc.StartTransactionVerification(context.Background())
 
-	srv := server.New(cfg, verificationSvc, authSvc, logger, wsHub)
+	srv := server.New(cfg, verificationSvc, authSvc, conversionSvc, feeSvc, quoteSvc, reviewSvc, withdrawalSvc, addressBookSvc, travelRuleSvc, playerProtectionSvc, logger, wsHub, circuitBreakers)
 	srv.Start()
 }
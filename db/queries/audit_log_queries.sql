@@ -0,0 +1,11 @@
+-- name: CreateAuditLog :exec
+INSERT INTO audit_logs (
+    action, entity_type, entity_id, admin_id, old_values, new_values, ip_address, user_agent
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+);
+
+-- name: ListAuditLogsForEntity :many
+SELECT * FROM audit_logs
+WHERE entity_type = $1 AND entity_id = $2
+ORDER BY created_at ASC;
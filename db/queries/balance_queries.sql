@@ -0,0 +1,19 @@
+-- name: CreateBalanceIfMissing :exec
+INSERT INTO balances (user_id, currency_code)
+VALUES ($1, $2)
+ON CONFLICT (user_id, currency_code) DO NOTHING;
+
+-- name: GetBalanceForUpdate :one
+SELECT * FROM balances
+WHERE user_id = $1 AND currency_code = $2
+FOR UPDATE;
+
+-- name: SettleReservedBalance :one
+UPDATE balances
+SET reserved_cents = reserved_cents - sqlc.arg(reserved_cents),
+    amount_cents = amount_cents - sqlc.arg(charged_cents),
+    amount_units = GREATEST(amount_units - sqlc.arg(debited_units), 0),
+    updated_at = CURRENT_TIMESTAMP
+WHERE user_id = sqlc.arg(user_id) AND currency_code = sqlc.arg(currency_code)
+    AND reserved_cents >= sqlc.arg(reserved_cents) AND amount_cents >= sqlc.arg(charged_cents)
+RETURNING *;
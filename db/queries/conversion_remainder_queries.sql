@@ -0,0 +1,19 @@
+-- name: CreateConversionRemainder :exec
+INSERT INTO conversion_remainders (
+    transaction_id, original_amount, converted_amount, remainder_amount,
+    currency_code, conversion_type
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+);
+
+-- name: GetRoundingDrift :many
+SELECT
+    currency_code,
+    conversion_type,
+    COUNT(*) AS conversions,
+    CAST(COALESCE(SUM(remainder_amount), 0) AS TEXT) AS net_remainder,
+    CAST(COALESCE(SUM(ABS(remainder_amount)), 0) AS TEXT) AS absolute_remainder
+FROM conversion_remainders
+WHERE created_at >= $1 AND created_at < $2
+GROUP BY currency_code, conversion_type
+ORDER BY currency_code, conversion_type;
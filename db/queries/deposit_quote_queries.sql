@@ -0,0 +1,29 @@
+-- name: UpsertDepositQuote :one
+INSERT INTO deposit_quotes (
+    session_id, user_id, crypto_currency, fiat_currency, fiat_amount_cents,
+    crypto_amount, exchange_rate, price_usd, slippage_bps, status, expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, 'active', $10
+)
+ON CONFLICT (session_id) DO UPDATE
+SET fiat_currency = EXCLUDED.fiat_currency,
+    fiat_amount_cents = EXCLUDED.fiat_amount_cents,
+    crypto_amount = EXCLUDED.crypto_amount,
+    exchange_rate = EXCLUDED.exchange_rate,
+    price_usd = EXCLUDED.price_usd,
+    slippage_bps = EXCLUDED.slippage_bps,
+    expires_at = EXCLUDED.expires_at,
+    updated_at = CURRENT_TIMESTAMP
+WHERE deposit_quotes.status = 'active'
+RETURNING *;
+
+-- name: GetDepositQuoteBySessionID :one
+SELECT * FROM deposit_quotes
+WHERE session_id = $1;
+
+-- name: SettleDepositQuote :execrows
+UPDATE deposit_quotes
+SET status = $2,
+    settled_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE session_id = $1 AND status = 'active';
@@ -0,0 +1,27 @@
+-- name: ListDepositSessionsByStatus :many
+SELECT * FROM deposit_sessions
+WHERE status = $1
+ORDER BY updated_at ASC
+LIMIT $2 OFFSET $3;
+
+-- name: ResolveHeldDepositSession :execrows
+UPDATE deposit_sessions
+SET status = $2,
+    metadata = $3,
+    error_message = $4,
+    updated_at = CURRENT_TIMESTAMP
+WHERE session_id = $1 AND status = $5;
+
+-- name: SumUserCreditedDeposits :one
+SELECT COALESCE(SUM(t.usd_amount_cents), 0)::BIGINT AS total
+FROM transactions t
+JOIN deposit_sessions d ON d.session_id = t.deposit_session_id
+WHERE d.user_id = $1
+  AND t.transaction_type = 'deposit'
+  AND t.status = 'verified'
+  AND t.created_at >= $2;
+
+-- name: ListUserDepositSessionsByStatus :many
+SELECT * FROM deposit_sessions
+WHERE user_id = $1 AND status = $2
+ORDER BY updated_at DESC;
@@ -0,0 +1,10 @@
+-- name: UpsertExchangeRate :exec
+INSERT INTO exchange_rates (from_currency, to_currency, rate, updated_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (from_currency, to_currency) DO UPDATE
+SET rate = EXCLUDED.rate,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: GetExchangeRate :one
+SELECT * FROM exchange_rates
+WHERE from_currency = $1 AND to_currency = $2;
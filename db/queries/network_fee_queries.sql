@@ -0,0 +1,25 @@
+-- name: CreateNetworkFee :exec
+INSERT INTO network_fees (
+    chain_id, tx_hash, transaction_type, withdrawal_id, deposit_session_id,
+    fee_currency, fee_base_units, fee_payer, paid_by, usd_cents, price_usd,
+    charged_currency, charged_cents
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+)
+ON CONFLICT (chain_id, tx_hash) DO NOTHING;
+
+-- name: GetNetworkFeeSummary :many
+SELECT
+    chain_id,
+    fee_currency,
+    transaction_type,
+    paid_by,
+    CAST(COALESCE(charged_currency, '') AS TEXT) AS charged_currency,
+    COUNT(*) AS transactions,
+    CAST(COALESCE(SUM(fee_base_units), 0) AS BIGINT) AS fee_base_units,
+    CAST(COALESCE(SUM(usd_cents), 0) AS BIGINT) AS usd_cents,
+    CAST(COALESCE(SUM(charged_cents), 0) AS BIGINT) AS charged_cents
+FROM network_fees
+WHERE created_at >= $1 AND created_at < $2
+GROUP BY chain_id, fee_currency, transaction_type, paid_by, charged_currency
+ORDER BY chain_id, transaction_type, paid_by, charged_currency;
@@ -0,0 +1,31 @@
+-- name: ListDepositLimits :many
+SELECT * FROM deposit_limits
+WHERE user_id = $1;
+
+-- name: UpsertDepositLimit :one
+INSERT INTO deposit_limits (
+    user_id, period, limit_usd_cents, pending_limit_usd_cents, pending_effective_at, set_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (user_id, period) DO UPDATE
+SET limit_usd_cents = EXCLUDED.limit_usd_cents,
+    pending_limit_usd_cents = EXCLUDED.pending_limit_usd_cents,
+    pending_effective_at = EXCLUDED.pending_effective_at,
+    set_by = EXCLUDED.set_by,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: CreateSelfExclusion :one
+INSERT INTO self_exclusions (
+    user_id, starts_at, ends_at, created_by
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetActiveSelfExclusion :one
+SELECT * FROM self_exclusions
+WHERE user_id = $1 AND starts_at <= $2 AND ends_at > $2
+ORDER BY ends_at DESC
+LIMIT 1;
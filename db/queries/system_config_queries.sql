@@ -0,0 +1,3 @@
+-- name: GetSystemConfig :one
+SELECT * FROM system_config
+WHERE config_key = $1;
@@ -0,0 +1,11 @@
+-- name: GetUserDefaultCurrency :one
+SELECT default_currency FROM users
+WHERE id = $1;
+
+-- name: GetUserProfile :one
+SELECT id, first_name, last_name, street_address, city, state, postal_code, country FROM users
+WHERE id = $1;
+
+-- name: GetUserAccountStatus :one
+SELECT kyc_status, status FROM users
+WHERE id = $1;
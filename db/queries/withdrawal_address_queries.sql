@@ -0,0 +1,44 @@
+-- name: CreateWithdrawalAddress :one
+INSERT INTO withdrawal_addresses (
+    user_id, chain_id, address, label, available_at
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: ListWithdrawalAddresses :many
+SELECT * FROM withdrawal_addresses
+WHERE user_id = $1
+ORDER BY created_at ASC;
+
+-- name: GetWithdrawalAddress :one
+SELECT * FROM withdrawal_addresses
+WHERE user_id = $1 AND chain_id = $2 AND address = $3;
+
+-- name: UpdateWithdrawalAddressLabel :one
+UPDATE withdrawal_addresses
+SET label = $3,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: DeleteWithdrawalAddress :one
+DELETE FROM withdrawal_addresses
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: GetWithdrawalAddressSettings :one
+SELECT * FROM withdrawal_address_settings
+WHERE user_id = $1;
+
+-- name: UpsertWithdrawalAddressSettings :one
+INSERT INTO withdrawal_address_settings (
+    user_id, strict_mode, strict_mode_ends_at
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (user_id) DO UPDATE
+SET strict_mode = EXCLUDED.strict_mode,
+    strict_mode_ends_at = EXCLUDED.strict_mode_ends_at,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
@@ -0,0 +1,30 @@
+-- name: CreateWithdrawalApproval :one
+INSERT INTO withdrawal_approvals (
+    withdrawal_id, admin_id, note, ip_address, user_agent
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: RevokeWithdrawalApproval :execrows
+UPDATE withdrawal_approvals
+SET revoked_at = CURRENT_TIMESTAMP
+WHERE withdrawal_id = $1 AND admin_id = $2 AND revoked_at IS NULL;
+
+-- name: RevokeAllWithdrawalApprovals :exec
+UPDATE withdrawal_approvals
+SET revoked_at = CURRENT_TIMESTAMP
+WHERE withdrawal_id = $1 AND revoked_at IS NULL;
+
+-- name: ListActiveWithdrawalApprovals :many
+SELECT * FROM withdrawal_approvals
+WHERE withdrawal_id = $1 AND revoked_at IS NULL
+ORDER BY approved_at ASC;
+
+-- name: CountQuorumApprovals :one
+SELECT COUNT(DISTINCT a.admin_id)::int AS approvals
+FROM withdrawal_approvals a
+JOIN withdrawals w ON w.withdrawal_id = a.withdrawal_id
+WHERE a.withdrawal_id = $1
+  AND a.revoked_at IS NULL
+  AND a.admin_id <> w.user_id;
@@ -0,0 +1,127 @@
+-- name: SumUserWithdrawalsSince :one
+SELECT COALESCE(SUM(COALESCE((metadata->'limit_check'->>'usd_amount_cents')::bigint, usd_amount_cents)), 0)::bigint AS total_usd_cents
+FROM withdrawals
+WHERE user_id = $1
+  AND withdrawal_id <> $2
+  AND created_at >= $3
+  AND status NOT IN ('failed', 'cancelled');
+
+-- name: SetWithdrawalLimitCheck :exec
+UPDATE withdrawals
+SET
+    metadata = COALESCE(metadata, '{}'::jsonb) || jsonb_build_object('limit_check', sqlc.arg(limit_check)::jsonb),
+    updated_at = CURRENT_TIMESTAMP
+WHERE withdrawal_id = sqlc.arg(withdrawal_id);
+
+-- name: SetWithdrawalNetworkFee :exec
+UPDATE withdrawals
+SET
+    metadata = COALESCE(metadata, '{}'::jsonb) || jsonb_build_object('network_fee', sqlc.arg(network_fee)::jsonb),
+    updated_at = CURRENT_TIMESTAMP
+WHERE withdrawal_id = sqlc.arg(withdrawal_id);
+
+-- name: FlagWithdrawalForReview :execrows
+UPDATE withdrawals
+SET
+    status = 'awaiting_admin_review',
+    requires_admin_review = TRUE,
+    admin_review_deadline = sqlc.arg(admin_review_deadline),
+    metadata = COALESCE(metadata, '{}'::jsonb) || jsonb_build_object('limit_check', sqlc.arg(limit_check)::jsonb),
+    updated_at = CURRENT_TIMESTAMP
+WHERE withdrawal_id = sqlc.arg(withdrawal_id) AND status = 'pending';
+
+-- name: FlagWithdrawalVerificationMismatch :execrows
+UPDATE withdrawals
+SET
+    status = 'awaiting_admin_review',
+    requires_admin_review = TRUE,
+    admin_review_deadline = sqlc.arg(admin_review_deadline),
+    metadata = (COALESCE(metadata, '{}'::jsonb) - 'review') || jsonb_build_object('verification', sqlc.arg(verification)::jsonb),
+    updated_at = CURRENT_TIMESTAMP
+WHERE withdrawal_id = sqlc.arg(withdrawal_id) AND status IN ('pending', 'processing');
+
+-- name: ListWithdrawalsAwaitingReview :many
+SELECT * FROM withdrawals
+WHERE status = 'awaiting_admin_review'
+ORDER BY admin_review_deadline ASC NULLS LAST, created_at ASC
+LIMIT $1 OFFSET $2;
+
+-- name: ListOverdueWithdrawalReviews :many
+SELECT * FROM withdrawals
+WHERE status = 'awaiting_admin_review' AND admin_review_deadline < $1
+ORDER BY admin_review_deadline ASC;
+
+-- name: UpdateWithdrawalReview :execrows
+UPDATE withdrawals
+SET
+    admin_review_deadline = COALESCE(sqlc.narg(admin_review_deadline), admin_review_deadline),
+    metadata = COALESCE(metadata, '{}'::jsonb) || jsonb_build_object('review', sqlc.arg(review)::jsonb),
+    updated_at = CURRENT_TIMESTAMP
+WHERE withdrawal_id = sqlc.arg(withdrawal_id) AND status = 'awaiting_admin_review';
+
+-- name: ResolveWithdrawalReview :execrows
+UPDATE withdrawals
+SET
+    status = sqlc.arg(status),
+    admin_id = sqlc.narg(admin_id),
+    requires_admin_review = FALSE,
+    error_message = sqlc.narg(error_message),
+    metadata = COALESCE(metadata, '{}'::jsonb) || jsonb_build_object('review', sqlc.arg(review)::jsonb),
+    updated_at = CURRENT_TIMESTAMP
+WHERE withdrawal_id = sqlc.arg(withdrawal_id) AND status = 'awaiting_admin_review';
+
+-- name: MarkWithdrawalReservationReleased :exec
+UPDATE withdrawals
+SET
+    reservation_released = TRUE,
+    reservation_released_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE withdrawal_id = $1;
+
+-- name: ReleaseBalanceForWithdrawal :execrows
+UPDATE balances
+SET reserved_cents = reserved_cents - sqlc.arg(amount_cents),
+    amount_cents = amount_cents + sqlc.arg(amount_cents),
+    updated_at = CURRENT_TIMESTAMP
+WHERE user_id = sqlc.arg(user_id) AND currency_code = sqlc.arg(currency_code) AND reserved_cents >= sqlc.arg(amount_cents);
+
+-- name: ReserveBalanceForWithdrawal :execrows
+UPDATE balances
+SET reserved_cents = reserved_cents + sqlc.arg(amount_cents),
+    amount_cents = amount_cents - sqlc.arg(amount_cents),
+    updated_at = CURRENT_TIMESTAMP
+WHERE user_id = sqlc.arg(user_id) AND currency_code = sqlc.arg(currency_code) AND amount_cents >= sqlc.arg(amount_cents);
+
+-- name: CreateWithdrawal :one
+INSERT INTO withdrawals (
+    user_id, withdrawal_id, chain_id, network, crypto_currency, usd_amount_cents,
+    crypto_amount, exchange_rate, fee_cents, to_address, status,
+    source_wallet_address, amount_reserved_cents, metadata
+)
+VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, 'pending', $11, $12, $13
+)
+RETURNING *;
+
+-- name: ListWithdrawalsAwaitingBroadcast :many
+SELECT * FROM withdrawals
+WHERE status = 'pending'
+  AND tx_hash IS NULL
+  AND metadata->>'origin' = 'api'
+ORDER BY created_at ASC
+LIMIT $1;
+
+-- name: ClaimWithdrawalBroadcast :execrows
+UPDATE withdrawals
+SET
+    metadata = COALESCE(metadata, '{}'::jsonb) || jsonb_build_object('broadcast', sqlc.arg(broadcast)::jsonb),
+    updated_at = CURRENT_TIMESTAMP
+WHERE withdrawal_id = sqlc.arg(withdrawal_id)
+  AND status = 'pending'
+  AND tx_hash IS NULL
+  AND COALESCE(metadata->'broadcast'->>'signature', '') = sqlc.arg(previous_signature)::text;
+
+-- name: SetWithdrawalTxHash :execrows
+UPDATE withdrawals
+SET tx_hash = $2, updated_at = CURRENT_TIMESTAMP
+WHERE withdrawal_id = $1 AND tx_hash IS NULL;
@@ -3,7 +3,7 @@ CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
 CREATE EXTENSION IF NOT EXISTS "pgcrypto";
 
 -- Define custom types
-CREATE TYPE deposit_session_status AS ENUM ('pending', 'processing', 'completed', 'failed', 'cancelled', 'expired');
+CREATE TYPE deposit_session_status AS ENUM ('pending', 'processing', 'completed', 'failed', 'cancelled', 'expired', 'held', 'limit_held', 'refunded');
 CREATE TYPE withdrawal_status AS ENUM ('pending', 'processing', 'completed', 'failed', 'cancelled', 'awaiting_admin_review');
 CREATE TYPE ProcessorType AS ENUM ('internal', 'pdm');
 CREATE TYPE components AS ENUM ('real_money', 'bonus_money', 'points');
@@ -53,11 +53,19 @@ CREATE TABLE IF NOT EXISTS currency_config (
 -- Insert common currencies
 INSERT INTO currency_config (currency_code, currency_name, currency_type, decimal_places, smallest_unit_name) VALUES
 ('USD', 'US Dollar', 'fiat', 2, 'Cent'),
+('EUR', 'Euro', 'fiat', 2, 'Cent'),
+('GBP', 'British Pound', 'fiat', 2, 'Penny'),
+('BRL', 'Brazilian Real', 'fiat', 2, 'Centavo'),
+('CAD', 'Canadian Dollar', 'fiat', 2, 'Cent'),
+('AUD', 'Australian Dollar', 'fiat', 2, 'Cent'),
+('JPY', 'Japanese Yen', 'fiat', 0, 'Yen'),
+('MXN', 'Mexican Peso', 'fiat', 2, 'Centavo'),
 ('BTC', 'Bitcoin', 'crypto', 8, 'Satoshi'),
 ('ETH', 'Ethereum', 'crypto', 18, 'Wei'),
 ('USDT', 'Tether', 'crypto', 6, 'Micro-USDT'),
 ('USDC', 'USD Coin', 'crypto', 6, 'Micro-USDC'),
-('LTC', 'Litecoin', 'crypto', 8, 'Photon')
+('LTC', 'Litecoin', 'crypto', 8, 'Photon'),
+('SOL', 'Solana', 'crypto', 9, 'Lamport')
 ON CONFLICT (currency_code) DO NOTHING;
 
 -- System configuration for withdrawal thresholds
@@ -75,7 +83,8 @@ CREATE TABLE IF NOT EXISTS system_config (
 INSERT INTO system_config (config_key, config_value, description) VALUES
 ('withdrawal_threshold', '{"usd_amount_cents": 500000, "auto_approve": true}', 'Maximum USD amount in cents for automatic withdrawal processing'),
 ('auto_withdrawal_limit', '{"daily_usd_cents": 2000000, "weekly_usd_cents": 5000000, "monthly_usd_cents": 10000000}', 'Daily, weekly, and monthly withdrawal limits in cents'),
-('conversion_settings', '{"rounding_method": "bankers", "intermediate_precision": 10}', 'Currency conversion settings')
+('conversion_settings', '{"rounding_method": "bankers", "intermediate_precision": 10}', 'Currency conversion settings'),
+('travel_rule_threshold', '{"default_usd_cents": 100000, "jurisdictions": {}}', 'USD amount in cents from which withdrawals need Travel Rule data, with per-country overrides')
 ON CONFLICT (config_key) DO NOTHING;
 
 -- Balances table
@@ -285,6 +294,18 @@ CREATE TABLE IF NOT EXISTS withdrawals (
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
 );
 
+-- Withdrawal approvals table (four-eyes quorum for large withdrawals)
+CREATE TABLE IF NOT EXISTS withdrawal_approvals (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    withdrawal_id VARCHAR(255) NOT NULL REFERENCES withdrawals(withdrawal_id) ON DELETE CASCADE,
+    admin_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    note TEXT,
+    ip_address INET,
+    user_agent TEXT,
+    approved_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
 -- Transactions table
 CREATE TABLE IF NOT EXISTS transactions (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
@@ -325,6 +346,94 @@ CREATE TABLE IF NOT EXISTS conversion_remainders (
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
 );
 
+-- Network fees table: the fee account, one entry per on-chain fee of a deposit or withdrawal
+CREATE TABLE IF NOT EXISTS network_fees (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    chain_id VARCHAR(50) NOT NULL REFERENCES supported_chains(chain_id),
+    tx_hash VARCHAR(100) NOT NULL CHECK (tx_hash <> ''),
+    transaction_type VARCHAR(20) NOT NULL CHECK (transaction_type IN ('deposit', 'withdrawal')),
+    withdrawal_id VARCHAR(255) REFERENCES withdrawals(withdrawal_id) ON DELETE CASCADE,
+    deposit_session_id VARCHAR(255) REFERENCES deposit_sessions(session_id) ON DELETE CASCADE,
+    fee_currency VARCHAR(10) NOT NULL CHECK (fee_currency <> ''),  -- Native asset the fee was paid in
+    fee_base_units BIGINT NOT NULL CHECK (fee_base_units >= 0),  -- Fee in the native asset's base units, e.g. lamports
+    fee_payer VARCHAR(100) NOT NULL CHECK (fee_payer <> ''),  -- Account that paid the fee on chain
+    paid_by VARCHAR(10) NOT NULL CHECK (paid_by IN ('user', 'house')),
+    usd_cents BIGINT NOT NULL DEFAULT 0,  -- Fee value at transaction time
+    price_usd DECIMAL(24,8),
+    charged_currency VARCHAR(10) REFERENCES currency_config(currency_code),
+    charged_cents BIGINT NOT NULL DEFAULT 0 CHECK (charged_cents >= 0),  -- Charged to the user's balance, in minor units of charged_currency
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_network_fee_tx UNIQUE (chain_id, tx_hash)
+);
+
+-- Deposit quotes table: a fiat amount locked to a crypto amount for a session
+CREATE TABLE IF NOT EXISTS deposit_quotes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    session_id VARCHAR(255) UNIQUE NOT NULL REFERENCES deposit_sessions(session_id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    crypto_currency VARCHAR(10) NOT NULL CHECK (crypto_currency <> ''),
+    fiat_currency VARCHAR(10) NOT NULL REFERENCES currency_config(currency_code),
+    fiat_amount_cents BIGINT NOT NULL CHECK (fiat_amount_cents > 0),  -- Quoted amount in minor units of fiat_currency
+    crypto_amount DECIMAL(36,18) NOT NULL CHECK (crypto_amount > 0),
+    exchange_rate DECIMAL(24,8) NOT NULL CHECK (exchange_rate > 0),  -- fiat_currency per crypto unit
+    price_usd DECIMAL(24,8) NOT NULL CHECK (price_usd > 0),
+    slippage_bps INTEGER NOT NULL DEFAULT 0 CHECK (slippage_bps >= 0 AND slippage_bps <= 10000),
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'consumed', 'repriced')),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    settled_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Withdrawal address book: destinations a user has saved. An address can
+-- only be withdrawn to under strict mode once available_at has passed.
+CREATE TABLE IF NOT EXISTS withdrawal_addresses (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    chain_id VARCHAR(50) NOT NULL REFERENCES supported_chains(chain_id),
+    address VARCHAR(255) NOT NULL CHECK (address <> ''),
+    label VARCHAR(100) NOT NULL DEFAULT '',
+    available_at TIMESTAMP WITH TIME ZONE NOT NULL,  -- End of the cool-down started when the address was added
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_withdrawal_address UNIQUE (user_id, chain_id, address)
+);
+
+-- Withdrawal address book settings. Turning strict mode off only takes
+-- effect at strict_mode_ends_at, one cool-down after it was requested.
+CREATE TABLE IF NOT EXISTS withdrawal_address_settings (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    strict_mode BOOLEAN NOT NULL DEFAULT FALSE,
+    strict_mode_ends_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Responsible-gambling deposit limits, one per user and period. Raising or
+-- removing a limit is staged in pending_limit_usd_cents and only takes effect
+-- at pending_effective_at, one cool-off after it was asked for; a pending
+-- limit of zero removes the limit.
+CREATE TABLE IF NOT EXISTS deposit_limits (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    period VARCHAR(10) NOT NULL CHECK (period IN ('daily', 'weekly', 'monthly')),
+    limit_usd_cents BIGINT NOT NULL CHECK (limit_usd_cents > 0),
+    pending_limit_usd_cents BIGINT CHECK (pending_limit_usd_cents >= 0),
+    pending_effective_at TIMESTAMP WITH TIME ZONE,
+    set_by UUID REFERENCES users(id) ON DELETE SET NULL,  -- The user, or the admin who set the limit for them
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, period)
+);
+
+-- Self-exclusion periods. Deposits made before ends_at are held for refund.
+CREATE TABLE IF NOT EXISTS self_exclusions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL CHECK (ends_at > starts_at),
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
 -- Admin Fund Movements table
 CREATE TABLE IF NOT EXISTS admin_fund_movements (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
@@ -408,3 +517,9 @@ CREATE INDEX IF NOT EXISTS idx_admin_fund_movements_admin_id ON admin_fund_movem
 CREATE INDEX IF NOT EXISTS idx_admin_fund_movements_from_address ON admin_fund_movements(from_address);
 CREATE INDEX IF NOT EXISTS idx_admin_fund_movements_status ON admin_fund_movements(status);
 CREATE INDEX IF NOT EXISTS idx_conversion_remainders_transaction_id ON conversion_remainders(transaction_id);
+CREATE INDEX IF NOT EXISTS idx_network_fees_created_at ON network_fees(created_at);
+CREATE INDEX IF NOT EXISTS idx_deposit_quotes_user_id ON deposit_quotes(user_id);
+CREATE INDEX IF NOT EXISTS idx_withdrawal_approvals_withdrawal_id ON withdrawal_approvals(withdrawal_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_withdrawal_approvals_active ON withdrawal_approvals(withdrawal_id, admin_id) WHERE revoked_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_withdrawal_addresses_user_id ON withdrawal_addresses(user_id);
+CREATE INDEX IF NOT EXISTS idx_self_exclusions_user_id_ends_at ON self_exclusions(user_id, ends_at);
@@ -0,0 +1,17 @@
+package accountpolicyservice
+
+import (
+	"context"
+
+	"github.com/tuncanbit/tvs/internal/domain"
+)
+
+type IAccountPolicyService interface {
+	// CheckWithdrawal returns the restriction on the user's withdrawals, or
+	// nil if they may withdraw.
+	CheckWithdrawal(ctx context.Context, userID string) (*domain.AccountRestriction, error)
+	// CheckDeposit returns the restriction on crediting the user a deposit of
+	// amount cryptoCurrency, or nil if it may be credited. Deposits are never
+	// blocked, since the funds have already arrived; they are held instead.
+	CheckDeposit(ctx context.Context, userID, cryptoCurrency string, amount float64) (*domain.AccountRestriction, error)
+}
@@ -0,0 +1,135 @@
+package accountpolicyservice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/internal/application/conversionservice"
+	"github.com/tuncanbit/tvs/internal/application/pricingservice"
+	"github.com/tuncanbit/tvs/internal/domain"
+	"github.com/tuncanbit/tvs/internal/repositories/sessionrepo"
+	"github.com/tuncanbit/tvs/internal/repositories/userrepo"
+	"github.com/tuncanbit/tvs/pkg/config"
+	"github.com/tuncanbit/tvs/pkg/currency"
+	"github.com/tuncanbit/tvs/pkg/metrics"
+)
+
+type accountPolicyService struct {
+	userRepo      userrepo.IUserRepository
+	sessionRepo   sessionrepo.ISessionRepository
+	pricingSvc    pricingservice.IPricingService
+	conversionSvc conversionservice.IConversionService
+	config        config.AccountPolicyConfig
+	currencyUtils *currency.CurrencyUtils
+	logger        zerolog.Logger
+}
+
+func New(
+	userRepo userrepo.IUserRepository,
+	sessionRepo sessionrepo.ISessionRepository,
+	pricingSvc pricingservice.IPricingService,
+	conversionSvc conversionservice.IConversionService,
+	cfg config.AccountPolicyConfig,
+	logger zerolog.Logger,
+) IAccountPolicyService {
+	return &accountPolicyService{
+		userRepo:      userRepo,
+		sessionRepo:   sessionRepo,
+		pricingSvc:    pricingSvc,
+		conversionSvc: conversionSvc,
+		config:        cfg,
+		currencyUtils: currency.NewCurrencyUtils(),
+		logger:        logger.With().Str("component", "account_policy_service").Logger(),
+	}
+}
+
+func (s *accountPolicyService) CheckWithdrawal(ctx context.Context, userID string) (*domain.AccountRestriction, error) {
+	status, err := s.userRepo.GetAccountStatus(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	restriction := accountRestriction(status, domain.RestrictionBlock)
+	if restriction == nil && s.config.Enabled && status.KYCStatus != domain.KYCStatusVerified {
+		action := domain.RestrictionHold
+		if s.config.PendingKYCWithdrawals == string(domain.RestrictionBlock) {
+			action = domain.RestrictionBlock
+		}
+		restriction = &domain.AccountRestriction{
+			Code:    domain.RestrictionKYCPending,
+			Action:  action,
+			Message: "identity verification is not complete",
+		}
+	}
+	if restriction != nil {
+		metrics.Add("account_policy.withdrawals_restricted", 1)
+	}
+	return restriction, nil
+}
+
+func (s *accountPolicyService) CheckDeposit(ctx context.Context, userID, cryptoCurrency string, amount float64) (*domain.AccountRestriction, error) {
+	status, err := s.userRepo.GetAccountStatus(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	restriction := accountRestriction(status, domain.RestrictionHold)
+	if restriction == nil && s.config.Enabled && status.KYCStatus != domain.KYCStatusVerified {
+		restriction, err = s.checkDepositCap(ctx, userID, cryptoCurrency, amount)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if restriction != nil {
+		metrics.Add("account_policy.deposits_restricted", 1)
+	}
+	return restriction, nil
+}
+
+// checkDepositCap holds a deposit from a user whose KYC is pending once it
+// takes their credited deposits over the cap.
+func (s *accountPolicyService) checkDepositCap(ctx context.Context, userID, cryptoCurrency string, amount float64) (*domain.AccountRestriction, error) {
+	credited, err := s.sessionRepo.SumCreditedDeposits(ctx, userID, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	rate, err := s.pricingSvc.GetExchangeRate(ctx, cryptoCurrency, "USD")
+	if err != nil {
+		return nil, fmt.Errorf("failed to value deposit in USD: %w", err)
+	}
+	usdCents := s.conversionSvc.Convert(ctx, amount, rate.PriceUSD, "USD").Converted
+
+	limit := s.config.PendingKYCDepositCapUSDCents
+	if credited+usdCents <= limit {
+		return nil, nil
+	}
+	s.logger.Info().
+		Str("user_id", userID).
+		Int64("credited_usd_cents", credited).
+		Int64("deposit_usd_cents", usdCents).
+		Int64("cap_usd_cents", limit).
+		Msg("Deposit exceeds cap for unverified account")
+	return &domain.AccountRestriction{
+		Code:    domain.RestrictionKYCDepositCap,
+		Action:  domain.RestrictionHold,
+		Message: fmt.Sprintf("deposits above %s in total need identity verification", s.currencyUtils.Format(limit, "USD")),
+	}, nil
+}
+
+// accountRestriction applies the rules that do not depend on the amount:
+// suspended and inactive accounts and rejected KYC are restricted with
+// action. Statuses are checked in that order, so a suspension is reported
+// ahead of anything else.
+func accountRestriction(status *domain.AccountStatus, action domain.RestrictionAction) *domain.AccountRestriction {
+	switch {
+	case status.Status == domain.AccountStatusSuspended:
+		return &domain.AccountRestriction{Code: domain.RestrictionAccountSuspended, Action: action, Message: "account is suspended"}
+	case status.Status == domain.AccountStatusInactive:
+		return &domain.AccountRestriction{Code: domain.RestrictionAccountInactive, Action: action, Message: "account is inactive"}
+	case status.KYCStatus == domain.KYCStatusRejected:
+		return &domain.AccountRestriction{Code: domain.RestrictionKYCRejected, Action: action, Message: "identity verification was rejected"}
+	}
+	return nil
+}
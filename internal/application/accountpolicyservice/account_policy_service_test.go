@@ -0,0 +1,175 @@
+package accountpolicyservice
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/internal/application/pricingservice"
+	"github.com/tuncanbit/tvs/internal/domain"
+	"github.com/tuncanbit/tvs/internal/repositories/sessionrepo"
+	"github.com/tuncanbit/tvs/internal/repositories/userrepo"
+	"github.com/tuncanbit/tvs/pkg/config"
+	"github.com/tuncanbit/tvs/pkg/currency"
+)
+
+type stubUsers struct {
+	userrepo.IUserRepository
+	status domain.AccountStatus
+}
+
+func (s stubUsers) GetAccountStatus(context.Context, string) (*domain.AccountStatus, error) {
+	status := s.status
+	return &status, nil
+}
+
+type stubSessions struct {
+	sessionrepo.ISessionRepository
+	credited int64
+}
+
+func (s stubSessions) SumCreditedDeposits(context.Context, string, time.Time) (int64, error) {
+	return s.credited, nil
+}
+
+type stubPricing struct {
+	pricingservice.IPricingService
+	priceUSD float64
+}
+
+func (s stubPricing) GetExchangeRate(_ context.Context, cryptoCurrency, fiatCurrency string) (*domain.ExchangeRateResponse, error) {
+	return &domain.ExchangeRateResponse{CryptoCurrency: cryptoCurrency, FiatCurrency: fiatCurrency, PriceUSD: s.priceUSD}, nil
+}
+
+// fixedConversion converts at the exact amount times rate in cents and
+// rounds half up, standing in for the configured conversion settings.
+type fixedConversion struct{}
+
+func (fixedConversion) Convert(_ context.Context, cryptoAmount, exchangeRate float64, currencyCode string) domain.Conversion {
+	exact := cryptoAmount * exchangeRate * 100
+	converted := int64(exact + 0.5)
+	return domain.Conversion{CurrencyCode: currencyCode, Exact: exact, Converted: converted, Remainder: exact - float64(converted)}
+}
+
+func (fixedConversion) RecordRemainder(context.Context, string, domain.ConversionType, domain.Conversion) {
+}
+
+func (fixedConversion) GetRoundingDrift(context.Context, time.Time, time.Time) ([]domain.RoundingDrift, error) {
+	return nil, nil
+}
+
+func newTestService(cfg config.AccountPolicyConfig, status domain.AccountStatus, credited int64) *accountPolicyService {
+	return &accountPolicyService{
+		userRepo:      stubUsers{status: status},
+		sessionRepo:   stubSessions{credited: credited},
+		pricingSvc:    stubPricing{priceUSD: 100},
+		conversionSvc: fixedConversion{},
+		config:        cfg,
+		currencyUtils: currency.NewCurrencyUtils(),
+		logger:        zerolog.Nop(),
+	}
+}
+
+func TestAccountRestriction(t *testing.T) {
+	tests := []struct {
+		name   string
+		status domain.AccountStatus
+		want   domain.RestrictionCode
+	}{
+		{"active and verified", domain.AccountStatus{Status: domain.AccountStatusActive, KYCStatus: domain.KYCStatusVerified}, ""},
+		{"kyc pending", domain.AccountStatus{Status: domain.AccountStatusActive, KYCStatus: domain.KYCStatusPending}, ""},
+		{"kyc rejected", domain.AccountStatus{Status: domain.AccountStatusActive, KYCStatus: domain.KYCStatusRejected}, domain.RestrictionKYCRejected},
+		{"inactive", domain.AccountStatus{Status: domain.AccountStatusInactive, KYCStatus: domain.KYCStatusRejected}, domain.RestrictionAccountInactive},
+		{"suspended", domain.AccountStatus{Status: domain.AccountStatusSuspended, KYCStatus: domain.KYCStatusRejected}, domain.RestrictionAccountSuspended},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := accountRestriction(&tt.status, domain.RestrictionHold)
+			if tt.want == "" {
+				if got != nil {
+					t.Errorf("accountRestriction() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil || got.Code != tt.want || got.Action != domain.RestrictionHold {
+				t.Errorf("accountRestriction() = %+v, want %s with hold", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckWithdrawal(t *testing.T) {
+	suspended := domain.AccountStatus{Status: domain.AccountStatusSuspended, KYCStatus: domain.KYCStatusVerified}
+	pending := domain.AccountStatus{Status: domain.AccountStatusActive, KYCStatus: domain.KYCStatusPending}
+	verified := domain.AccountStatus{Status: domain.AccountStatusActive, KYCStatus: domain.KYCStatusVerified}
+
+	tests := []struct {
+		name       string
+		cfg        config.AccountPolicyConfig
+		status     domain.AccountStatus
+		wantCode   domain.RestrictionCode
+		wantAction domain.RestrictionAction
+	}{
+		{"suspended while disabled", config.AccountPolicyConfig{}, suspended, domain.RestrictionAccountSuspended, domain.RestrictionBlock},
+		{"pending while disabled", config.AccountPolicyConfig{}, pending, "", ""},
+		{"pending defaults to hold", config.AccountPolicyConfig{Enabled: true}, pending, domain.RestrictionKYCPending, domain.RestrictionHold},
+		{"pending blocked", config.AccountPolicyConfig{Enabled: true, PendingKYCWithdrawals: "block"}, pending, domain.RestrictionKYCPending, domain.RestrictionBlock},
+		{"verified", config.AccountPolicyConfig{Enabled: true, PendingKYCWithdrawals: "block"}, verified, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newTestService(tt.cfg, tt.status, 0).CheckWithdrawal(context.Background(), "user-1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantCode == "" {
+				if got != nil {
+					t.Errorf("CheckWithdrawal() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil || got.Code != tt.wantCode || got.Action != tt.wantAction {
+				t.Errorf("CheckWithdrawal() = %+v, want %s with %s", got, tt.wantCode, tt.wantAction)
+			}
+		})
+	}
+}
+
+func TestCheckDeposit(t *testing.T) {
+	enabled := config.AccountPolicyConfig{Enabled: true, PendingKYCDepositCapUSDCents: 100_000}
+	pending := domain.AccountStatus{Status: domain.AccountStatusActive, KYCStatus: domain.KYCStatusPending}
+
+	tests := []struct {
+		name     string
+		cfg      config.AccountPolicyConfig
+		status   domain.AccountStatus
+		credited int64
+		amount   float64 // at $100
+		want     domain.RestrictionCode
+	}{
+		{"under the cap", enabled, pending, 50_000, 1, ""},
+		{"up to the cap", enabled, pending, 50_000, 5, ""},
+		{"over the cap", enabled, pending, 50_000, 5.01, domain.RestrictionKYCDepositCap},
+		{"verified over the cap", enabled, domain.AccountStatus{Status: domain.AccountStatusActive, KYCStatus: domain.KYCStatusVerified}, 50_000, 10, ""},
+		{"pending while disabled", config.AccountPolicyConfig{}, pending, 50_000, 10, ""},
+		{"inactive while disabled", config.AccountPolicyConfig{}, domain.AccountStatus{Status: domain.AccountStatusInactive, KYCStatus: domain.KYCStatusVerified}, 0, 1, domain.RestrictionAccountInactive},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newTestService(tt.cfg, tt.status, tt.credited).CheckDeposit(context.Background(), "user-1", "SOL", tt.amount)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.want == "" {
+				if got != nil {
+					t.Errorf("CheckDeposit() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil || got.Code != tt.want || got.Action != domain.RestrictionHold {
+				t.Errorf("CheckDeposit() = %+v, want %s with hold", got, tt.want)
+			}
+		})
+	}
+}
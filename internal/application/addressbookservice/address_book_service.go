@@ -0,0 +1,35 @@
+package addressbookservice
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tuncanbit/tvs/internal/domain"
+)
+
+var (
+	ErrUnsupportedChain      = errors.New("addresses are not supported on this chain")
+	ErrInvalidAddress        = errors.New("invalid address")
+	ErrAddressExists         = errors.New("address is already in the address book")
+	ErrAddressNotFound       = errors.New("address book entry not found")
+	ErrAddressCoolingDown    = errors.New("address book entry is still in its cool-down")
+	ErrAddressNotAllowlisted = errors.New("strict mode only allows withdrawals to address book entries")
+)
+
+type IAddressBookService interface {
+	GetAddressBook(ctx context.Context, userID string) (*domain.AddressBook, error)
+	// AddAddress saves a destination. It can be withdrawn to once the
+	// configured cool-down has passed.
+	AddAddress(ctx context.Context, userID string, req domain.AddWithdrawalAddressRequest, info domain.RequestInfo) (*domain.WithdrawalAddress, error)
+	UpdateLabel(ctx context.Context, userID, id string, req domain.UpdateWithdrawalAddressRequest, info domain.RequestInfo) (*domain.WithdrawalAddress, error)
+	RemoveAddress(ctx context.Context, userID, id string, info domain.RequestInfo) error
+	// SetStrictMode turns strict mode on immediately. Turning it off is
+	// scheduled one cool-down ahead, so a taken-over account cannot lift it
+	// and withdraw at once.
+	SetStrictMode(ctx context.Context, userID string, enabled bool, info domain.RequestInfo) (*domain.AddressBookSettings, error)
+	// CheckDestination returns the user's entry for a normalized address,
+	// or nil if it has none. It returns ErrAddressCoolingDown for entries
+	// still in their cool-down and, under strict mode,
+	// ErrAddressNotAllowlisted for addresses without an entry.
+	CheckDestination(ctx context.Context, userID, chainID, address string) (*domain.WithdrawalAddress, error)
+}
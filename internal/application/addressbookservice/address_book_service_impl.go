@@ -0,0 +1,267 @@
+package addressbookservice
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/internal/domain"
+	"github.com/tuncanbit/tvs/internal/repositories/addressbookrepo"
+	"github.com/tuncanbit/tvs/internal/repositories/auditrepo"
+	"github.com/tuncanbit/tvs/internal/server/websocket"
+	"github.com/tuncanbit/tvs/pkg/address"
+	"github.com/tuncanbit/tvs/pkg/config"
+	"github.com/tuncanbit/tvs/pkg/metrics"
+)
+
+const (
+	auditEntityAddress  = "withdrawal_address"
+	auditEntitySettings = "withdrawal_address_settings"
+
+	auditActionAdded           = "withdrawal_address_added"
+	auditActionLabelUpdated    = "withdrawal_address_label_updated"
+	auditActionRemoved         = "withdrawal_address_removed"
+	auditActionStrictEnabled   = "withdrawal_address_strict_enabled"
+	auditActionStrictDisabling = "withdrawal_address_strict_disabling"
+	auditActionStrictDisabled  = "withdrawal_address_strict_disabled"
+)
+
+type addressBookService struct {
+	addressBookRepo addressbookrepo.IAddressBookRepository
+	auditRepo       auditrepo.IAuditRepository
+	wsHub           *websocket.WsHub
+	config          config.WithdrawalsConfig
+	logger          zerolog.Logger
+}
+
+func New(
+	addressBookRepo addressbookrepo.IAddressBookRepository,
+	auditRepo auditrepo.IAuditRepository,
+	wsHub *websocket.WsHub,
+	cfg config.WithdrawalsConfig,
+	logger zerolog.Logger,
+) IAddressBookService {
+	return &addressBookService{
+		addressBookRepo: addressBookRepo,
+		auditRepo:       auditRepo,
+		wsHub:           wsHub,
+		config:          cfg,
+		logger:          logger.With().Str("component", "address_book_service").Logger(),
+	}
+}
+
+func (s *addressBookService) GetAddressBook(ctx context.Context, userID string) (*domain.AddressBook, error) {
+	settings, err := s.addressBookRepo.GetSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	addresses, err := s.addressBookRepo.ListAddresses(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.AddressBook{Settings: *settings, Addresses: addresses}, nil
+}
+
+func (s *addressBookService) AddAddress(ctx context.Context, userID string, req domain.AddWithdrawalAddressRequest, info domain.RequestInfo) (*domain.WithdrawalAddress, error) {
+	normalized, err := address.Validate(req.ChainID, req.Address)
+	switch {
+	case errors.Is(err, address.ErrUnsupportedChain):
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedChain, req.ChainID)
+	case err != nil:
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAddress, err)
+	}
+
+	entry, err := s.addressBookRepo.CreateAddress(ctx, domain.WithdrawalAddress{
+		UserID:      userID,
+		ChainID:     req.ChainID,
+		Address:     normalized,
+		Label:       strings.TrimSpace(req.Label),
+		AvailableAt: time.Now().Add(s.config.AddressCooldown),
+	})
+	if errors.Is(err, addressbookrepo.ErrAddressExists) {
+		return nil, ErrAddressExists
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, auditActionAdded, auditEntityAddress, entry.ID, userID, info, nil, map[string]interface{}{
+		"chain_id":     entry.ChainID,
+		"address":      entry.Address,
+		"label":        entry.Label,
+		"available_at": entry.AvailableAt,
+	})
+	s.wsHub.BroadcastAddressBook(domain.AddressBookEvent{UserID: userID, Action: domain.AddressBookActionAdded, Address: entry})
+	metrics.Add("address_book.added", 1)
+	s.logger.Info().
+		Str("user_id", userID).
+		Str("chain_id", entry.ChainID).
+		Str("address", entry.Address).
+		Time("available_at", entry.AvailableAt).
+		Msg("Withdrawal address added")
+	return entry, nil
+}
+
+func (s *addressBookService) UpdateLabel(ctx context.Context, userID, id string, req domain.UpdateWithdrawalAddressRequest, info domain.RequestInfo) (*domain.WithdrawalAddress, error) {
+	existing, err := s.findEntry(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := s.addressBookRepo.UpdateLabel(ctx, userID, id, strings.TrimSpace(req.Label))
+	if errors.Is(err, addressbookrepo.ErrAddressNotFound) {
+		return nil, ErrAddressNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, auditActionLabelUpdated, auditEntityAddress, entry.ID, userID, info,
+		map[string]interface{}{"label": existing.Label},
+		map[string]interface{}{"label": entry.Label})
+	s.wsHub.BroadcastAddressBook(domain.AddressBookEvent{UserID: userID, Action: domain.AddressBookActionLabelUpdated, Address: entry})
+	return entry, nil
+}
+
+func (s *addressBookService) RemoveAddress(ctx context.Context, userID, id string, info domain.RequestInfo) error {
+	entry, err := s.addressBookRepo.DeleteAddress(ctx, userID, id)
+	if errors.Is(err, addressbookrepo.ErrAddressNotFound) {
+		return ErrAddressNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	s.audit(ctx, auditActionRemoved, auditEntityAddress, entry.ID, userID, info, map[string]interface{}{
+		"chain_id": entry.ChainID,
+		"address":  entry.Address,
+		"label":    entry.Label,
+	}, nil)
+	s.wsHub.BroadcastAddressBook(domain.AddressBookEvent{UserID: userID, Action: domain.AddressBookActionRemoved, Address: entry})
+	s.logger.Info().
+		Str("user_id", userID).
+		Str("chain_id", entry.ChainID).
+		Str("address", entry.Address).
+		Msg("Withdrawal address removed")
+	return nil
+}
+
+func (s *addressBookService) SetStrictMode(ctx context.Context, userID string, enabled bool, info domain.RequestInfo) (*domain.AddressBookSettings, error) {
+	current, err := s.addressBookRepo.GetSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var action string
+	var endsAt *time.Time
+	strict := enabled
+	switch {
+	case enabled:
+		action = auditActionStrictEnabled
+	case !current.Strict(now):
+		action = auditActionStrictDisabled
+	case current.StrictModeEndsAt != nil:
+		// Already scheduled to lapse; asking again does not restart the
+		// cool-down.
+		return current, nil
+	default:
+		action = auditActionStrictDisabling
+		strict = true
+		lapse := now.Add(s.config.AddressCooldown)
+		endsAt = &lapse
+	}
+	if current.StrictMode == strict && current.StrictModeEndsAt == nil && endsAt == nil {
+		return current, nil
+	}
+
+	settings, err := s.addressBookRepo.SaveSettings(ctx, userID, strict, endsAt)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, action, auditEntitySettings, userID, userID, info,
+		map[string]interface{}{"strict_mode": current.StrictMode, "strict_mode_ends_at": current.StrictModeEndsAt},
+		map[string]interface{}{"strict_mode": settings.StrictMode, "strict_mode_ends_at": settings.StrictModeEndsAt})
+	s.wsHub.BroadcastAddressBook(domain.AddressBookEvent{UserID: userID, Action: domain.AddressBookActionSettingsUpdated, Settings: settings})
+	s.logger.Info().
+		Str("user_id", userID).
+		Str("action", action).
+		Msg("Address book strict mode changed")
+	return settings, nil
+}
+
+func (s *addressBookService) CheckDestination(ctx context.Context, userID, chainID, toAddress string) (*domain.WithdrawalAddress, error) {
+	entry, err := s.addressBookRepo.GetAddress(ctx, userID, chainID, toAddress)
+	if errors.Is(err, addressbookrepo.ErrAddressNotFound) {
+		entry, err = nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	settings, err := s.addressBookRepo.GetSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// A new entry waits out its cool-down whatever the mode, so an account
+	// taken over cannot add an address and withdraw to it at once.
+	now := time.Now()
+	if entry != nil && !entry.Available(now) {
+		metrics.Add("address_book.blocked", 1)
+		return entry, fmt.Errorf("%w until %s", ErrAddressCoolingDown, entry.AvailableAt.UTC().Format(time.RFC3339))
+	}
+	if entry == nil && settings.Strict(now) {
+		metrics.Add("address_book.blocked", 1)
+		return nil, ErrAddressNotAllowlisted
+	}
+	return entry, nil
+}
+
+func (s *addressBookService) findEntry(ctx context.Context, userID, id string) (*domain.WithdrawalAddress, error) {
+	addresses, err := s.addressBookRepo.ListAddresses(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range addresses {
+		if addresses[i].ID == id {
+			return &addresses[i], nil
+		}
+	}
+	return nil, ErrAddressNotFound
+}
+
+// audit writes an address book change to audit_logs. Changes are made by
+// the user, so the entry has no admin and the user is recorded in its
+// values. A failed write is logged rather than failing the change.
+func (s *addressBookService) audit(ctx context.Context, action, entityType, entityID, userID string, info domain.RequestInfo, oldValues, newValues map[string]interface{}) {
+	if newValues == nil {
+		newValues = map[string]interface{}{}
+	}
+	newValues["user_id"] = userID
+	var oldJSON json.RawMessage
+	if oldValues != nil {
+		oldJSON, _ = json.Marshal(oldValues)
+	}
+	newJSON, _ := json.Marshal(newValues)
+
+	if err := s.auditRepo.CreateAuditLog(ctx, domain.AuditLog{
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		OldValues:  oldJSON,
+		NewValues:  newJSON,
+		IPAddress:  info.IPAddress,
+		UserAgent:  info.UserAgent,
+	}); err != nil {
+		metrics.Add("audit.write_failures", 1)
+		s.logger.Error().
+			Err(err).
+			Str("action", action).
+			Str("entity_id", entityID).
+			Msg("Failed to write audit log")
+	}
+}
@@ -0,0 +1,77 @@
+package addressbookservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/internal/domain"
+	"github.com/tuncanbit/tvs/internal/repositories/addressbookrepo"
+)
+
+const (
+	testUser    = "8d3f0c1e-5b7a-4f3e-9a51-2f6c4b1d0e77"
+	testChain   = "sol-mainnet"
+	testAddress = "FVen3X669xLzsi6N2V91DoiyzHzg1uAgqiT8jZ9nS96Z"
+)
+
+// memoryAddressBook holds at most one entry and the user's settings.
+type memoryAddressBook struct {
+	addressbookrepo.IAddressBookRepository
+	entry    *domain.WithdrawalAddress
+	settings domain.AddressBookSettings
+}
+
+func (m *memoryAddressBook) GetAddress(_ context.Context, userID, chainID, address string) (*domain.WithdrawalAddress, error) {
+	if m.entry == nil || m.entry.UserID != userID || m.entry.ChainID != chainID || m.entry.Address != address {
+		return nil, addressbookrepo.ErrAddressNotFound
+	}
+	entry := *m.entry
+	return &entry, nil
+}
+
+func (m *memoryAddressBook) GetSettings(context.Context, string) (*domain.AddressBookSettings, error) {
+	settings := m.settings
+	return &settings, nil
+}
+
+func TestCheckDestination(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	cooling := &domain.WithdrawalAddress{UserID: testUser, ChainID: testChain, Address: testAddress, AvailableAt: now.Add(time.Hour)}
+	available := &domain.WithdrawalAddress{UserID: testUser, ChainID: testChain, Address: testAddress, AvailableAt: past}
+
+	tests := []struct {
+		name      string
+		entry     *domain.WithdrawalAddress
+		settings  domain.AddressBookSettings
+		wantEntry bool
+		wantErr   error
+	}{
+		{name: "unsaved address"},
+		{name: "available entry", entry: available, wantEntry: true},
+		{name: "entry cooling down", entry: cooling, wantEntry: true, wantErr: ErrAddressCoolingDown},
+		{name: "strict mode, unsaved address", settings: domain.AddressBookSettings{StrictMode: true}, wantErr: ErrAddressNotAllowlisted},
+		{name: "strict mode, available entry", entry: available, settings: domain.AddressBookSettings{StrictMode: true}, wantEntry: true},
+		{name: "strict mode, entry cooling down", entry: cooling, settings: domain.AddressBookSettings{StrictMode: true}, wantEntry: true, wantErr: ErrAddressCoolingDown},
+		{name: "strict mode lapsed, unsaved address", settings: domain.AddressBookSettings{StrictMode: true, StrictModeEndsAt: &past}},
+		{name: "strict mode lapsed, entry cooling down", entry: cooling, settings: domain.AddressBookSettings{StrictMode: true, StrictModeEndsAt: &past}, wantEntry: true, wantErr: ErrAddressCoolingDown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &addressBookService{
+				addressBookRepo: &memoryAddressBook{entry: tt.entry, settings: tt.settings},
+				logger:          zerolog.Nop(),
+			}
+			entry, err := s.CheckDestination(context.Background(), testUser, testChain, testAddress)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CheckDestination() error = %v, want %v", err, tt.wantErr)
+			}
+			if (entry != nil) != tt.wantEntry {
+				t.Errorf("CheckDestination() entry = %+v, want entry %v", entry, tt.wantEntry)
+			}
+		})
+	}
+}
@@ -12,4 +12,5 @@ type IAuthService interface {
 	GenerateJWTWithVerification(ctx context.Context, userID uuid.UUID, isVerified, emailVerified, phoneVerified bool) (string, error)
 	VerifyAPIKey(ctx context.Context, apiKey string) error
 	SaveUserSession(ctx context.Context, session *domain.UserSession) error
+	IsAdmin(ctx context.Context, userID string) (bool, error)
 }
@@ -133,6 +133,14 @@ func (s *AuthService) SaveUserSession(ctx context.Context, session *domain.UserS
 	return nil
 }
 
+func (s *AuthService) IsAdmin(ctx context.Context, userID string) (bool, error) {
+	user, err := s.authRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return user.UserType == domain.UserTypeAdmin, nil
+}
+
 func (s *AuthService) VerifyAPIKey(ctx context.Context, apiKey string) error {
 	if apiKey == "" {
 		return errors.New("invalid API key")
@@ -0,0 +1,14 @@
+package conversionservice
+
+import (
+	"context"
+	"time"
+
+	"github.com/tuncanbit/tvs/internal/domain"
+)
+
+type IConversionService interface {
+	Convert(ctx context.Context, cryptoAmount, exchangeRate float64, currencyCode string) domain.Conversion
+	RecordRemainder(ctx context.Context, transactionID string, conversionType domain.ConversionType, conversion domain.Conversion)
+	GetRoundingDrift(ctx context.Context, from, to time.Time) ([]domain.RoundingDrift, error)
+}
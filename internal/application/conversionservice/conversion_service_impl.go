@@ -0,0 +1,90 @@
+package conversionservice
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/internal/domain"
+	"github.com/tuncanbit/tvs/internal/repositories/configrepo"
+	"github.com/tuncanbit/tvs/internal/repositories/conversionrepo"
+	"github.com/tuncanbit/tvs/pkg/currency"
+)
+
+const conversionSettingsKey = "conversion_settings"
+
+var defaultSettings = domain.ConversionSettings{
+	RoundingMethod:        currency.RoundingBankers,
+	IntermediatePrecision: 10,
+}
+
+type conversionService struct {
+	configRepo     configrepo.IConfigRepository
+	conversionRepo conversionrepo.IConversionRepository
+	currencyUtils  *currency.CurrencyUtils
+	logger         zerolog.Logger
+}
+
+func New(configRepo configrepo.IConfigRepository, conversionRepo conversionrepo.IConversionRepository, logger zerolog.Logger) IConversionService {
+	return &conversionService{
+		configRepo:     configRepo,
+		conversionRepo: conversionRepo,
+		currencyUtils:  currency.NewCurrencyUtils(),
+		logger:         logger.With().Str("component", "conversion_service").Logger(),
+	}
+}
+
+// Convert converts a crypto amount into minor units of currencyCode using the
+// rounding method and precision from system_config.conversion_settings.
+func (s *conversionService) Convert(ctx context.Context, cryptoAmount, exchangeRate float64, currencyCode string) domain.Conversion {
+	settings := s.settings(ctx)
+	converted, exact := s.currencyUtils.ConvertToMinorUnits(cryptoAmount, exchangeRate, currencyCode, settings.RoundingMethod, settings.IntermediatePrecision)
+	return domain.Conversion{
+		CurrencyCode: currencyCode,
+		Exact:        exact,
+		Converted:    converted,
+		Remainder:    exact - float64(converted),
+	}
+}
+
+// RecordRemainder stores the rounding remainder of a conversion. Failures are
+// logged rather than returned so they never block crediting a user.
+func (s *conversionService) RecordRemainder(ctx context.Context, transactionID string, conversionType domain.ConversionType, conversion domain.Conversion) {
+	if err := s.conversionRepo.SaveRemainder(ctx, transactionID, conversionType, conversion); err != nil {
+		s.logger.Error().
+			Err(err).
+			Str("transaction_id", transactionID).
+			Str("currency", conversion.CurrencyCode).
+			Float64("remainder", conversion.Remainder).
+			Msg("Failed to record conversion remainder")
+	}
+}
+
+func (s *conversionService) GetRoundingDrift(ctx context.Context, from, to time.Time) ([]domain.RoundingDrift, error) {
+	return s.conversionRepo.GetRoundingDrift(ctx, from, to)
+}
+
+func (s *conversionService) settings(ctx context.Context) domain.ConversionSettings {
+	raw, err := s.configRepo.GetConfig(ctx, conversionSettingsKey)
+	if err != nil {
+		s.logger.Warn().Err(err).Msg("Failed to load conversion settings, using defaults")
+		return defaultSettings
+	}
+
+	settings := defaultSettings
+	if err := json.Unmarshal(raw, &settings); err != nil {
+		s.logger.Warn().Err(err).Msg("Invalid conversion settings, using defaults")
+		return defaultSettings
+	}
+
+	switch settings.RoundingMethod {
+	case currency.RoundingBankers, currency.RoundingHalfUp, currency.RoundingFloor:
+	default:
+		s.logger.Warn().
+			Str("rounding_method", settings.RoundingMethod).
+			Msg("Unknown rounding method, using bankers")
+		settings.RoundingMethod = currency.RoundingBankers
+	}
+	return settings
+}
@@ -0,0 +1,10 @@
+package executorservice
+
+import "context"
+
+type IExecutorService interface {
+	// Start signs and broadcasts approved API withdrawals from the hot
+	// wallets until ctx is done, writing back each transaction hash once
+	// the transaction confirms.
+	Start(ctx context.Context)
+}
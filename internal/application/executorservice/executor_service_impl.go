@@ -0,0 +1,366 @@
+package executorservice
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/internal/application/limitservice"
+	"github.com/tuncanbit/tvs/internal/domain"
+	"github.com/tuncanbit/tvs/internal/infrastructure/rpc"
+	"github.com/tuncanbit/tvs/internal/infrastructure/signer"
+	"github.com/tuncanbit/tvs/internal/repositories/withdrawalrepo"
+	"github.com/tuncanbit/tvs/pkg/config"
+	"github.com/tuncanbit/tvs/pkg/metrics"
+	"github.com/tuncanbit/tvs/pkg/solana"
+)
+
+const (
+	batchSize          = 50
+	defaultInterval    = 15 * time.Second
+	defaultMaxAttempts = 3
+	// signingCutoff stops new transactions this long before the verifier
+	// fails a withdrawal for missing its broadcast timeout, so nothing can
+	// land after the reservation has been released.
+	signingCutoff = 10 * time.Minute
+)
+
+type executorService struct {
+	withdrawalRepo   withdrawalrepo.IWithdrawalRepository
+	limitSvc         limitservice.ILimitService
+	solanaRPC        rpc.SolanaRPC
+	signer           signer.Signer
+	config           config.ExecutorConfig
+	broadcastTimeout time.Duration
+	logger           zerolog.Logger
+}
+
+func New(
+	withdrawalRepo withdrawalrepo.IWithdrawalRepository,
+	limitSvc limitservice.ILimitService,
+	solanaRPC rpc.SolanaRPC,
+	signer signer.Signer,
+	cfg config.ExecutorConfig,
+	broadcastTimeout time.Duration,
+	logger zerolog.Logger,
+) IExecutorService {
+	return &executorService{
+		withdrawalRepo:   withdrawalRepo,
+		limitSvc:         limitSvc,
+		solanaRPC:        solanaRPC,
+		signer:           signer,
+		config:           cfg,
+		broadcastTimeout: broadcastTimeout,
+		logger:           logger.With().Str("component", "withdrawal_executor").Logger(),
+	}
+}
+
+func (s *executorService) Start(ctx context.Context) {
+	interval := s.config.Interval
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.processWithdrawals(ctx); err != nil {
+					s.logger.Error().Err(err).Msg("Failed to process withdrawals awaiting broadcast")
+				}
+			}
+		}
+	}()
+}
+
+func (s *executorService) processWithdrawals(ctx context.Context) error {
+	withdrawals, err := s.withdrawalRepo.ListAwaitingBroadcast(ctx, batchSize)
+	if err != nil {
+		return err
+	}
+	for _, withdrawal := range withdrawals {
+		if err := s.execute(ctx, withdrawal); err != nil {
+			metrics.Add("withdrawals.executor.errors", 1)
+			s.logger.Error().
+				Str("withdrawal_id", withdrawal.WithdrawalID).
+				Err(err).
+				Msg("Failed to execute withdrawal")
+		}
+	}
+	return nil
+}
+
+// execute pays out one withdrawal. A withdrawal with an earlier transaction
+// is only signed again once that transaction failed on chain or its
+// blockhash expired, so at most one payout can ever land.
+func (s *executorService) execute(ctx context.Context, withdrawal domain.Withdrawal) error {
+	approved, err := s.limitSvc.EnforceWithdrawalLimits(ctx, &withdrawal)
+	if err != nil {
+		return fmt.Errorf("failed to check limits: %w", err)
+	}
+	if !approved {
+		return nil
+	}
+
+	clusterType, err := rpc.ClusterTypeForChain(withdrawal.ChainID)
+	if err != nil {
+		return err
+	}
+
+	var metadata domain.WithdrawalMetadata
+	if len(withdrawal.Metadata) > 0 {
+		if err := json.Unmarshal(withdrawal.Metadata, &metadata); err != nil {
+			return fmt.Errorf("invalid metadata: %w", err)
+		}
+	}
+	var previous domain.WithdrawalBroadcast
+	if metadata.Broadcast != nil {
+		previous = *metadata.Broadcast
+	}
+	if previous.Signature != "" {
+		settled, err := s.reconcile(ctx, withdrawal, clusterType, previous)
+		if err != nil || settled {
+			return err
+		}
+	}
+
+	maxAttempts := s.config.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxAttempts
+	}
+	if previous.Attempt >= maxAttempts {
+		s.logger.Warn().
+			Str("withdrawal_id", withdrawal.WithdrawalID).
+			Int("attempts", previous.Attempt).
+			Msg("Withdrawal exhausted broadcast attempts, leaving it to time out")
+		return nil
+	}
+	if s.broadcastTimeout > 0 && time.Since(metadata.PayableSince(withdrawal.CreatedAt)) > s.broadcastTimeout-signingCutoff {
+		return nil
+	}
+	if !s.signer.HasKey(withdrawal.SourceWalletAddress) {
+		return fmt.Errorf("%w %s", signer.ErrUnknownKey, withdrawal.SourceWalletAddress)
+	}
+
+	message, lastValidBlockHeight, err := s.buildMessage(ctx, withdrawal, clusterType)
+	if err != nil {
+		return err
+	}
+	transaction := solana.Transaction{Message: message}
+	messageBytes := message.Serialize()
+	for _, key := range message.Signers() {
+		signature, err := s.signer.Sign(ctx, key.String(), messageBytes)
+		if err != nil {
+			return fmt.Errorf("failed to sign: %w", err)
+		}
+		transaction.Signatures = append(transaction.Signatures, signature)
+	}
+	raw, err := transaction.Serialize()
+	if err != nil {
+		return err
+	}
+
+	broadcast := domain.WithdrawalBroadcast{
+		Signature:            transaction.ID(),
+		Blockhash:            message.RecentBlockhash.String(),
+		LastValidBlockHeight: lastValidBlockHeight,
+		Attempt:              previous.Attempt + 1,
+		SignedAt:             time.Now(),
+	}
+	broadcastJSON, err := json.Marshal(broadcast)
+	if err != nil {
+		return fmt.Errorf("failed to marshal broadcast: %w", err)
+	}
+	claimed, err := s.withdrawalRepo.ClaimBroadcast(ctx, withdrawal.WithdrawalID, previous.Signature, broadcastJSON)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		s.logger.Info().
+			Str("withdrawal_id", withdrawal.WithdrawalID).
+			Msg("Withdrawal claimed by another executor, skipping")
+		return nil
+	}
+
+	if _, err := s.solanaRPC.SendTransaction(ctx, clusterType, raw); err != nil {
+		// The claim stays in place; the transaction is retried with a new
+		// blockhash once this one has expired.
+		broadcast.Error = err.Error()
+		if failedJSON, marshalErr := json.Marshal(broadcast); marshalErr == nil {
+			if _, claimErr := s.withdrawalRepo.ClaimBroadcast(ctx, withdrawal.WithdrawalID, broadcast.Signature, failedJSON); claimErr != nil {
+				s.logger.Err(claimErr).Str("withdrawal_id", withdrawal.WithdrawalID).Msg("Failed to record broadcast error")
+			}
+		}
+		return fmt.Errorf("failed to send transaction %s: %w", broadcast.Signature, err)
+	}
+
+	metrics.Add("withdrawals.executor.sent", 1)
+	s.logger.Info().
+		Str("withdrawal_id", withdrawal.WithdrawalID).
+		Str("signature", broadcast.Signature).
+		Int("attempt", broadcast.Attempt).
+		Msg("Withdrawal transaction sent")
+	// Confirmation is picked up by reconcile on a later pass, so one slow
+	// transaction does not hold up the rest of the batch.
+	return nil
+}
+
+// reconcile settles an earlier transaction. It reports settled when the
+// transaction confirmed or may still land, and false when it can no longer
+// land and the withdrawal may be signed again.
+func (s *executorService) reconcile(ctx context.Context, withdrawal domain.Withdrawal, clusterType domain.SolanaClusterType, broadcast domain.WithdrawalBroadcast) (bool, error) {
+	status, err := s.solanaRPC.GetSignatureStatus(ctx, clusterType, broadcast.Signature)
+	if err != nil {
+		return true, fmt.Errorf("failed to get status of %s: %w", broadcast.Signature, err)
+	}
+	if status != nil {
+		if status.Err != nil {
+			metrics.Add("withdrawals.executor.failed_on_chain", 1)
+			s.logger.Warn().
+				Str("withdrawal_id", withdrawal.WithdrawalID).
+				Str("signature", broadcast.Signature).
+				Interface("error", status.Err).
+				Msg("Withdrawal transaction failed on chain")
+			return false, nil
+		}
+		if isConfirmed(status) {
+			return true, s.recordTxHash(ctx, withdrawal, broadcast.Signature)
+		}
+		return true, nil
+	}
+
+	height, err := s.solanaRPC.GetBlockHeight(ctx, clusterType)
+	if err != nil {
+		return true, fmt.Errorf("failed to get block height: %w", err)
+	}
+	return height <= broadcast.LastValidBlockHeight, nil
+}
+
+func (s *executorService) recordTxHash(ctx context.Context, withdrawal domain.Withdrawal, signature string) error {
+	updated, err := s.withdrawalRepo.SetTxHash(ctx, withdrawal.WithdrawalID, signature)
+	if err != nil {
+		return err
+	}
+	if updated {
+		metrics.Add("withdrawals.executor.confirmed", 1)
+		s.logger.Info().
+			Str("withdrawal_id", withdrawal.WithdrawalID).
+			Str("tx_hash", signature).
+			Msg("Withdrawal transaction confirmed")
+	}
+	return nil
+}
+
+// buildMessage compiles the payout transfer from the withdrawal's source
+// wallet, which also pays the network fee. SPL payouts go through the mint's
+// token program, into the destination itself when it is a token account of
+// the mint and otherwise into its associated token account.
+func (s *executorService) buildMessage(ctx context.Context, withdrawal domain.Withdrawal, clusterType domain.SolanaClusterType) (solana.Message, uint64, error) {
+	from, err := solana.ParsePublicKey(withdrawal.SourceWalletAddress)
+	if err != nil {
+		return solana.Message{}, 0, fmt.Errorf("invalid source wallet: %w", err)
+	}
+	to, err := solana.ParsePublicKey(withdrawal.ToAddress)
+	if err != nil {
+		return solana.Message{}, 0, fmt.Errorf("invalid destination: %w", err)
+	}
+	tokenType, err := rpc.TokenTypeForCrypto(withdrawal.CryptoCurrency)
+	if err != nil {
+		return solana.Message{}, 0, err
+	}
+	decimals, err := s.solanaRPC.GetDecimals(clusterType, tokenType)
+	if err != nil {
+		return solana.Message{}, 0, err
+	}
+	amount, err := baseUnits(withdrawal.CryptoAmount, decimals)
+	if err != nil {
+		return solana.Message{}, 0, err
+	}
+
+	var instructions []solana.Instruction
+	if tokenType == domain.SPLTokenTypeSOL {
+		instructions = append(instructions, solana.SystemTransfer(from, to, amount))
+	} else {
+		mintAddress, err := s.solanaRPC.GetMintAddress(clusterType, tokenType)
+		if err != nil {
+			return solana.Message{}, 0, err
+		}
+		mint, err := solana.ParsePublicKey(mintAddress)
+		if err != nil {
+			return solana.Message{}, 0, fmt.Errorf("invalid mint: %w", err)
+		}
+		destination, err := s.solanaRPC.InspectTokenDestination(ctx, clusterType, tokenType, withdrawal.ToAddress)
+		if err != nil {
+			return solana.Message{}, 0, fmt.Errorf("failed to check destination: %w", err)
+		}
+		instructions, err = tokenPayoutInstructions(from, to, mint, *destination, amount, uint8(decimals))
+		if err != nil {
+			return solana.Message{}, 0, err
+		}
+	}
+
+	blockhash, lastValidBlockHeight, err := s.solanaRPC.GetLatestBlockhash(ctx, clusterType)
+	if err != nil {
+		return solana.Message{}, 0, fmt.Errorf("failed to get blockhash: %w", err)
+	}
+	message, err := solana.NewMessage(from, blockhash, instructions)
+	if err != nil {
+		return solana.Message{}, 0, err
+	}
+	return message, lastValidBlockHeight, nil
+}
+
+// tokenPayoutInstructions transfers amount of mint from the source wallet's
+// associated token account to destination. A wallet destination is paid
+// into its associated token account, preceded by an idempotent create even
+// when the account existed at inspection, since it may be closed before the
+// transaction lands.
+func tokenPayoutInstructions(from, to, mint solana.PublicKey, destination domain.TokenDestination, amount uint64, decimals uint8) ([]solana.Instruction, error) {
+	program, err := solana.ParsePublicKey(destination.TokenProgram)
+	if err != nil {
+		return nil, fmt.Errorf("invalid token program: %w", err)
+	}
+	tokenAccount, err := solana.ParsePublicKey(destination.TokenAccount)
+	if err != nil {
+		return nil, fmt.Errorf("invalid destination token account: %w", err)
+	}
+	source, err := solana.AssociatedTokenAddress(from, mint, program)
+	if err != nil {
+		return nil, err
+	}
+
+	var instructions []solana.Instruction
+	if !destination.IsTokenAccount {
+		instructions = append(instructions, solana.CreateAssociatedTokenAccountIdempotent(from, tokenAccount, to, mint, program))
+	}
+	return append(instructions, solana.TokenTransferChecked(program, source, mint, tokenAccount, from, amount, decimals)), nil
+}
+
+// baseUnits converts a decimal crypto amount to the token's base units,
+// refusing amounts with more precision than the token has.
+func baseUnits(amount string, decimals int) (uint64, error) {
+	value, ok := new(big.Rat).SetString(amount)
+	if !ok {
+		return 0, fmt.Errorf("invalid crypto amount %q", amount)
+	}
+	value.Mul(value, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)))
+	if !value.IsInt() {
+		return 0, fmt.Errorf("crypto amount %s has more than %d decimals", amount, decimals)
+	}
+	units := value.Num()
+	if units.Sign() <= 0 || !units.IsUint64() {
+		return 0, errors.New("crypto amount out of range")
+	}
+	return units.Uint64(), nil
+}
+
+func isConfirmed(status *domain.SignatureStatus) bool {
+	return status.ConfirmationStatus == "confirmed" || status.ConfirmationStatus == "finalized"
+}
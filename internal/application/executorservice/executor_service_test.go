@@ -0,0 +1,79 @@
+package executorservice
+
+import (
+	"testing"
+
+	"github.com/tuncanbit/tvs/internal/domain"
+	"github.com/tuncanbit/tvs/pkg/solana"
+)
+
+var (
+	hotWallet = solana.MustParsePublicKey("FVen3X669xLzsi6N2V91DoiyzHzg1uAgqiT8jZ9nS96Z")
+	recipient = solana.MustParsePublicKey("586Z7H2vpX9qNhN2T4e9Utugie3ogjbxzGaMtM3E6HR5")
+	usdcMint  = solana.MustParsePublicKey("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v")
+)
+
+func TestTokenPayoutInstructions(t *testing.T) {
+	recipientAccount, err := solana.AssociatedTokenAddress(recipient, usdcMint, solana.TokenProgramID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		destination domain.TokenDestination
+		wantCreate  bool
+	}{
+		{
+			name: "wallet without token account",
+			destination: domain.TokenDestination{
+				TokenAccount:         recipientAccount.String(),
+				RequiresTokenAccount: true,
+			},
+			wantCreate: true,
+		},
+		{
+			// The account may be closed before the payout lands.
+			name:        "wallet with token account",
+			destination: domain.TokenDestination{TokenAccount: recipientAccount.String()},
+			wantCreate:  true,
+		},
+		{
+			name:        "token account",
+			destination: domain.TokenDestination{TokenAccount: recipientAccount.String(), IsTokenAccount: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.destination.TokenProgram = solana.TokenProgramID.String()
+			instructions, err := tokenPayoutInstructions(hotWallet, recipient, usdcMint, tt.destination, 1_000_000, 6)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := 1
+			if tt.wantCreate {
+				want = 2
+			}
+			if len(instructions) != want {
+				t.Fatalf("got %d instructions, want %d", len(instructions), want)
+			}
+			if tt.wantCreate {
+				create := instructions[0]
+				if create.ProgramID != solana.AssociatedTokenProgramID {
+					t.Errorf("first instruction program = %s, want %s", create.ProgramID, solana.AssociatedTokenProgramID)
+				}
+				if create.Accounts[1].PublicKey != recipientAccount || create.Accounts[2].PublicKey != recipient {
+					t.Errorf("create instruction targets %s of %s, want %s of %s", create.Accounts[1].PublicKey, create.Accounts[2].PublicKey, recipientAccount, recipient)
+				}
+			}
+			transfer := instructions[len(instructions)-1]
+			if transfer.ProgramID != solana.TokenProgramID {
+				t.Errorf("transfer program = %s, want %s", transfer.ProgramID, solana.TokenProgramID)
+			}
+			if transfer.Accounts[2].PublicKey != recipientAccount {
+				t.Errorf("transfer destination = %s, want %s", transfer.Accounts[2].PublicKey, recipientAccount)
+			}
+		})
+	}
+}
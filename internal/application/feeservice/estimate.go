@@ -0,0 +1,224 @@
+package feeservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tuncanbit/tvs/internal/domain"
+	"github.com/tuncanbit/tvs/internal/infrastructure/rpc"
+	"github.com/tuncanbit/tvs/pkg/address"
+)
+
+const (
+	// solanaSignatureFee is the base fee per transaction signature.
+	solanaSignatureFee = 5000
+	// Compute units a payout is budgeted for: a system transfer, a token
+	// transfer to an existing account, and a token transfer that creates
+	// the recipient's associated token account.
+	solanaNativeTransferUnits       = 450
+	solanaTokenTransferUnits        = 6_500
+	solanaTokenAccountCreationUnits = 35_000
+
+	// evmFeeHistoryBlocks is how many recent blocks the priority fee is taken
+	// from, at evmPriorityPercentile of each block's transactions.
+	evmFeeHistoryBlocks   = 10
+	evmPriorityPercentile = 50
+	evmNativeTransferGas  = 21_000
+	evmTokenTransferGas   = 65_000
+)
+
+type cachedEstimate struct {
+	estimate  domain.NetworkFeeEstimate
+	expiresAt time.Time
+}
+
+func (s *feeService) EstimateNetworkFee(ctx context.Context, chainID, cryptoCurrency, toAddress, fiatCurrency string) (*domain.NetworkFeeEstimate, error) {
+	asset, ok := nativeAssets[chainID]
+	if !ok {
+		return nil, fmt.Errorf("%w %s", ErrUnsupportedChain, chainID)
+	}
+
+	toAddress, err := address.Validate(chainID, toAddress)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAddress, err)
+	}
+
+	key := strings.Join([]string{chainID, cryptoCurrency, toAddress, fiatCurrency}, "|")
+	if estimate, ok := s.cachedEstimate(key); ok {
+		return estimate, nil
+	}
+
+	estimate := &domain.NetworkFeeEstimate{
+		ChainID:        chainID,
+		CryptoCurrency: cryptoCurrency,
+		Currency:       asset.currency,
+		PaidBy:         s.withdrawalFeePayer(chainID),
+		FiatCurrency:   fiatCurrency,
+	}
+	var rentUnits int64
+	if clusterType, clusterErr := rpc.ClusterTypeForChain(chainID); clusterErr == nil {
+		rentUnits, err = s.estimateSolanaFee(ctx, clusterType, cryptoCurrency, toAddress, estimate)
+	} else {
+		err = s.estimateEVMFee(ctx, chainID, cryptoCurrency, estimate)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rate, err := s.pricingSvc.GetExchangeRate(ctx, asset.currency, fiatCurrency)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrPriceUnavailable, err)
+	}
+	if rate.Stale {
+		s.logger.Warn().
+			Str("chain_id", chainID).
+			Dur("rate_age", rate.Age).
+			Msg("Using stale exchange rate for fee estimate")
+	}
+
+	scale := math.Pow10(asset.decimals)
+	estimate.Amount = formatBaseUnits(estimate.BaseUnits, asset.decimals)
+	estimate.FiatCents = s.chargeConversion(ctx, float64(estimate.BaseUnits)/scale, rate.Rate, fiatCurrency).Converted
+	if rentUnits > 0 {
+		estimate.TokenAccountRent = formatBaseUnits(rentUnits, asset.decimals)
+		estimate.TokenAccountRentCents = s.chargeConversion(ctx, float64(rentUnits)/scale, rate.Rate, fiatCurrency).Converted
+	}
+	estimate.EstimatedAt = time.Now()
+	estimate.ExpiresAt = estimate.EstimatedAt.Add(s.config.FeeEstimateTTL)
+
+	s.cacheEstimate(key, *estimate)
+	return estimate, nil
+}
+
+// estimateSolanaFee fills in the fee of a payout on clusterType and returns
+// the rent of the token account it must create, if any.
+func (s *feeService) estimateSolanaFee(ctx context.Context, clusterType domain.SolanaClusterType, cryptoCurrency, toAddress string, estimate *domain.NetworkFeeEstimate) (int64, error) {
+	tokenType, err := rpc.TokenTypeForCrypto(cryptoCurrency)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, cryptoCurrency)
+	}
+
+	units := uint64(solanaNativeTransferUnits)
+	writable := []string{toAddress}
+	var rentUnits int64
+	if tokenType != domain.SPLTokenTypeSOL {
+		destination, err := s.heliusClient.InspectTokenDestination(ctx, clusterType, tokenType, toAddress)
+		switch {
+		case errors.Is(err, rpc.ErrInvalidDestination),
+			errors.Is(err, rpc.ErrDestinationNotWallet),
+			errors.Is(err, rpc.ErrDestinationMintMismatch),
+			errors.Is(err, rpc.ErrDestinationFrozen):
+			return 0, fmt.Errorf("%w: %v", ErrInvalidAddress, err)
+		case errors.Is(err, rpc.ErrUnsupportedTokenProgram):
+			return 0, fmt.Errorf("%w: %v", ErrUnsupportedCurrency, err)
+		case err != nil:
+			return 0, fmt.Errorf("failed to check destination: %w", err)
+		}
+		estimate.Destination = destination
+		writable = []string{destination.TokenAccount}
+		units = solanaTokenTransferUnits
+		if destination.RequiresTokenAccount {
+			estimate.RequiresTokenAccount = true
+			units = solanaTokenAccountCreationUnits
+			rentUnits = int64(destination.TokenAccountRent)
+		}
+	}
+
+	fees, err := s.heliusClient.GetRecentPrioritizationFees(ctx, clusterType, writable)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get prioritization fees: %w", err)
+	}
+	estimate.PriorityFee = median(fees)
+	estimate.BaseUnits = solanaPayoutFee(estimate.PriorityFee, units)
+	return rentUnits, nil
+}
+
+// solanaPayoutFee is the fee in lamports of a single-signature transaction
+// budgeted units compute units at priorityFee micro-lamports per unit. The
+// priority fee is rounded up to whole lamports.
+func solanaPayoutFee(priorityFee, units uint64) int64 {
+	priority := (priorityFee*units + 999_999) / 1_000_000
+	return int64(solanaSignatureFee + priority)
+}
+
+// estimateEVMFee fills in the fee of a payout on an EVM chain: the next
+// block's base fee plus the median recent priority fee, for the gas a
+// native or token transfer uses.
+func (s *feeService) estimateEVMFee(ctx context.Context, chainID, cryptoCurrency string, estimate *domain.NetworkFeeEstimate) error {
+	gas := uint64(evmNativeTransferGas)
+	switch {
+	case cryptoCurrency == estimate.Currency:
+	case slices.Contains(s.config.EVMTokens, cryptoCurrency):
+		gas = evmTokenTransferGas
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedCurrency, cryptoCurrency)
+	}
+
+	history, err := s.alchemyClient.GetFeeHistory(ctx, chainID, evmFeeHistoryBlocks, []float64{evmPriorityPercentile})
+	if err != nil {
+		return fmt.Errorf("failed to get fee history: %w", err)
+	}
+	if len(history.BaseFeePerGas) == 0 {
+		return fmt.Errorf("fee history for %s has no base fee", chainID)
+	}
+	var tips []uint64
+	for _, block := range history.Reward {
+		if len(block) > 0 {
+			tips = append(tips, block[0])
+		}
+	}
+	baseFee := history.BaseFeePerGas[len(history.BaseFeePerGas)-1]
+	estimate.PriorityFee = median(tips)
+	estimate.BaseUnits = evmPayoutFee(baseFee, estimate.PriorityFee, gas)
+	return nil
+}
+
+// evmPayoutFee is the fee in wei of a transaction using gas at baseFee plus
+// priorityFee per unit of gas.
+func evmPayoutFee(baseFee, priorityFee, gas uint64) int64 {
+	return int64((baseFee + priorityFee) * gas)
+}
+
+func (s *feeService) cachedEstimate(key string) (*domain.NetworkFeeEstimate, bool) {
+	s.estimateMu.RLock()
+	entry, exists := s.estimates[key]
+	s.estimateMu.RUnlock()
+	if !exists || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+	estimate := entry.estimate
+	return &estimate, true
+}
+
+// cacheEstimate stores an estimate until it expires. Estimates are keyed by
+// destination, so expired entries are dropped on each store to keep the
+// cache from growing with every address ever quoted.
+func (s *feeService) cacheEstimate(key string, estimate domain.NetworkFeeEstimate) {
+	if s.config.FeeEstimateTTL <= 0 {
+		return
+	}
+	now := time.Now()
+	s.estimateMu.Lock()
+	defer s.estimateMu.Unlock()
+	for k, entry := range s.estimates {
+		if now.After(entry.expiresAt) {
+			delete(s.estimates, k)
+		}
+	}
+	s.estimates[key] = cachedEstimate{estimate: estimate, expiresAt: estimate.ExpiresAt}
+}
+
+func median(values []uint64) uint64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]uint64(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted[len(sorted)/2]
+}
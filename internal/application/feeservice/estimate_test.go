@@ -0,0 +1,121 @@
+package feeservice
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/tuncanbit/tvs/internal/domain"
+)
+
+// fixedConversion converts at the exact amount times rate in cents and
+// rounds half up, standing in for the configured conversion settings.
+type fixedConversion struct{}
+
+func (fixedConversion) Convert(_ context.Context, cryptoAmount, exchangeRate float64, currencyCode string) domain.Conversion {
+	exact := cryptoAmount * exchangeRate * 100
+	converted := int64(exact + 0.5)
+	return domain.Conversion{CurrencyCode: currencyCode, Exact: exact, Converted: converted, Remainder: exact - float64(converted)}
+}
+
+func (fixedConversion) RecordRemainder(context.Context, string, domain.ConversionType, domain.Conversion) {
+}
+
+func (fixedConversion) GetRoundingDrift(context.Context, time.Time, time.Time) ([]domain.RoundingDrift, error) {
+	return nil, nil
+}
+
+func TestMedian(t *testing.T) {
+	tests := []struct {
+		values []uint64
+		want   uint64
+	}{
+		{nil, 0},
+		{[]uint64{7}, 7},
+		{[]uint64{9, 1, 5}, 5},
+		{[]uint64{4, 1, 3, 2}, 3},
+		{[]uint64{0, 0, 100_000}, 0},
+	}
+	for _, tt := range tests {
+		if got := median(tt.values); got != tt.want {
+			t.Errorf("median(%v) = %d, want %d", tt.values, got, tt.want)
+		}
+	}
+}
+
+func TestMedianLeavesInputUnsorted(t *testing.T) {
+	values := []uint64{3, 1, 2}
+	median(values)
+	if values[0] != 3 || values[1] != 1 || values[2] != 2 {
+		t.Errorf("median reordered its input to %v", values)
+	}
+}
+
+func TestSolanaPayoutFee(t *testing.T) {
+	tests := []struct {
+		priorityFee uint64
+		units       uint64
+		want        int64
+	}{
+		{0, solanaNativeTransferUnits, 5000},
+		// 1 micro-lamport over 450 units is rounded up to a whole lamport.
+		{1, solanaNativeTransferUnits, 5001},
+		{1_000_000, solanaTokenTransferUnits, 5000 + 6_500},
+		{2_500, solanaTokenAccountCreationUnits, 5000 + 88},
+	}
+	for _, tt := range tests {
+		if got := solanaPayoutFee(tt.priorityFee, tt.units); got != tt.want {
+			t.Errorf("solanaPayoutFee(%d, %d) = %d, want %d", tt.priorityFee, tt.units, got, tt.want)
+		}
+	}
+}
+
+func TestEVMPayoutFee(t *testing.T) {
+	// 30 gwei base fee and 2 gwei tip for a native transfer.
+	if got, want := evmPayoutFee(30_000_000_000, 2_000_000_000, evmNativeTransferGas), int64(672_000_000_000_000); got != want {
+		t.Errorf("evmPayoutFee = %d, want %d", got, want)
+	}
+}
+
+func TestChargeConversionRoundsUp(t *testing.T) {
+	s := &feeService{conversionSvc: fixedConversion{}}
+	tests := []struct {
+		amount, rate float64
+		want         int64
+	}{
+		// 0.000005 SOL at 150.00 is 0.075 cents, charged as a whole cent.
+		{0.000005, 150, 1},
+		// 1.234 cents is rounded down by the conversion and back up here.
+		{0.01234, 1, 2},
+		// Whole cents are charged as they are.
+		{0.02, 1, 2},
+		{0, 150, 0},
+	}
+	for _, tt := range tests {
+		got := s.chargeConversion(context.Background(), tt.amount, tt.rate, "USD")
+		if got.Converted != tt.want {
+			t.Errorf("chargeConversion(%v, %v).Converted = %d, want %d", tt.amount, tt.rate, got.Converted, tt.want)
+		}
+		if got.Remainder > 0 {
+			t.Errorf("chargeConversion(%v, %v).Remainder = %v, want <= 0", tt.amount, tt.rate, got.Remainder)
+		}
+	}
+}
+
+func TestFormatBaseUnits(t *testing.T) {
+	tests := []struct {
+		units    int64
+		decimals int
+		want     string
+	}{
+		{5000, 9, "0.000005000"},
+		{1_500_000_000, 9, "1.500000000"},
+		{42, 0, "42"},
+		{0, 6, "0.000000"},
+	}
+	for _, tt := range tests {
+		if got := formatBaseUnits(tt.units, tt.decimals); got != tt.want {
+			t.Errorf("formatBaseUnits(%d, %d) = %q, want %q", tt.units, tt.decimals, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package feeservice
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/tuncanbit/tvs/internal/domain"
+)
+
+var (
+	ErrUnsupportedChain    = errors.New("no native asset known for chain")
+	ErrUnsupportedCurrency = errors.New("unsupported currency")
+	ErrInvalidAddress      = errors.New("invalid destination address")
+	ErrPriceUnavailable    = errors.New("no price available for the network fee")
+)
+
+type IFeeService interface {
+	// AssessNetworkFee values a transaction's on-chain fee of baseUnits, paid
+	// by payer, at the current price. When the chain's policy makes the user
+	// pay withdrawal fees, the fee is also priced in chargeCurrency for
+	// charging their balance, rounded up. Deposit fees are paid by the sender
+	// and never charged. The conversions are returned on the fee for the
+	// caller to record their remainders against its transaction.
+	AssessNetworkFee(ctx context.Context, chainID string, txType domain.TransactionType, txHash, payer string, baseUnits int64, chargeCurrency string) (*domain.NetworkFee, error)
+	// RecordNetworkFee posts the fee to the network fee account. Failures are
+	// logged rather than returned because the transaction it belongs to has
+	// already been processed.
+	RecordNetworkFee(ctx context.Context, fee domain.NetworkFee)
+	// RecordNetworkFeeTx posts the fee within tx, so it is kept only if the
+	// balance changes made in tx are committed.
+	RecordNetworkFeeTx(ctx context.Context, tx *sql.Tx, fee domain.NetworkFee) error
+	// EstimateNetworkFee estimates the on-chain fee of paying cryptoCurrency
+	// out to toAddress on chainID from recent network fees, valued in
+	// fiatCurrency. Estimates are cached for the configured fee estimate TTL.
+	EstimateNetworkFee(ctx context.Context, chainID, cryptoCurrency, toAddress, fiatCurrency string) (*domain.NetworkFeeEstimate, error)
+	GetNetworkFeeReport(ctx context.Context, from, to time.Time) ([]domain.NetworkFeeSummary, error)
+}
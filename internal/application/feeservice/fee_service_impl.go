@@ -0,0 +1,190 @@
+package feeservice
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/internal/application/conversionservice"
+	"github.com/tuncanbit/tvs/internal/application/pricingservice"
+	"github.com/tuncanbit/tvs/internal/domain"
+	"github.com/tuncanbit/tvs/internal/infrastructure/rpc"
+	"github.com/tuncanbit/tvs/internal/repositories/feerepo"
+	"github.com/tuncanbit/tvs/pkg/config"
+	"github.com/tuncanbit/tvs/pkg/metrics"
+)
+
+// nativeAsset is the currency a chain charges transaction fees in.
+type nativeAsset struct {
+	currency string
+	decimals int
+}
+
+var nativeAssets = map[string]nativeAsset{
+	"sol-mainnet": {currency: "SOL", decimals: 9},
+	"sol-testnet": {currency: "SOL", decimals: 9},
+	"eth-mainnet": {currency: "ETH", decimals: 18},
+	"eth-testnet": {currency: "ETH", decimals: 18},
+}
+
+type feeService struct {
+	feeRepo       feerepo.IFeeRepository
+	pricingSvc    pricingservice.IPricingService
+	conversionSvc conversionservice.IConversionService
+	heliusClient  *rpc.HeliusClient
+	alchemyClient *rpc.AlchemyClient
+	config        config.WithdrawalsConfig
+	logger        zerolog.Logger
+
+	estimateMu sync.RWMutex
+	estimates  map[string]cachedEstimate
+}
+
+func New(
+	feeRepo feerepo.IFeeRepository,
+	pricingSvc pricingservice.IPricingService,
+	conversionSvc conversionservice.IConversionService,
+	heliusClient *rpc.HeliusClient,
+	alchemyClient *rpc.AlchemyClient,
+	cfg config.WithdrawalsConfig,
+	logger zerolog.Logger,
+) IFeeService {
+	return &feeService{
+		feeRepo:       feeRepo,
+		pricingSvc:    pricingSvc,
+		conversionSvc: conversionSvc,
+		heliusClient:  heliusClient,
+		alchemyClient: alchemyClient,
+		config:        cfg,
+		logger:        logger.With().Str("component", "fee_service").Logger(),
+		estimates:     make(map[string]cachedEstimate),
+	}
+}
+
+func (s *feeService) AssessNetworkFee(ctx context.Context, chainID string, txType domain.TransactionType, txHash, payer string, baseUnits int64, chargeCurrency string) (*domain.NetworkFee, error) {
+	asset, ok := nativeAssets[chainID]
+	if !ok {
+		return nil, fmt.Errorf("%w %s", ErrUnsupportedChain, chainID)
+	}
+
+	paidBy := domain.NetworkFeePaidByUser
+	if txType == domain.TypeWithdrawal {
+		paidBy = s.withdrawalFeePayer(chainID)
+	}
+	charged := paidBy == domain.NetworkFeePaidByUser && txType == domain.TypeWithdrawal && chargeCurrency != ""
+
+	quoteCurrency := "USD"
+	if charged {
+		quoteCurrency = chargeCurrency
+	}
+	rate, err := s.pricingSvc.GetExchangeRate(ctx, asset.currency, quoteCurrency)
+	if err != nil {
+		return nil, fmt.Errorf("failed to price %s network fee: %w", asset.currency, err)
+	}
+	if rate.Stale {
+		s.logger.Warn().
+			Str("tx_hash", txHash).
+			Dur("rate_age", rate.Age).
+			Msg("Using stale exchange rate for network fee")
+	}
+
+	amount := float64(baseUnits) / math.Pow10(asset.decimals)
+	usdConversion := s.conversionSvc.Convert(ctx, amount, rate.PriceUSD, "USD")
+	fee := &domain.NetworkFee{
+		ChainID:         chainID,
+		TxHash:          txHash,
+		TransactionType: txType,
+		Currency:        asset.currency,
+		BaseUnits:       baseUnits,
+		Amount:          formatBaseUnits(baseUnits, asset.decimals),
+		Payer:           payer,
+		PaidBy:          paidBy,
+		PriceUSD:        rate.PriceUSD,
+		USDCents:        usdConversion.Converted,
+		PricedAt:        time.Now(),
+		Conversions:     []domain.Conversion{usdConversion},
+	}
+	if charged {
+		// Like the platform fee, a charged network fee is rounded up so the
+		// house never pays part of a fee the user owes.
+		chargedConversion := s.chargeConversion(ctx, amount, rate.Rate, chargeCurrency)
+		fee.ChargedCurrency = chargeCurrency
+		fee.ChargedCents = chargedConversion.Converted
+		fee.Conversions = append(fee.Conversions, chargedConversion)
+	}
+	return fee, nil
+}
+
+func (s *feeService) RecordNetworkFee(ctx context.Context, fee domain.NetworkFee) {
+	if err := s.feeRepo.SaveNetworkFee(ctx, fee); err != nil {
+		s.logger.Error().
+			Err(err).
+			Str("tx_hash", fee.TxHash).
+			Int64("base_units", fee.BaseUnits).
+			Msg("Failed to record network fee")
+		return
+	}
+	recordNetworkFeeMetrics(fee)
+}
+
+func (s *feeService) RecordNetworkFeeTx(ctx context.Context, tx *sql.Tx, fee domain.NetworkFee) error {
+	if err := s.feeRepo.SaveNetworkFeeTx(ctx, tx, fee); err != nil {
+		return err
+	}
+	recordNetworkFeeMetrics(fee)
+	return nil
+}
+
+func (s *feeService) GetNetworkFeeReport(ctx context.Context, from, to time.Time) ([]domain.NetworkFeeSummary, error) {
+	return s.feeRepo.GetNetworkFeeSummary(ctx, from, to)
+}
+
+// chargeConversion converts amount of an asset at rate into currencyCode
+// minor units, rounded up so a fee charged to the user is never short.
+func (s *feeService) chargeConversion(ctx context.Context, amount, rate float64, currencyCode string) domain.Conversion {
+	conversion := s.conversionSvc.Convert(ctx, amount, rate, currencyCode)
+	if conversion.Remainder > 0 {
+		conversion.Converted++
+		conversion.Remainder--
+	}
+	return conversion
+}
+
+func (s *feeService) withdrawalFeePayer(chainID string) domain.NetworkFeePaidBy {
+	switch payer := domain.NetworkFeePaidBy(s.config.NetworkFeePayers[chainID]); payer {
+	case domain.NetworkFeePaidByUser, domain.NetworkFeePaidByHouse:
+		return payer
+	case "":
+		return domain.NetworkFeePaidByHouse
+	default:
+		s.logger.Warn().
+			Str("chain_id", chainID).
+			Str("payer", string(payer)).
+			Msg("Unknown network fee payer, the house pays")
+		return domain.NetworkFeePaidByHouse
+	}
+}
+
+func recordNetworkFeeMetrics(fee domain.NetworkFee) {
+	metrics.Add("network_fees.recorded", 1)
+	metrics.Add("network_fees.usd_cents."+string(fee.PaidBy), fee.USDCents)
+}
+
+// formatBaseUnits renders base units as an exact decimal amount of the
+// asset, e.g. 5000 lamports as 0.000005000.
+func formatBaseUnits(units int64, decimals int) string {
+	digits := strconv.FormatInt(units, 10)
+	if decimals == 0 {
+		return digits
+	}
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+	return digits[:len(digits)-decimals] + "." + digits[len(digits)-decimals:]
+}
@@ -0,0 +1,27 @@
+package limitservice
+
+import (
+	"context"
+
+	"github.com/tuncanbit/tvs/internal/domain"
+)
+
+type ILimitService interface {
+	// Start loads the limits from system_config and keeps reloading them
+	// until ctx is done, so edits to the config rows apply without a restart.
+	Start(ctx context.Context)
+	// EvaluateWithdrawal checks a withdrawal against the auto-approve
+	// threshold, the user's rolling daily, weekly and monthly totals, the
+	// screening lists and the account policy.
+	EvaluateWithdrawal(ctx context.Context, withdrawal domain.Withdrawal) (*domain.LimitCheck, error)
+	// EnforceWithdrawalLimits evaluates a pending withdrawal once, records the
+	// result and moves it to awaiting_admin_review when a limit is exceeded.
+	// It reports whether the withdrawal may proceed, which includes
+	// withdrawals an admin approved in review, unless the account policy has
+	// since blocked the user's withdrawals.
+	EnforceWithdrawalLimits(ctx context.Context, withdrawal *domain.Withdrawal) (bool, error)
+	// RequiredApprovals is the number of distinct admins that must approve
+	// the withdrawal in review: the quorum size above the quorum threshold,
+	// one otherwise.
+	RequiredApprovals(withdrawal domain.Withdrawal) int
+}
@@ -0,0 +1,373 @@
+package limitservice
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/internal/application/accountpolicyservice"
+	"github.com/tuncanbit/tvs/internal/application/pricingservice"
+	"github.com/tuncanbit/tvs/internal/application/screeningservice"
+	"github.com/tuncanbit/tvs/internal/domain"
+	"github.com/tuncanbit/tvs/internal/repositories/configrepo"
+	"github.com/tuncanbit/tvs/internal/repositories/withdrawalrepo"
+	"github.com/tuncanbit/tvs/pkg/config"
+	"github.com/tuncanbit/tvs/pkg/currency"
+	"github.com/tuncanbit/tvs/pkg/metrics"
+)
+
+const (
+	withdrawalThresholdKey = "withdrawal_threshold"
+	withdrawalLimitKey     = "auto_withdrawal_limit"
+
+	defaultReloadInterval    = time.Minute
+	defaultAdminReviewWindow = 24 * time.Hour
+)
+
+type limitWindow struct {
+	name   string
+	period time.Duration
+	limit  int64
+}
+
+type limitService struct {
+	configRepo     configrepo.IConfigRepository
+	withdrawalRepo withdrawalrepo.IWithdrawalRepository
+	pricingSvc     pricingservice.IPricingService
+	screeningSvc   screeningservice.IScreeningService
+	policySvc      accountpolicyservice.IAccountPolicyService
+	config         config.WithdrawalsConfig
+	currencyUtils  *currency.CurrencyUtils
+	logger         zerolog.Logger
+
+	mu        sync.RWMutex
+	loaded    bool
+	threshold domain.WithdrawalThreshold
+	limits    domain.WithdrawalLimits
+}
+
+func New(
+	configRepo configrepo.IConfigRepository,
+	withdrawalRepo withdrawalrepo.IWithdrawalRepository,
+	pricingSvc pricingservice.IPricingService,
+	screeningSvc screeningservice.IScreeningService,
+	policySvc accountpolicyservice.IAccountPolicyService,
+	cfg config.WithdrawalsConfig,
+	logger zerolog.Logger,
+) ILimitService {
+	return &limitService{
+		configRepo:     configRepo,
+		withdrawalRepo: withdrawalRepo,
+		pricingSvc:     pricingSvc,
+		screeningSvc:   screeningSvc,
+		policySvc:      policySvc,
+		config:         cfg,
+		currencyUtils:  currency.NewCurrencyUtils(),
+		logger:         logger.With().Str("component", "limit_service").Logger(),
+	}
+}
+
+func (s *limitService) Start(ctx context.Context) {
+	if err := s.reload(ctx); err != nil {
+		s.logger.Error().Err(err).Msg("Failed to load withdrawal limits")
+	}
+
+	interval := s.config.LimitsReloadInterval
+	if interval <= 0 {
+		interval = defaultReloadInterval
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.reload(ctx); err != nil {
+					s.logger.Warn().Err(err).Msg("Failed to reload withdrawal limits, keeping previous values")
+				}
+			}
+		}
+	}()
+}
+
+// reload reads both config rows and swaps them in together, logging only
+// when the values actually changed.
+func (s *limitService) reload(ctx context.Context) error {
+	var threshold domain.WithdrawalThreshold
+	if err := s.loadConfig(ctx, withdrawalThresholdKey, &threshold); err != nil {
+		return err
+	}
+	var limits domain.WithdrawalLimits
+	if err := s.loadConfig(ctx, withdrawalLimitKey, &limits); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	changed := !s.loaded || s.threshold != threshold || s.limits != limits
+	s.threshold, s.limits, s.loaded = threshold, limits, true
+	s.mu.Unlock()
+
+	if changed {
+		s.logger.Info().
+			Int64("threshold_usd_cents", threshold.USDAmountCents).
+			Bool("auto_approve", threshold.AutoApprove).
+			Int64("daily_usd_cents", limits.DailyUSDCents).
+			Int64("weekly_usd_cents", limits.WeeklyUSDCents).
+			Int64("monthly_usd_cents", limits.MonthlyUSDCents).
+			Msg("Withdrawal limits loaded")
+	}
+	return nil
+}
+
+func (s *limitService) loadConfig(ctx context.Context, key string, out interface{}) error {
+	raw, err := s.configRepo.GetConfig(ctx, key)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(raw, out); err != nil {
+		return fmt.Errorf("invalid %s config: %w", key, err)
+	}
+	return nil
+}
+
+func (s *limitService) current(ctx context.Context) (domain.WithdrawalThreshold, domain.WithdrawalLimits, error) {
+	s.mu.RLock()
+	loaded := s.loaded
+	s.mu.RUnlock()
+	if !loaded {
+		if err := s.reload(ctx); err != nil {
+			return domain.WithdrawalThreshold{}, domain.WithdrawalLimits{}, fmt.Errorf("withdrawal limits unavailable: %w", err)
+		}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.threshold, s.limits, nil
+}
+
+func (s *limitService) EvaluateWithdrawal(ctx context.Context, withdrawal domain.Withdrawal) (*domain.LimitCheck, error) {
+	threshold, limits, err := s.current(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	usdCents, err := s.usdValue(ctx, withdrawal)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	check := &domain.LimitCheck{USDAmountCents: usdCents, CheckedAt: now}
+	if !threshold.AutoApprove {
+		check.Reasons = append(check.Reasons, "automatic approval is disabled")
+	}
+	if threshold.USDAmountCents > 0 && usdCents > threshold.USDAmountCents {
+		check.Reasons = append(check.Reasons, fmt.Sprintf("amount %s exceeds auto-approve threshold of %s",
+			s.currencyUtils.Format(usdCents, "USD"), s.currencyUtils.Format(threshold.USDAmountCents, "USD")))
+	}
+	if match := s.screeningSvc.Screen(withdrawal.ToAddress); match != nil {
+		check.Screening = match
+		check.Reasons = append(check.Reasons, fmt.Sprintf("destination %s is on the %s screening list: %s", match.Address, match.List, match.Reason))
+	}
+	restriction, err := s.policySvc.CheckWithdrawal(ctx, withdrawal.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if restriction != nil {
+		check.Restriction = restriction
+		check.Reasons = append(check.Reasons, fmt.Sprintf("%s (%s)", restriction.Message, restriction.Code))
+	}
+
+	windows := []limitWindow{
+		{name: "daily", period: 24 * time.Hour, limit: limits.DailyUSDCents},
+		{name: "weekly", period: 7 * 24 * time.Hour, limit: limits.WeeklyUSDCents},
+		{name: "monthly", period: 30 * 24 * time.Hour, limit: limits.MonthlyUSDCents},
+	}
+	for _, window := range windows {
+		if window.limit <= 0 {
+			continue
+		}
+		total, err := s.withdrawalRepo.SumUserWithdrawalsSince(ctx, withdrawal.UserID, withdrawal.WithdrawalID, now.Add(-window.period))
+		if err != nil {
+			return nil, err
+		}
+		if total+usdCents > window.limit {
+			check.Reasons = append(check.Reasons, fmt.Sprintf("%s limit of %s exceeded: %s including this withdrawal",
+				window.name, s.currencyUtils.Format(window.limit, "USD"), s.currencyUtils.Format(total+usdCents, "USD")))
+		}
+	}
+
+	if required := s.requiredApprovals(usdCents); required > 1 {
+		check.Reasons = append(check.Reasons, fmt.Sprintf("amount above %s requires %d admin approvals",
+			s.currencyUtils.Format(s.config.QuorumThresholdCents, "USD"), required))
+	}
+
+	check.Approved = len(check.Reasons) == 0
+	return check, nil
+}
+
+func (s *limitService) RequiredApprovals(withdrawal domain.Withdrawal) int {
+	return s.requiredApprovals(usdValueOf(withdrawal))
+}
+
+func (s *limitService) requiredApprovals(usdCents int64) int {
+	if s.config.QuorumSize > 1 && s.config.QuorumThresholdCents > 0 && usdCents > s.config.QuorumThresholdCents {
+		return s.config.QuorumSize
+	}
+	return 1
+}
+
+// hasQuorum confirms an approved withdrawal carries the approvals its amount
+// requires, so a review decision alone never releases a large withdrawal.
+func (s *limitService) hasQuorum(ctx context.Context, withdrawal domain.Withdrawal) (bool, error) {
+	required := s.RequiredApprovals(withdrawal)
+	if required <= 1 {
+		return true, nil
+	}
+
+	approvals, err := s.withdrawalRepo.CountQuorumApprovals(ctx, withdrawal.WithdrawalID)
+	if err != nil {
+		return false, err
+	}
+	if approvals < required {
+		s.logger.Warn().
+			Str("withdrawal_id", withdrawal.WithdrawalID).
+			Int("approvals", approvals).
+			Int("required", required).
+			Msg("Withdrawal approved without quorum, holding")
+		return false, nil
+	}
+	return true, nil
+}
+
+func (s *limitService) EnforceWithdrawalLimits(ctx context.Context, withdrawal *domain.Withdrawal) (bool, error) {
+	var metadata domain.WithdrawalMetadata
+	if len(withdrawal.Metadata) > 0 {
+		if err := json.Unmarshal(withdrawal.Metadata, &metadata); err != nil {
+			return false, fmt.Errorf("invalid metadata on withdrawal %s: %w", withdrawal.WithdrawalID, err)
+		}
+	}
+	if metadata.Review != nil && metadata.Review.Decision == domain.ReviewDecisionApproved {
+		if frozen, err := s.frozen(ctx, *withdrawal, metadata); frozen || err != nil {
+			return false, err
+		}
+		return s.hasQuorum(ctx, *withdrawal)
+	}
+	if metadata.LimitCheck != nil {
+		if !metadata.LimitCheck.Approved {
+			return false, nil
+		}
+		frozen, err := s.frozen(ctx, *withdrawal, metadata)
+		return !frozen, err
+	}
+
+	check, err := s.EvaluateWithdrawal(ctx, *withdrawal)
+	if err != nil {
+		return false, err
+	}
+	checkJSON, err := json.Marshal(check)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal limit check: %w", err)
+	}
+
+	if check.Approved {
+		metrics.Add("withdrawals.limits.approved", 1)
+		return true, s.withdrawalRepo.SetLimitCheck(ctx, withdrawal.WithdrawalID, checkJSON)
+	}
+
+	window := s.config.AdminReviewWindow
+	if window <= 0 {
+		window = defaultAdminReviewWindow
+	}
+	deadline := time.Now().Add(window)
+	flagged, err := s.withdrawalRepo.FlagForAdminReview(ctx, withdrawal.WithdrawalID, deadline, checkJSON)
+	if err != nil {
+		return false, err
+	}
+	if !flagged {
+		return false, fmt.Errorf("withdrawal %s is no longer pending", withdrawal.WithdrawalID)
+	}
+
+	metrics.Add("withdrawals.limits.flagged", 1)
+	withdrawal.Status = domain.WithdrawalStatusAwaitingAdminReview
+	withdrawal.RequiresAdminReview = true
+	withdrawal.AdminReviewDeadline = deadline
+	s.logger.Warn().
+		Str("withdrawal_id", withdrawal.WithdrawalID).
+		Str("user_id", withdrawal.UserID).
+		Strs("reasons", check.Reasons).
+		Time("review_deadline", deadline).
+		Msg("Withdrawal exceeds limits, awaiting admin review")
+	return false, nil
+}
+
+// frozen reports whether the account policy now blocks the user's
+// withdrawals, as when the account was suspended after the withdrawal was
+// approved. Such withdrawals stay pending until the block is lifted or an
+// admin rejects them. A withdrawal whose payout was already signed is not
+// frozen, since its transaction may have landed and must still be verified.
+func (s *limitService) frozen(ctx context.Context, withdrawal domain.Withdrawal, metadata domain.WithdrawalMetadata) (bool, error) {
+	if metadata.Broadcast != nil {
+		return false, nil
+	}
+	restriction, err := s.policySvc.CheckWithdrawal(ctx, withdrawal.UserID)
+	if err != nil {
+		return false, err
+	}
+	if restriction == nil || restriction.Action != domain.RestrictionBlock {
+		return false, nil
+	}
+	metrics.Add("withdrawals.limits.frozen", 1)
+	s.logger.Warn().
+		Str("withdrawal_id", withdrawal.WithdrawalID).
+		Str("user_id", withdrawal.UserID).
+		Str("code", string(restriction.Code)).
+		Msg("Account restricted, holding approved withdrawal")
+	return true, nil
+}
+
+// usdValue returns the withdrawal's value in USD cents. Withdrawals created
+// through the API record it; those inserted by another service in a fiat
+// currency other than USD are valued from their crypto amount.
+func (s *limitService) usdValue(ctx context.Context, withdrawal domain.Withdrawal) (int64, error) {
+	var metadata domain.WithdrawalMetadata
+	if len(withdrawal.Metadata) > 0 {
+		_ = json.Unmarshal(withdrawal.Metadata, &metadata)
+	}
+	if metadata.Origin == domain.WithdrawalOriginAPI || metadata.FiatCurrency == "" || metadata.FiatCurrency == "USD" {
+		return withdrawal.USDAmountCents, nil
+	}
+
+	cryptoAmount, err := strconv.ParseFloat(withdrawal.CryptoAmount, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid crypto amount %q on withdrawal %s: %w", withdrawal.CryptoAmount, withdrawal.WithdrawalID, err)
+	}
+	rate, err := s.pricingSvc.GetExchangeRate(ctx, withdrawal.CryptoCurrency, "USD")
+	if err != nil {
+		return 0, fmt.Errorf("failed to value withdrawal %s in USD: %w", withdrawal.WithdrawalID, err)
+	}
+	if rate.Stale {
+		return 0, fmt.Errorf("no fresh %s/USD rate to value withdrawal %s", withdrawal.CryptoCurrency, withdrawal.WithdrawalID)
+	}
+	return int64(math.Round(cryptoAmount * rate.PriceUSD * 100)), nil
+}
+
+// usdValueOf returns the USD value the limits were evaluated against, falling
+// back to the requested amount for withdrawals not yet evaluated.
+func usdValueOf(withdrawal domain.Withdrawal) int64 {
+	var metadata domain.WithdrawalMetadata
+	if len(withdrawal.Metadata) > 0 {
+		_ = json.Unmarshal(withdrawal.Metadata, &metadata)
+	}
+	if metadata.LimitCheck != nil {
+		return metadata.LimitCheck.USDAmountCents
+	}
+	return withdrawal.USDAmountCents
+}
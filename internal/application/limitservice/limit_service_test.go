@@ -0,0 +1,81 @@
+package limitservice
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/internal/application/accountpolicyservice"
+	"github.com/tuncanbit/tvs/internal/domain"
+)
+
+type stubPolicy struct {
+	accountpolicyservice.IAccountPolicyService
+	restriction *domain.AccountRestriction
+	err         error
+	calls       int
+}
+
+func (s *stubPolicy) CheckWithdrawal(context.Context, string) (*domain.AccountRestriction, error) {
+	s.calls++
+	return s.restriction, s.err
+}
+
+func TestFrozen(t *testing.T) {
+	errStatus := errors.New("status unavailable")
+	tests := []struct {
+		name        string
+		restriction *domain.AccountRestriction
+		err         error
+		broadcast   bool
+		want        bool
+	}{
+		{name: "unrestricted"},
+		{name: "held", restriction: &domain.AccountRestriction{Code: domain.RestrictionKYCPending, Action: domain.RestrictionHold}},
+		{name: "blocked", restriction: &domain.AccountRestriction{Code: domain.RestrictionAccountSuspended, Action: domain.RestrictionBlock}, want: true},
+		{name: "blocked after broadcast", restriction: &domain.AccountRestriction{Code: domain.RestrictionAccountSuspended, Action: domain.RestrictionBlock}, broadcast: true},
+		{name: "status error", err: errStatus},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &stubPolicy{restriction: tt.restriction, err: tt.err}
+			s := &limitService{policySvc: policy, logger: zerolog.Nop()}
+			var metadata domain.WithdrawalMetadata
+			if tt.broadcast {
+				metadata.Broadcast = &domain.WithdrawalBroadcast{}
+			}
+
+			got, err := s.frozen(context.Background(), domain.Withdrawal{WithdrawalID: "wd_1", UserID: "user-1"}, metadata)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("frozen() error = %v, want %v", err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("frozen() = %v, want %v", got, tt.want)
+			}
+			if tt.broadcast && policy.calls != 0 {
+				t.Error("frozen() checked the policy for a broadcast withdrawal")
+			}
+		})
+	}
+}
+
+func TestUSDValueOfAPIWithdrawal(t *testing.T) {
+	// No pricing service: a withdrawal created through the API records its
+	// USD value and is not revalued.
+	s := &limitService{logger: zerolog.Nop()}
+	withdrawal := domain.Withdrawal{
+		WithdrawalID:   "wd_1",
+		USDAmountCents: 10_850,
+		CryptoAmount:   "0.5",
+		Metadata:       json.RawMessage(`{"fiat_currency":"EUR","fiat_amount_cents":10000,"origin":"api"}`),
+	}
+	got, err := s.usdValue(context.Background(), withdrawal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != 10_850 {
+		t.Errorf("usdValue() = %d, want 10850", got)
+	}
+}
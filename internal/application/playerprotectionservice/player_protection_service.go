@@ -0,0 +1,32 @@
+package playerprotectionservice
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tuncanbit/tvs/internal/domain"
+)
+
+var (
+	ErrDepositLimitNotFound = errors.New("no deposit limit is set for this period")
+	ErrInvalidSelfExclusion = errors.New("self-exclusion period out of range")
+	ErrSelfExclusionInForce = errors.New("a self-exclusion ending later is already in force")
+	ErrPlayerProtectionOff  = errors.New("player protection is not enabled")
+)
+
+type IPlayerProtectionService interface {
+	// GetProtection returns the user's deposit limits as they stand now and
+	// their self-exclusion, if one is in force.
+	GetProtection(ctx context.Context, userID string) (*domain.PlayerProtection, error)
+	// SetDepositLimit sets the user's limit for a period on behalf of actorID,
+	// the user or an admin. Lowering a limit applies at once; raising or
+	// removing it only after the configured cool-off.
+	SetDepositLimit(ctx context.Context, actorID, userID string, req domain.SetDepositLimitRequest, info domain.RequestInfo) (*domain.DepositLimit, error)
+	// SelfExclude bars the user from depositing for the requested number of
+	// days. A self-exclusion can be extended but never shortened.
+	SelfExclude(ctx context.Context, actorID, userID string, req domain.SelfExclusionRequest, info domain.RequestInfo) (*domain.SelfExclusion, error)
+	// CheckDeposit returns the restriction on crediting the user a deposit of
+	// amount cryptoCurrency, or nil if it may be credited: the user is
+	// self-excluded, or the deposit would take them over a deposit limit.
+	CheckDeposit(ctx context.Context, userID, cryptoCurrency string, amount float64) (*domain.AccountRestriction, error)
+}
@@ -0,0 +1,290 @@
+package playerprotectionservice
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/internal/application/conversionservice"
+	"github.com/tuncanbit/tvs/internal/application/pricingservice"
+	"github.com/tuncanbit/tvs/internal/domain"
+	"github.com/tuncanbit/tvs/internal/repositories/auditrepo"
+	"github.com/tuncanbit/tvs/internal/repositories/playerprotectionrepo"
+	"github.com/tuncanbit/tvs/internal/repositories/sessionrepo"
+	"github.com/tuncanbit/tvs/pkg/config"
+	"github.com/tuncanbit/tvs/pkg/currency"
+	"github.com/tuncanbit/tvs/pkg/metrics"
+)
+
+const (
+	auditEntityDepositLimit  = "deposit_limit"
+	auditEntitySelfExclusion = "self_exclusion"
+
+	auditActionLimitLowered   = "deposit_limit_lowered"
+	auditActionLimitRaising   = "deposit_limit_raise_scheduled"
+	auditActionLimitCancelled = "deposit_limit_change_cancelled"
+	auditActionSelfExcluded   = "self_exclusion_started"
+)
+
+type playerProtectionService struct {
+	protectionRepo playerprotectionrepo.IPlayerProtectionRepository
+	sessionRepo    sessionrepo.ISessionRepository
+	auditRepo      auditrepo.IAuditRepository
+	pricingSvc     pricingservice.IPricingService
+	conversionSvc  conversionservice.IConversionService
+	config         config.PlayerProtectionConfig
+	currencyUtils  *currency.CurrencyUtils
+	logger         zerolog.Logger
+}
+
+func New(
+	protectionRepo playerprotectionrepo.IPlayerProtectionRepository,
+	sessionRepo sessionrepo.ISessionRepository,
+	auditRepo auditrepo.IAuditRepository,
+	pricingSvc pricingservice.IPricingService,
+	conversionSvc conversionservice.IConversionService,
+	cfg config.PlayerProtectionConfig,
+	logger zerolog.Logger,
+) IPlayerProtectionService {
+	return &playerProtectionService{
+		protectionRepo: protectionRepo,
+		sessionRepo:    sessionRepo,
+		auditRepo:      auditRepo,
+		pricingSvc:     pricingSvc,
+		conversionSvc:  conversionSvc,
+		config:         cfg,
+		currencyUtils:  currency.NewCurrencyUtils(),
+		logger:         logger.With().Str("component", "player_protection_service").Logger(),
+	}
+}
+
+func (s *playerProtectionService) GetProtection(ctx context.Context, userID string) (*domain.PlayerProtection, error) {
+	now := time.Now()
+	limits, err := s.currentLimits(ctx, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	exclusion, err := s.protectionRepo.GetActiveSelfExclusion(ctx, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	protection := &domain.PlayerProtection{DepositLimits: []domain.DepositLimit{}, SelfExclusion: exclusion}
+	for _, period := range domain.DepositLimitPeriods {
+		if limit, ok := limits[period]; ok {
+			protection.DepositLimits = append(protection.DepositLimits, limit)
+		}
+	}
+	return protection, nil
+}
+
+func (s *playerProtectionService) SetDepositLimit(ctx context.Context, actorID, userID string, req domain.SetDepositLimitRequest, info domain.RequestInfo) (*domain.DepositLimit, error) {
+	if !s.config.Enabled {
+		return nil, ErrPlayerProtectionOff
+	}
+	now := time.Now()
+	limits, err := s.currentLimits(ctx, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	current, exists := limits[req.Period]
+	if !exists && req.LimitUSDCents == 0 {
+		return nil, ErrDepositLimitNotFound
+	}
+
+	next := domain.DepositLimit{UserID: userID, Period: req.Period, SetBy: actorID}
+	var action string
+	switch {
+	case !exists || (req.LimitUSDCents > 0 && req.LimitUSDCents < current.LimitUSDCents):
+		action = auditActionLimitLowered
+		next.LimitUSDCents = req.LimitUSDCents
+	case req.LimitUSDCents == current.LimitUSDCents:
+		if current.PendingLimitUSDCents == nil {
+			return &current, nil
+		}
+		action = auditActionLimitCancelled
+		next.LimitUSDCents = current.LimitUSDCents
+	case current.PendingLimitUSDCents != nil && *current.PendingLimitUSDCents == req.LimitUSDCents:
+		// Asking for the same increase again does not restart the cool-off.
+		return &current, nil
+	default:
+		action = auditActionLimitRaising
+		next.LimitUSDCents = current.LimitUSDCents
+		pending := req.LimitUSDCents
+		effectiveAt := now.Add(s.config.LimitIncreaseCoolOff)
+		next.PendingLimitUSDCents = &pending
+		next.PendingEffectiveAt = &effectiveAt
+	}
+
+	saved, err := s.protectionRepo.SaveDepositLimit(ctx, next)
+	if err != nil {
+		return nil, err
+	}
+
+	var oldValues map[string]interface{}
+	if exists {
+		oldValues = map[string]interface{}{"limit_usd_cents": current.LimitUSDCents, "pending_limit_usd_cents": current.PendingLimitUSDCents}
+	}
+	s.audit(ctx, action, auditEntityDepositLimit, userID, actorID, userID, info, oldValues, map[string]interface{}{
+		"period":                  req.Period,
+		"limit_usd_cents":         saved.LimitUSDCents,
+		"pending_limit_usd_cents": saved.PendingLimitUSDCents,
+		"pending_effective_at":    saved.PendingEffectiveAt,
+	})
+	s.logger.Info().
+		Str("user_id", userID).
+		Str("actor_id", actorID).
+		Str("period", string(req.Period)).
+		Str("action", action).
+		Int64("limit_usd_cents", saved.LimitUSDCents).
+		Msg("Deposit limit changed")
+	return saved, nil
+}
+
+func (s *playerProtectionService) SelfExclude(ctx context.Context, actorID, userID string, req domain.SelfExclusionRequest, info domain.RequestInfo) (*domain.SelfExclusion, error) {
+	if !s.config.Enabled {
+		return nil, ErrPlayerProtectionOff
+	}
+	if req.Days < s.config.MinSelfExclusionDays || (s.config.MaxSelfExclusionDays > 0 && req.Days > s.config.MaxSelfExclusionDays) {
+		return nil, fmt.Errorf("%w: %d days, allowed %d to %d", ErrInvalidSelfExclusion, req.Days, s.config.MinSelfExclusionDays, s.config.MaxSelfExclusionDays)
+	}
+
+	now := time.Now()
+	endsAt := now.AddDate(0, 0, req.Days)
+	active, err := s.protectionRepo.GetActiveSelfExclusion(ctx, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	if active != nil && !endsAt.After(active.EndsAt) {
+		return nil, fmt.Errorf("%w: until %s", ErrSelfExclusionInForce, active.EndsAt.UTC().Format(time.RFC3339))
+	}
+
+	exclusion, err := s.protectionRepo.CreateSelfExclusion(ctx, domain.SelfExclusion{
+		UserID:    userID,
+		StartsAt:  now,
+		EndsAt:    endsAt,
+		CreatedBy: actorID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.Add("player_protection.self_exclusions", 1)
+	s.audit(ctx, auditActionSelfExcluded, auditEntitySelfExclusion, exclusion.ID, actorID, userID, info, nil,
+		map[string]interface{}{"starts_at": exclusion.StartsAt, "ends_at": exclusion.EndsAt, "days": req.Days})
+	s.logger.Info().
+		Str("user_id", userID).
+		Str("actor_id", actorID).
+		Time("ends_at", exclusion.EndsAt).
+		Msg("Self-exclusion started")
+	return exclusion, nil
+}
+
+func (s *playerProtectionService) CheckDeposit(ctx context.Context, userID, cryptoCurrency string, amount float64) (*domain.AccountRestriction, error) {
+	if !s.config.Enabled {
+		return nil, nil
+	}
+	now := time.Now()
+	exclusion, err := s.protectionRepo.GetActiveSelfExclusion(ctx, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	if exclusion != nil {
+		metrics.Add("player_protection.deposits_held", 1)
+		return &domain.AccountRestriction{
+			Code:    domain.RestrictionSelfExcluded,
+			Action:  domain.RestrictionHold,
+			Message: fmt.Sprintf("account is self-excluded until %s", exclusion.EndsAt.UTC().Format(time.RFC3339)),
+		}, nil
+	}
+
+	limits, err := s.currentLimits(ctx, userID, now)
+	if err != nil || len(limits) == 0 {
+		return nil, err
+	}
+	rate, err := s.pricingSvc.GetExchangeRate(ctx, cryptoCurrency, "USD")
+	if err != nil {
+		return nil, fmt.Errorf("failed to value deposit in USD: %w", err)
+	}
+	usdCents := s.conversionSvc.Convert(ctx, amount, rate.PriceUSD, "USD").Converted
+
+	for _, period := range domain.DepositLimitPeriods {
+		limit, ok := limits[period]
+		if !ok {
+			continue
+		}
+		credited, err := s.sessionRepo.SumCreditedDeposits(ctx, userID, now.Add(-period.Window()))
+		if err != nil {
+			return nil, err
+		}
+		if credited+usdCents <= limit.LimitUSDCents {
+			continue
+		}
+		metrics.Add("player_protection.deposits_held", 1)
+		s.logger.Info().
+			Str("user_id", userID).
+			Str("period", string(period)).
+			Int64("credited_usd_cents", credited).
+			Int64("deposit_usd_cents", usdCents).
+			Int64("limit_usd_cents", limit.LimitUSDCents).
+			Msg("Deposit exceeds deposit limit")
+		return &domain.AccountRestriction{
+			Code:    domain.RestrictionDepositLimit,
+			Action:  domain.RestrictionHold,
+			Message: fmt.Sprintf("deposit would exceed the %s deposit limit of %s", period, s.currencyUtils.Format(limit.LimitUSDCents, "USD")),
+		}, nil
+	}
+	return nil, nil
+}
+
+// currentLimits returns the user's limits in force at now by period, with
+// pending changes whose cool-off has passed applied. Removed limits are
+// left out.
+func (s *playerProtectionService) currentLimits(ctx context.Context, userID string, now time.Time) (map[domain.DepositLimitPeriod]domain.DepositLimit, error) {
+	stored, err := s.protectionRepo.ListDepositLimits(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	limits := make(map[domain.DepositLimitPeriod]domain.DepositLimit, len(stored))
+	for _, limit := range stored {
+		if current := limit.At(now); current.LimitUSDCents > 0 {
+			limits[limit.Period] = current
+		}
+	}
+	return limits, nil
+}
+
+// audit writes a player protection change to audit_logs. The actor is the
+// user or an admin acting for them, who is recorded as the entry's admin. A
+// failed write is logged rather than failing the change.
+func (s *playerProtectionService) audit(ctx context.Context, action, entityType, entityID, actorID, userID string, info domain.RequestInfo, oldValues, newValues map[string]interface{}) {
+	newValues["user_id"] = userID
+	var adminID string
+	if actorID != userID {
+		adminID = actorID
+	}
+	var oldJSON json.RawMessage
+	if oldValues != nil {
+		oldJSON, _ = json.Marshal(oldValues)
+	}
+	newJSON, _ := json.Marshal(newValues)
+
+	if err := s.auditRepo.CreateAuditLog(ctx, domain.AuditLog{
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		AdminID:    adminID,
+		OldValues:  oldJSON,
+		NewValues:  newJSON,
+		IPAddress:  info.IPAddress,
+		UserAgent:  info.UserAgent,
+	}); err != nil {
+		metrics.Add("audit.write_failures", 1)
+		s.logger.Error().
+			Err(err).
+			Str("action", action).
+			Str("entity_id", entityID).
+			Msg("Failed to write audit log")
+	}
+}
@@ -0,0 +1,215 @@
+package playerprotectionservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/internal/application/pricingservice"
+	"github.com/tuncanbit/tvs/internal/domain"
+	"github.com/tuncanbit/tvs/internal/repositories/auditrepo"
+	"github.com/tuncanbit/tvs/internal/repositories/playerprotectionrepo"
+	"github.com/tuncanbit/tvs/internal/repositories/sessionrepo"
+	"github.com/tuncanbit/tvs/pkg/config"
+	"github.com/tuncanbit/tvs/pkg/currency"
+)
+
+type memoryProtection struct {
+	playerprotectionrepo.IPlayerProtectionRepository
+	limits    []domain.DepositLimit
+	exclusion *domain.SelfExclusion
+	saved     *domain.DepositLimit
+}
+
+func (m *memoryProtection) ListDepositLimits(context.Context, string) ([]domain.DepositLimit, error) {
+	return m.limits, nil
+}
+
+func (m *memoryProtection) SaveDepositLimit(_ context.Context, limit domain.DepositLimit) (*domain.DepositLimit, error) {
+	m.saved = &limit
+	saved := limit
+	return &saved, nil
+}
+
+func (m *memoryProtection) GetActiveSelfExclusion(context.Context, string, time.Time) (*domain.SelfExclusion, error) {
+	return m.exclusion, nil
+}
+
+type stubAudit struct {
+	auditrepo.IAuditRepository
+	actions []string
+}
+
+func (s *stubAudit) CreateAuditLog(_ context.Context, entry domain.AuditLog) error {
+	s.actions = append(s.actions, entry.Action)
+	return nil
+}
+
+type stubSessions struct {
+	sessionrepo.ISessionRepository
+	credited int64
+}
+
+func (s stubSessions) SumCreditedDeposits(context.Context, string, time.Time) (int64, error) {
+	return s.credited, nil
+}
+
+type stubPricing struct {
+	pricingservice.IPricingService
+}
+
+func (stubPricing) GetExchangeRate(_ context.Context, cryptoCurrency, fiatCurrency string) (*domain.ExchangeRateResponse, error) {
+	return &domain.ExchangeRateResponse{CryptoCurrency: cryptoCurrency, FiatCurrency: fiatCurrency, PriceUSD: 100}, nil
+}
+
+// fixedConversion converts at the exact amount times rate in cents and
+// rounds half up, standing in for the configured conversion settings.
+type fixedConversion struct{}
+
+func (fixedConversion) Convert(_ context.Context, cryptoAmount, exchangeRate float64, currencyCode string) domain.Conversion {
+	exact := cryptoAmount * exchangeRate * 100
+	converted := int64(exact + 0.5)
+	return domain.Conversion{CurrencyCode: currencyCode, Exact: exact, Converted: converted, Remainder: exact - float64(converted)}
+}
+
+func (fixedConversion) RecordRemainder(context.Context, string, domain.ConversionType, domain.Conversion) {
+}
+
+func (fixedConversion) GetRoundingDrift(context.Context, time.Time, time.Time) ([]domain.RoundingDrift, error) {
+	return nil, nil
+}
+
+const coolOff = 168 * time.Hour
+
+func newTestService(repo *memoryProtection, credited int64) (*playerProtectionService, *stubAudit) {
+	audit := &stubAudit{}
+	return &playerProtectionService{
+		protectionRepo: repo,
+		sessionRepo:    stubSessions{credited: credited},
+		auditRepo:      audit,
+		pricingSvc:     stubPricing{},
+		conversionSvc:  fixedConversion{},
+		config:         config.PlayerProtectionConfig{Enabled: true, LimitIncreaseCoolOff: coolOff},
+		currencyUtils:  currency.NewCurrencyUtils(),
+		logger:         zerolog.Nop(),
+	}, audit
+}
+
+func cents(v int64) *int64 {
+	return &v
+}
+
+func TestSetDepositLimit(t *testing.T) {
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name        string
+		stored      *domain.DepositLimit
+		limit       int64
+		wantErr     error
+		wantAction  string // empty if nothing is saved
+		wantLimit   int64
+		wantPending *int64
+	}{
+		{name: "first limit", limit: 10_000, wantAction: auditActionLimitLowered, wantLimit: 10_000},
+		{name: "removing a missing limit", limit: 0, wantErr: ErrDepositLimitNotFound},
+		{name: "lower", stored: &domain.DepositLimit{LimitUSDCents: 10_000}, limit: 5_000, wantAction: auditActionLimitLowered, wantLimit: 5_000},
+		{name: "raise", stored: &domain.DepositLimit{LimitUSDCents: 10_000}, limit: 20_000, wantAction: auditActionLimitRaising, wantLimit: 10_000, wantPending: cents(20_000)},
+		{name: "remove", stored: &domain.DepositLimit{LimitUSDCents: 10_000}, limit: 0, wantAction: auditActionLimitRaising, wantLimit: 10_000, wantPending: cents(0)},
+		{name: "unchanged", stored: &domain.DepositLimit{LimitUSDCents: 10_000}, limit: 10_000},
+		{name: "cancel a raise", stored: &domain.DepositLimit{LimitUSDCents: 10_000, PendingLimitUSDCents: cents(20_000), PendingEffectiveAt: &future}, limit: 10_000, wantAction: auditActionLimitCancelled, wantLimit: 10_000},
+		{name: "repeat a raise", stored: &domain.DepositLimit{LimitUSDCents: 10_000, PendingLimitUSDCents: cents(20_000), PendingEffectiveAt: &future}, limit: 20_000},
+		{name: "lower during a raise", stored: &domain.DepositLimit{LimitUSDCents: 10_000, PendingLimitUSDCents: cents(20_000), PendingEffectiveAt: &future}, limit: 5_000, wantAction: auditActionLimitLowered, wantLimit: 5_000},
+		{name: "lower after a raise matured", stored: &domain.DepositLimit{LimitUSDCents: 10_000, PendingLimitUSDCents: cents(20_000), PendingEffectiveAt: &past}, limit: 15_000, wantAction: auditActionLimitLowered, wantLimit: 15_000},
+		{name: "set after a removal matured", stored: &domain.DepositLimit{LimitUSDCents: 10_000, PendingLimitUSDCents: cents(0), PendingEffectiveAt: &past}, limit: 30_000, wantAction: auditActionLimitLowered, wantLimit: 30_000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &memoryProtection{}
+			if tt.stored != nil {
+				stored := *tt.stored
+				stored.UserID, stored.Period = "user-1", domain.DepositLimitDaily
+				repo.limits = []domain.DepositLimit{stored}
+			}
+			s, audit := newTestService(repo, 0)
+
+			start := time.Now()
+			got, err := s.SetDepositLimit(context.Background(), "user-1", "user-1", domain.SetDepositLimitRequest{Period: domain.DepositLimitDaily, LimitUSDCents: tt.limit}, domain.RequestInfo{})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SetDepositLimit() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if tt.wantAction == "" {
+				if repo.saved != nil || len(audit.actions) != 0 {
+					t.Errorf("SetDepositLimit() saved %+v, want no change", repo.saved)
+				}
+				return
+			}
+			if repo.saved == nil || len(audit.actions) != 1 || audit.actions[0] != tt.wantAction {
+				t.Fatalf("SetDepositLimit() audited %v, want %s", audit.actions, tt.wantAction)
+			}
+			if got.LimitUSDCents != tt.wantLimit {
+				t.Errorf("LimitUSDCents = %d, want %d", got.LimitUSDCents, tt.wantLimit)
+			}
+			if tt.wantPending == nil {
+				if got.PendingLimitUSDCents != nil || got.PendingEffectiveAt != nil {
+					t.Errorf("pending = %v at %v, want none", got.PendingLimitUSDCents, got.PendingEffectiveAt)
+				}
+				return
+			}
+			if got.PendingLimitUSDCents == nil || *got.PendingLimitUSDCents != *tt.wantPending {
+				t.Errorf("PendingLimitUSDCents = %v, want %d", got.PendingLimitUSDCents, *tt.wantPending)
+			}
+			if got.PendingEffectiveAt == nil || got.PendingEffectiveAt.Before(start.Add(coolOff)) {
+				t.Errorf("PendingEffectiveAt = %v, want the end of the cool-off", got.PendingEffectiveAt)
+			}
+		})
+	}
+}
+
+func TestSetDepositLimitDisabled(t *testing.T) {
+	s, _ := newTestService(&memoryProtection{}, 0)
+	s.config.Enabled = false
+	if _, err := s.SetDepositLimit(context.Background(), "user-1", "user-1", domain.SetDepositLimitRequest{Period: domain.DepositLimitDaily, LimitUSDCents: 10_000}, domain.RequestInfo{}); !errors.Is(err, ErrPlayerProtectionOff) {
+		t.Errorf("SetDepositLimit() error = %v, want ErrPlayerProtectionOff", err)
+	}
+}
+
+func TestCheckDeposit(t *testing.T) {
+	daily := domain.DepositLimit{UserID: "user-1", Period: domain.DepositLimitDaily, LimitUSDCents: 10_000}
+	tests := []struct {
+		name     string
+		repo     *memoryProtection
+		credited int64
+		amount   float64 // at $100
+		want     domain.RestrictionCode
+	}{
+		{name: "no limits", repo: &memoryProtection{}, credited: 1_000_000, amount: 10},
+		{name: "within the limit", repo: &memoryProtection{limits: []domain.DepositLimit{daily}}, credited: 5_000, amount: 0.5},
+		{name: "over the limit", repo: &memoryProtection{limits: []domain.DepositLimit{daily}}, credited: 5_000, amount: 0.51, want: domain.RestrictionDepositLimit},
+		{name: "self-excluded", repo: &memoryProtection{exclusion: &domain.SelfExclusion{EndsAt: time.Now().Add(time.Hour)}}, amount: 0.01, want: domain.RestrictionSelfExcluded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := newTestService(tt.repo, tt.credited)
+			got, err := s.CheckDeposit(context.Background(), "user-1", "SOL", tt.amount)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.want == "" {
+				if got != nil {
+					t.Errorf("CheckDeposit() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil || got.Code != tt.want || !got.Code.Refundable() {
+				t.Errorf("CheckDeposit() = %+v, want refundable %s", got, tt.want)
+			}
+		})
+	}
+}
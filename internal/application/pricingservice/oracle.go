@@ -0,0 +1,160 @@
+package pricingservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/tuncanbit/tvs/internal/domain"
+	"github.com/tuncanbit/tvs/internal/infrastructure/clients"
+	"github.com/tuncanbit/tvs/pkg/metrics"
+)
+
+const defaultSourceTimeout = 10 * time.Second
+
+// ErrPriceRejected is returned when the sources answered but their quotes
+// failed the outlier or divergence guard. Unlike an outage it is never
+// papered over with the last persisted rate.
+var ErrPriceRejected = errors.New("price rejected by oracle guard")
+
+type quoteFunc func(ctx context.Context, source clients.IPriceSource) (float64, error)
+
+// aggregate queries every price source concurrently and combines their quotes.
+// Quotes further than OutlierThresholdPct from the median are dropped, and the
+// price is refused if fewer than MinSources remain or the surviving quotes
+// still spread wider than MaxDivergencePct. Refusals of quotes that did arrive
+// wrap ErrPriceRejected; too few answers is an outage like any other.
+func (s *pricingService) aggregate(ctx context.Context, cryptoCurrency, fiatCurrency string, sources []clients.IPriceSource, quote quoteFunc) (*domain.ExchangeRateResponse, error) {
+	timeout := s.config.SourceTimeout
+	if timeout <= 0 {
+		timeout = defaultSourceTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	quotes := make([]domain.PriceSourceQuote, len(sources))
+	var wg sync.WaitGroup
+	for i := range sources {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := sources[i].Name()
+			quotes[i].Source = name
+
+			price, err := quote(ctx, sources[i])
+			if err != nil {
+				metrics.Add("pricing.source."+name+".errors", 1)
+				quotes[i].Error = err.Error()
+				return
+			}
+			quotes[i].Price = price
+		}(i)
+	}
+	wg.Wait()
+
+	var prices []float64
+	for _, quote := range quotes {
+		if quote.Error == "" {
+			prices = append(prices, quote.Price)
+		}
+	}
+	if len(prices) == 0 {
+		return nil, fmt.Errorf("no price source returned a quote for %s/%s", cryptoCurrency, fiatCurrency)
+	}
+
+	reference := median(prices)
+	var accepted []float64
+	for i := range quotes {
+		if quotes[i].Error != "" {
+			continue
+		}
+		if s.config.OutlierThresholdPct > 0 && deviationPct(quotes[i].Price, reference) > s.config.OutlierThresholdPct {
+			metrics.Add("pricing.source."+quotes[i].Source+".outliers", 1)
+			s.logger.Warn().
+				Str("source", quotes[i].Source).
+				Float64("price", quotes[i].Price).
+				Float64("median", reference).
+				Msg("Dropping outlier price quote")
+			continue
+		}
+		quotes[i].Included = true
+		accepted = append(accepted, quotes[i].Price)
+	}
+
+	minSources := s.config.MinSources
+	if minSources <= 0 {
+		minSources = 1
+	}
+	if len(accepted) < minSources {
+		metrics.Add("pricing.insufficient_sources", 1)
+		if len(prices) < minSources {
+			return nil, fmt.Errorf("only %d of %d required price sources answered for %s/%s", len(prices), minSources, cryptoCurrency, fiatCurrency)
+		}
+		return nil, fmt.Errorf("%w: only %d of %d required price sources agree on %s/%s", ErrPriceRejected, len(accepted), minSources, cryptoCurrency, fiatCurrency)
+	}
+
+	price := median(accepted)
+	sort.Float64s(accepted)
+	spread := deviationPct(accepted[len(accepted)-1], accepted[0])
+	if s.config.MaxDivergencePct > 0 && spread > s.config.MaxDivergencePct {
+		metrics.Add("pricing.divergence_rejected", 1)
+		return nil, fmt.Errorf("%w: price sources for %s/%s diverge by %.2f%%, exceeds %.2f%%", ErrPriceRejected, cryptoCurrency, fiatCurrency, spread, s.config.MaxDivergencePct)
+	}
+
+	now := time.Now()
+	rate := &domain.ExchangeRateResponse{
+		CryptoCurrency: cryptoCurrency,
+		FiatCurrency:   fiatCurrency,
+		Rate:           price,
+		LastUpdated:    now.Format(time.RFC3339),
+		FetchedAt:      now,
+		Method:         "median",
+		Sources:        quotes,
+	}
+	if fiatCurrency == "USD" {
+		rate.PriceUSD = price
+	}
+	return rate, nil
+}
+
+// spotQuote asks a source for its current price.
+func spotQuote(cryptoCurrency, fiatCurrency string) quoteFunc {
+	return func(ctx context.Context, source clients.IPriceSource) (float64, error) {
+		return source.GetPrice(ctx, cryptoCurrency, fiatCurrency)
+	}
+}
+
+// historicalQuote asks a source for the price in effect at a past moment.
+// Sources without history support report an error so they show up in the
+// breakdown without counting towards MinSources.
+func historicalQuote(cryptoCurrency, fiatCurrency string, at time.Time) quoteFunc {
+	return func(ctx context.Context, source clients.IPriceSource) (float64, error) {
+		historical, ok := source.(clients.IHistoricalPriceSource)
+		if !ok {
+			return 0, fmt.Errorf("%s does not support historical prices", source.Name())
+		}
+		return historical.GetHistoricalPrice(ctx, cryptoCurrency, fiatCurrency, at)
+	}
+}
+
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// deviationPct is the distance of value from reference in percent of reference.
+func deviationPct(value, reference float64) float64 {
+	if reference == 0 {
+		return math.Inf(1)
+	}
+	return math.Abs(value-reference) / reference * 100
+}
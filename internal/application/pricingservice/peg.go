@@ -0,0 +1,85 @@
+package pricingservice
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tuncanbit/tvs/internal/domain"
+	"github.com/tuncanbit/tvs/pkg/metrics"
+)
+
+// ErrDepegged is returned for a pegged token whose oracle price has left its
+// peg band while the peg is configured to pause crediting.
+var ErrDepegged = errors.New("stablecoin depegged, crediting paused")
+
+const depegActionPause = "pause"
+
+// applyPeg prices a pegged token at its peg while the oracle's USD price stays
+// within BandPct of it. Outside the band the token is treated as depegged: it
+// is priced at market, or ErrDepegged is returned if the peg pauses crediting.
+// Only live prices drive the depeg alarm; historical ones are checked against
+// the band without touching it.
+func (s *pricingService) applyPeg(market *domain.ExchangeRateResponse, live bool) (*domain.ExchangeRateResponse, error) {
+	peg, ok := s.config.Pegs[market.CryptoCurrency]
+	if !ok || !peg.Enabled || peg.PriceUSD <= 0 {
+		return market, nil
+	}
+
+	deviation := deviationPct(market.Rate, peg.PriceUSD)
+	depegged := deviation > peg.BandPct
+	if live {
+		s.setDepegged(market.CryptoCurrency, depegged, market.Rate, deviation)
+	}
+
+	if depegged {
+		if peg.OnDepeg == depegActionPause {
+			return nil, fmt.Errorf("%w: %s at %.4f USD, %.2f%% off peg", ErrDepegged, market.CryptoCurrency, market.Rate, deviation)
+		}
+		return market, nil
+	}
+
+	pegged := *market
+	pegged.Rate = peg.PriceUSD
+	pegged.PriceUSD = peg.PriceUSD
+	pegged.Method = "peg"
+	return &pegged, nil
+}
+
+// setDepegged records the token's peg state and raises the alarm on the
+// transition into a depeg, logging again once the price is back in band.
+func (s *pricingService) setDepegged(cryptoCurrency string, depegged bool, price, deviation float64) {
+	s.pegMu.Lock()
+	was := s.depegged[cryptoCurrency]
+	s.depegged[cryptoCurrency] = depegged
+	s.pegMu.Unlock()
+
+	switch {
+	case depegged && !was:
+		metrics.Add("pricing.peg."+cryptoCurrency+".depeg_alarms", 1)
+		s.logger.Error().
+			Str("crypto_currency", cryptoCurrency).
+			Float64("price_usd", price).
+			Float64("deviation_pct", deviation).
+			Str("on_depeg", s.config.Pegs[cryptoCurrency].OnDepeg).
+			Msg("DEPEG ALARM: stablecoin price left its peg band")
+	case !depegged && was:
+		s.logger.Warn().
+			Str("crypto_currency", cryptoCurrency).
+			Float64("price_usd", price).
+			Msg("Stablecoin price back within peg band, resuming peg pricing")
+	}
+}
+
+func (s *pricingService) registerPegGauges() {
+	for token, peg := range s.config.Pegs {
+		if !peg.Enabled {
+			continue
+		}
+		token := token
+		metrics.Gauge("pricing.peg."+token+".depegged", func() interface{} {
+			s.pegMu.Lock()
+			defer s.pegMu.Unlock()
+			return s.depegged[token]
+		})
+	}
+}
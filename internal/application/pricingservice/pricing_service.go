@@ -0,0 +1,14 @@
+package pricingservice
+
+import (
+	"context"
+	"time"
+
+	"github.com/tuncanbit/tvs/internal/domain"
+)
+
+type IPricingService interface {
+	GetExchangeRate(ctx context.Context, cryptoCurrency, fiatCurrency string) (*domain.ExchangeRateResponse, error)
+	GetDepositRate(ctx context.Context, session domain.DepositSession, onChainTime time.Time, fiatCurrency string) (*domain.ExchangeRateResponse, error)
+	SupportsFiat(currency string) bool
+}
@@ -0,0 +1,312 @@
+package pricingservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/internal/domain"
+	"github.com/tuncanbit/tvs/internal/infrastructure/clients"
+	"github.com/tuncanbit/tvs/internal/repositories/exchangeraterepo"
+	"github.com/tuncanbit/tvs/pkg/config"
+	"github.com/tuncanbit/tvs/pkg/metrics"
+	"golang.org/x/sync/singleflight"
+)
+
+type cachedRate struct {
+	rate      domain.ExchangeRateResponse
+	expiresAt time.Time
+}
+
+type rateLoader func(ctx context.Context) (*domain.ExchangeRateResponse, error)
+
+type pricingService struct {
+	sources          []clients.IPriceSource
+	fxClient         *clients.FXClient
+	exchangeRateRepo exchangeraterepo.IExchangeRateRepository
+	config           config.PricingConfig
+	cacheEnabled     bool
+	cacheTTL         time.Duration
+	logger           zerolog.Logger
+
+	mu    sync.RWMutex
+	cache map[string]cachedRate
+	group singleflight.Group
+
+	pegMu    sync.Mutex
+	depegged map[string]bool
+}
+
+func New(
+	sources []clients.IPriceSource,
+	fxClient *clients.FXClient,
+	exchangeRateRepo exchangeraterepo.IExchangeRateRepository,
+	cfg config.PricingConfig,
+	verificationCfg config.VerificationConfig,
+	logger zerolog.Logger,
+) IPricingService {
+	s := &pricingService{
+		sources:          sources,
+		fxClient:         fxClient,
+		exchangeRateRepo: exchangeRateRepo,
+		config:           cfg,
+		cacheEnabled:     verificationCfg.CacheEnabled,
+		cacheTTL:         verificationCfg.CacheTTL,
+		logger:           logger.With().Str("component", "pricing_service").Logger(),
+		cache:            make(map[string]cachedRate),
+		depegged:         make(map[string]bool),
+	}
+	s.registerPegGauges()
+	return s
+}
+
+// GetExchangeRate returns the crypto/fiat rate. The crypto price is always
+// aggregated in USD, replaced by the peg for pegged stablecoins, and then
+// converted with the USD/fiat FX rate, so every fiat currency is derived from
+// the same oracle price.
+func (s *pricingService) GetExchangeRate(ctx context.Context, cryptoCurrency, fiatCurrency string) (*domain.ExchangeRateResponse, error) {
+	usdRate, err := s.getRate(ctx, cryptoCurrency, "USD", func(ctx context.Context) (*domain.ExchangeRateResponse, error) {
+		return s.aggregate(ctx, cryptoCurrency, "USD", s.sources, spotQuote(cryptoCurrency, "USD"))
+	})
+	if err != nil {
+		return nil, err
+	}
+	if usdRate, err = s.applyPeg(usdRate, true); err != nil {
+		return nil, err
+	}
+	if fiatCurrency == "" || fiatCurrency == "USD" {
+		return usdRate, nil
+	}
+	return s.toFiat(ctx, usdRate, fiatCurrency, time.Time{})
+}
+
+// SupportsFiat reports whether balances may be held in the currency.
+func (s *pricingService) SupportsFiat(currency string) bool {
+	if currency == "USD" {
+		return true
+	}
+	if s.fxClient == nil {
+		return false
+	}
+	for _, supported := range s.config.FiatCurrencies {
+		if supported == currency {
+			return true
+		}
+	}
+	return false
+}
+
+// GetDepositRate values a deposit in fiatCurrency at the moment chosen by the
+// configured pricing policy. Moments within SpotWindow of now use the regular
+// spot rate; older ones are priced from the sources' historical data and are
+// neither cached nor persisted.
+func (s *pricingService) GetDepositRate(ctx context.Context, session domain.DepositSession, onChainTime time.Time, fiatCurrency string) (*domain.ExchangeRateResponse, error) {
+	policy := domain.PricingPolicy(s.config.Policy)
+
+	var pricedAt time.Time
+	switch policy {
+	case domain.PricingPolicyOnChainTime:
+		pricedAt = onChainTime
+	case domain.PricingPolicySessionTime:
+		pricedAt = session.CreatedAt
+	default:
+		policy = domain.PricingPolicyVerificationTime
+		pricedAt = time.Now()
+	}
+
+	if policy == domain.PricingPolicyVerificationTime || pricedAt.IsZero() || time.Since(pricedAt) <= s.config.SpotWindow {
+		rate, err := s.GetExchangeRate(ctx, session.CryptoCurrency, fiatCurrency)
+		if err != nil {
+			return nil, err
+		}
+		rate.Policy = policy
+		rate.PricedAt = rate.FetchedAt
+		return rate, nil
+	}
+
+	metrics.Add("pricing.historical_lookups", 1)
+	rate, err := s.aggregate(ctx, session.CryptoCurrency, "USD", s.sources, historicalQuote(session.CryptoCurrency, "USD", pricedAt))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s/USD rate at %s: %w", session.CryptoCurrency, pricedAt.Format(time.RFC3339), err)
+	}
+	if rate, err = s.applyPeg(rate, false); err != nil {
+		return nil, err
+	}
+	if fiatCurrency != "" && fiatCurrency != "USD" {
+		if rate, err = s.toFiat(ctx, rate, fiatCurrency, pricedAt); err != nil {
+			return nil, err
+		}
+	}
+	rate.Policy = policy
+	rate.PricedAt = pricedAt
+	rate.Age = time.Since(pricedAt)
+	return rate, nil
+}
+
+// toFiat converts a USD rate into fiatCurrency using the spot FX rate, or the
+// rate published for the day of at when at is set.
+func (s *pricingService) toFiat(ctx context.Context, usdRate *domain.ExchangeRateResponse, fiatCurrency string, at time.Time) (*domain.ExchangeRateResponse, error) {
+	if !s.SupportsFiat(fiatCurrency) {
+		return nil, fmt.Errorf("unsupported fiat currency %s", fiatCurrency)
+	}
+
+	var fxRate float64
+	var fxStale bool
+	if at.IsZero() {
+		fx, err := s.getRate(ctx, "USD", fiatCurrency, func(ctx context.Context) (*domain.ExchangeRateResponse, error) {
+			rate, err := s.fxClient.GetRate(ctx, "USD", fiatCurrency)
+			if err != nil {
+				return nil, err
+			}
+			now := time.Now()
+			return &domain.ExchangeRateResponse{
+				CryptoCurrency: "USD",
+				FiatCurrency:   fiatCurrency,
+				Rate:           rate,
+				LastUpdated:    now.Format(time.RFC3339),
+				FetchedAt:      now,
+				Method:         "fx",
+			}, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		fxRate, fxStale = fx.Rate, fx.Stale
+	} else {
+		rate, err := s.fxClient.GetHistoricalRate(ctx, "USD", fiatCurrency, at)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get USD/%s rate at %s: %w", fiatCurrency, at.Format(time.RFC3339), err)
+		}
+		fxRate = rate
+	}
+
+	converted := *usdRate
+	converted.FiatCurrency = fiatCurrency
+	converted.PriceUSD = usdRate.Rate
+	converted.FXRate = fxRate
+	converted.Rate = usdRate.Rate * fxRate
+	converted.Stale = usdRate.Stale || fxStale
+	return &converted, nil
+}
+
+// getRate returns the from/to rate from the in-process cache when fresh,
+// otherwise runs load once for all concurrent callers and writes the result
+// through to exchange_rates. If load fails, the last persisted rate is
+// returned marked as stale, provided it is younger than StaleRateMaxAge.
+func (s *pricingService) getRate(ctx context.Context, from, to string, load rateLoader) (*domain.ExchangeRateResponse, error) {
+	key := from + "/" + to
+
+	if rate, ok := s.cached(key); ok {
+		metrics.Add("pricing.cache_hits", 1)
+		return rate, nil
+	}
+	metrics.Add("pricing.cache_misses", 1)
+
+	result, err, _ := s.group.Do(key, func() (interface{}, error) {
+		return s.fetch(ctx, key, from, to, load)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rate := *result.(*domain.ExchangeRateResponse)
+	rate.Age = time.Since(rate.FetchedAt)
+	return &rate, nil
+}
+
+func (s *pricingService) cached(key string) (*domain.ExchangeRateResponse, bool) {
+	if !s.cacheEnabled {
+		return nil, false
+	}
+
+	s.mu.RLock()
+	entry, exists := s.cache[key]
+	s.mu.RUnlock()
+	if !exists || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+
+	rate := entry.rate
+	rate.Age = time.Since(rate.FetchedAt)
+	return &rate, true
+}
+
+func (s *pricingService) fetch(ctx context.Context, key, from, to string, load rateLoader) (*domain.ExchangeRateResponse, error) {
+	rate, err := load(ctx)
+	if errors.Is(err, ErrPriceRejected) {
+		// The sources are reachable and disagree; an older rate would only
+		// hide that.
+		s.logger.Warn().
+			Err(err).
+			Str("pair", key).
+			Msg("Price oracle rejected quotes")
+		return nil, fmt.Errorf("failed to get exchange rate for %s: %w", key, err)
+	}
+	if err != nil {
+		stale, staleErr := s.lastPersisted(ctx, from, to)
+		if staleErr != nil {
+			s.logger.Error().
+				Err(staleErr).
+				Str("pair", key).
+				Msg("No usable persisted exchange rate")
+			return nil, fmt.Errorf("failed to get exchange rate for %s: %w", key, err)
+		}
+
+		metrics.Add("pricing.stale_served", 1)
+		s.logger.Warn().
+			Err(err).
+			Str("pair", key).
+			Dur("age", time.Since(stale.FetchedAt)).
+			Msg("Price oracle unavailable, using last persisted rate")
+		return stale, nil
+	}
+
+	if s.cacheEnabled {
+		s.mu.Lock()
+		s.cache[key] = cachedRate{rate: *rate, expiresAt: rate.FetchedAt.Add(s.cacheTTL)}
+		s.mu.Unlock()
+	}
+
+	if err := s.exchangeRateRepo.SaveExchangeRate(ctx, domain.ExchangeRate{
+		FromCurrency: from,
+		ToCurrency:   to,
+		Rate:         rate.Rate,
+		UpdatedAt:    rate.FetchedAt,
+	}); err != nil {
+		s.logger.Warn().
+			Err(err).
+			Str("pair", key).
+			Msg("Failed to persist exchange rate")
+	}
+
+	return rate, nil
+}
+
+func (s *pricingService) lastPersisted(ctx context.Context, cryptoCurrency, fiatCurrency string) (*domain.ExchangeRateResponse, error) {
+	stored, err := s.exchangeRateRepo.GetExchangeRate(ctx, cryptoCurrency, fiatCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	age := time.Since(stored.UpdatedAt)
+	if s.config.StaleRateMaxAge > 0 && age > s.config.StaleRateMaxAge {
+		return nil, fmt.Errorf("persisted rate for %s/%s is %s old, exceeds %s", cryptoCurrency, fiatCurrency, age.Round(time.Second), s.config.StaleRateMaxAge)
+	}
+
+	rate := &domain.ExchangeRateResponse{
+		CryptoCurrency: stored.FromCurrency,
+		FiatCurrency:   stored.ToCurrency,
+		Rate:           stored.Rate,
+		LastUpdated:    stored.UpdatedAt.Format(time.RFC3339),
+		FetchedAt:      stored.UpdatedAt,
+		Age:            age,
+		Stale:          true,
+	}
+	if stored.ToCurrency == "USD" {
+		rate.PriceUSD = stored.Rate
+	}
+	return rate, nil
+}
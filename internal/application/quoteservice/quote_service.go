@@ -0,0 +1,33 @@
+package quoteservice
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/tuncanbit/tvs/internal/domain"
+)
+
+var (
+	ErrSessionNotFound     = errors.New("deposit session not found")
+	ErrNotSessionOwner     = errors.New("deposit session belongs to another user")
+	ErrSessionNotPending   = errors.New("deposit session is no longer pending")
+	ErrQuoteSettled        = errors.New("quote for this session is already settled")
+	ErrQuoteNotFound       = errors.New("no quote for this session")
+	ErrUnsupportedCurrency = errors.New("unsupported fiat currency")
+	ErrInvalidSlippage     = errors.New("slippage tolerance out of range")
+	ErrPriceUnavailable    = errors.New("no fresh price available to quote")
+)
+
+type IQuoteService interface {
+	CreateQuote(ctx context.Context, userID string, req domain.CreateQuoteRequest) (*domain.DepositQuote, error)
+	GetQuote(ctx context.Context, userID, sessionID string) (*domain.DepositQuote, error)
+	// GetSessionQuote returns the session's quote in any status, or nil if the
+	// session was never quoted.
+	GetSessionQuote(ctx context.Context, sessionID string) (*domain.DepositQuote, error)
+	// MinCryptoAmount is the smallest payment still honored at the quoted value.
+	MinCryptoAmount(quote *domain.DepositQuote) float64
+	// Settle values a payment against the quote and marks the quote consumed
+	// or repriced. market is the rate the deposit would get without a quote.
+	Settle(ctx context.Context, quote *domain.DepositQuote, market *domain.ExchangeRateResponse, cryptoAmount float64, paidAt time.Time) (*domain.ExchangeRateResponse, error)
+}
@@ -0,0 +1,223 @@
+package quoteservice
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/internal/application/pricingservice"
+	"github.com/tuncanbit/tvs/internal/domain"
+	"github.com/tuncanbit/tvs/internal/repositories/quoterepo"
+	"github.com/tuncanbit/tvs/internal/repositories/sessionrepo"
+	"github.com/tuncanbit/tvs/internal/repositories/userrepo"
+	"github.com/tuncanbit/tvs/pkg/config"
+	"github.com/tuncanbit/tvs/pkg/currency"
+	"github.com/tuncanbit/tvs/pkg/metrics"
+)
+
+const (
+	defaultQuoteTTL = 15 * time.Minute
+	// quotedCryptoDecimals is the precision quoted crypto amounts are rounded
+	// up to; every supported token has at least this many decimals.
+	quotedCryptoDecimals = 6
+)
+
+type quoteService struct {
+	quoteRepo     quoterepo.IQuoteRepository
+	sessionRepo   sessionrepo.ISessionRepository
+	userRepo      userrepo.IUserRepository
+	pricingSvc    pricingservice.IPricingService
+	config        config.QuotesConfig
+	currencyUtils *currency.CurrencyUtils
+	logger        zerolog.Logger
+}
+
+func New(
+	quoteRepo quoterepo.IQuoteRepository,
+	sessionRepo sessionrepo.ISessionRepository,
+	userRepo userrepo.IUserRepository,
+	pricingSvc pricingservice.IPricingService,
+	cfg config.QuotesConfig,
+	logger zerolog.Logger,
+) IQuoteService {
+	return &quoteService{
+		quoteRepo:     quoteRepo,
+		sessionRepo:   sessionRepo,
+		userRepo:      userRepo,
+		pricingSvc:    pricingSvc,
+		config:        cfg,
+		currencyUtils: currency.NewCurrencyUtils(),
+		logger:        logger.With().Str("component", "quote_service").Logger(),
+	}
+}
+
+// CreateQuote locks the crypto amount needed to deposit req.FiatAmount into
+// the session at the current oracle price. Re-quoting replaces an active
+// quote; settled quotes cannot be changed.
+func (s *quoteService) CreateQuote(ctx context.Context, userID string, req domain.CreateQuoteRequest) (*domain.DepositQuote, error) {
+	session, err := s.sessionRepo.GetBySessionID(ctx, req.SessionID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSessionNotFound, err)
+	}
+	if session.UserID != userID {
+		return nil, ErrNotSessionOwner
+	}
+	if session.Status != domain.SessionStatusPending {
+		return nil, ErrSessionNotPending
+	}
+
+	fiatCurrency := req.FiatCurrency
+	if fiatCurrency == "" {
+		fiatCurrency, err = s.userRepo.GetDefaultCurrency(ctx, userID)
+		if err != nil || fiatCurrency == "" {
+			fiatCurrency = "USD"
+		}
+	}
+	if !s.pricingSvc.SupportsFiat(fiatCurrency) {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, fiatCurrency)
+	}
+
+	slippageBps := s.config.DefaultSlippageBps
+	if req.SlippageBps != nil {
+		slippageBps = *req.SlippageBps
+	}
+	if slippageBps < 0 || slippageBps > s.config.MaxSlippageBps {
+		return nil, fmt.Errorf("%w: %d bps, maximum is %d", ErrInvalidSlippage, slippageBps, s.config.MaxSlippageBps)
+	}
+
+	rate, err := s.pricingSvc.GetExchangeRate(ctx, session.CryptoCurrency, fiatCurrency)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrPriceUnavailable, err)
+	}
+	if rate.Stale || rate.Rate <= 0 {
+		return nil, ErrPriceUnavailable
+	}
+
+	fiatAmountCents := s.currencyUtils.MajorToMinorUnits(req.FiatAmount, fiatCurrency)
+	fiatAmount := s.currencyUtils.MinorUnitsToMajor(fiatAmountCents, fiatCurrency)
+	scale := math.Pow(10, quotedCryptoDecimals)
+	cryptoAmount := math.Ceil(fiatAmount/rate.Rate*scale) / scale
+
+	ttl := s.config.TTL
+	if ttl <= 0 {
+		ttl = defaultQuoteTTL
+	}
+
+	quote, err := s.quoteRepo.SaveQuote(ctx, domain.DepositQuote{
+		SessionID:       session.SessionID,
+		UserID:          userID,
+		CryptoCurrency:  session.CryptoCurrency,
+		FiatCurrency:    fiatCurrency,
+		FiatAmountCents: fiatAmountCents,
+		CryptoAmount:    cryptoAmount,
+		ExchangeRate:    rate.Rate,
+		PriceUSD:        rate.PriceUSD,
+		SlippageBps:     slippageBps,
+		ExpiresAt:       time.Now().Add(ttl),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrQuoteSettled
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.Add("quotes.created", 1)
+	s.logger.Info().
+		Str("session_id", quote.SessionID).
+		Str("fiat", s.currencyUtils.Format(quote.FiatAmountCents, quote.FiatCurrency)).
+		Float64("crypto_amount", quote.CryptoAmount).
+		Time("expires_at", quote.ExpiresAt).
+		Msg("Deposit quote locked")
+	return quote, nil
+}
+
+func (s *quoteService) GetQuote(ctx context.Context, userID, sessionID string) (*domain.DepositQuote, error) {
+	quote, err := s.GetSessionQuote(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if quote == nil {
+		return nil, ErrQuoteNotFound
+	}
+	if quote.UserID != userID {
+		return nil, ErrNotSessionOwner
+	}
+	return quote, nil
+}
+
+func (s *quoteService) GetSessionQuote(ctx context.Context, sessionID string) (*domain.DepositQuote, error) {
+	quote, err := s.quoteRepo.GetQuoteBySessionID(ctx, sessionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return quote, err
+}
+
+func (s *quoteService) MinCryptoAmount(quote *domain.DepositQuote) float64 {
+	return quote.CryptoAmount * (1 - float64(quote.SlippageBps)/10000)
+}
+
+// Settle honors the quote when the payment lands before expiry and within the
+// slippage tolerance; a payment inside the tolerance is credited the full
+// quoted amount. Otherwise the deposit is repriced: at market, or under the
+// lower_of policy at whichever of the market and locked rates is lower.
+func (s *quoteService) Settle(ctx context.Context, quote *domain.DepositQuote, market *domain.ExchangeRateResponse, cryptoAmount float64, paidAt time.Time) (*domain.ExchangeRateResponse, error) {
+	locked := *market
+	locked.Rate = quote.ExchangeRate
+	locked.PriceUSD = quote.PriceUSD
+	locked.FXRate = 0
+	if quote.FiatCurrency != "USD" && quote.PriceUSD > 0 {
+		locked.FXRate = quote.ExchangeRate / quote.PriceUSD
+	}
+	locked.PricedAt = quote.CreatedAt
+	locked.Stale = false
+	locked.Sources = nil
+	locked.Method = "locked_quote"
+
+	onTime := !paidAt.After(quote.ExpiresAt)
+	withinTolerance := cryptoAmount >= s.MinCryptoAmount(quote)
+
+	var result domain.ExchangeRateResponse
+	var status domain.QuoteStatus
+	if onTime && withinTolerance {
+		result = locked
+		if cryptoAmount < quote.CryptoAmount {
+			scale := quote.CryptoAmount / cryptoAmount
+			result.Rate *= scale
+			result.PriceUSD *= scale
+		}
+		status = domain.QuoteStatusConsumed
+	} else {
+		result = *market
+		if domain.LatePaymentPolicy(s.config.LatePaymentPolicy) == domain.LatePaymentPolicyLowerOf && locked.Rate < market.Rate {
+			result = locked
+		}
+		result.Method = "repriced_quote"
+		status = domain.QuoteStatusRepriced
+
+		s.logger.Warn().
+			Str("session_id", quote.SessionID).
+			Bool("on_time", onTime).
+			Bool("within_tolerance", withinTolerance).
+			Float64("quoted_rate", quote.ExchangeRate).
+			Float64("market_rate", market.Rate).
+			Float64("applied_rate", result.Rate).
+			Msg("Deposit missed its quote, repriced")
+	}
+
+	settled, err := s.quoteRepo.SettleQuote(ctx, quote.SessionID, status)
+	if err != nil {
+		return nil, err
+	}
+	if settled {
+		metrics.Add("quotes."+string(status), 1)
+		quote.Status = status
+		quote.SettledAt = time.Now()
+	}
+	return &result, nil
+}
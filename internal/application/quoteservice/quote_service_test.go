@@ -0,0 +1,160 @@
+package quoteservice
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/internal/domain"
+	"github.com/tuncanbit/tvs/internal/repositories/quoterepo"
+	"github.com/tuncanbit/tvs/pkg/config"
+)
+
+type fakeQuoteRepo struct {
+	quoterepo.IQuoteRepository
+	settled bool
+	err     error
+	status  domain.QuoteStatus
+	calls   int
+}
+
+func (r *fakeQuoteRepo) SettleQuote(_ context.Context, _ string, status domain.QuoteStatus) (bool, error) {
+	r.calls++
+	r.status = status
+	return r.settled, r.err
+}
+
+func TestSettle(t *testing.T) {
+	expiresAt := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	errRepo := errors.New("database unavailable")
+	tests := []struct {
+		name         string
+		policy       domain.LatePaymentPolicy
+		marketRate   float64
+		cryptoAmount float64
+		paidAt       time.Time
+		alreadyDone  bool // SettleQuote reports the quote was settled before
+		repoErr      error
+
+		wantStatus domain.QuoteStatus
+		wantMethod string
+		wantRate   float64
+		wantFiat   float64 // cryptoAmount valued at the applied rate
+		wantErr    error
+	}{
+		{
+			name: "exact payment on time", marketRate: 1900, cryptoAmount: 1, paidAt: expiresAt.Add(-time.Minute),
+			wantStatus: domain.QuoteStatusConsumed, wantMethod: "locked_quote", wantRate: 2000, wantFiat: 2000,
+		},
+		{
+			name: "underpayment within tolerance is credited the quoted fiat", marketRate: 1900, cryptoAmount: 0.995, paidAt: expiresAt.Add(-time.Minute),
+			wantStatus: domain.QuoteStatusConsumed, wantMethod: "locked_quote", wantRate: 2000 / 0.995, wantFiat: 2000,
+		},
+		{
+			name: "underpayment at the tolerance", marketRate: 1900, cryptoAmount: 0.99, paidAt: expiresAt.Add(-time.Minute),
+			wantStatus: domain.QuoteStatusConsumed, wantMethod: "locked_quote", wantRate: 2000 / 0.99, wantFiat: 2000,
+		},
+		{
+			name: "overpayment keeps the locked rate", marketRate: 1900, cryptoAmount: 1.5, paidAt: expiresAt.Add(-time.Minute),
+			wantStatus: domain.QuoteStatusConsumed, wantMethod: "locked_quote", wantRate: 2000, wantFiat: 3000,
+		},
+		{
+			name: "paid at expiry is on time", marketRate: 2100, cryptoAmount: 1, paidAt: expiresAt,
+			wantStatus: domain.QuoteStatusConsumed, wantMethod: "locked_quote", wantRate: 2000, wantFiat: 2000,
+		},
+		{
+			name: "paid after expiry is repriced at market", policy: domain.LatePaymentPolicyMarket, marketRate: 2100, cryptoAmount: 1, paidAt: expiresAt.Add(time.Nanosecond),
+			wantStatus: domain.QuoteStatusRepriced, wantMethod: "repriced_quote", wantRate: 2100, wantFiat: 2100,
+		},
+		{
+			name: "short of tolerance is repriced at market", policy: domain.LatePaymentPolicyMarket, marketRate: 1900, cryptoAmount: 0.98, paidAt: expiresAt.Add(-time.Minute),
+			wantStatus: domain.QuoteStatusRepriced, wantMethod: "repriced_quote", wantRate: 1900, wantFiat: 0.98 * 1900,
+		},
+		{
+			name: "lower_of keeps the locked rate when the market rose", policy: domain.LatePaymentPolicyLowerOf, marketRate: 2100, cryptoAmount: 1, paidAt: expiresAt.Add(time.Minute),
+			wantStatus: domain.QuoteStatusRepriced, wantMethod: "repriced_quote", wantRate: 2000, wantFiat: 2000,
+		},
+		{
+			name: "lower_of takes the market when it fell", policy: domain.LatePaymentPolicyLowerOf, marketRate: 1900, cryptoAmount: 1, paidAt: expiresAt.Add(time.Minute),
+			wantStatus: domain.QuoteStatusRepriced, wantMethod: "repriced_quote", wantRate: 1900, wantFiat: 1900,
+		},
+		{
+			name: "lower_of does not rescale a short payment", policy: domain.LatePaymentPolicyLowerOf, marketRate: 2100, cryptoAmount: 0.98, paidAt: expiresAt.Add(-time.Minute),
+			wantStatus: domain.QuoteStatusRepriced, wantMethod: "repriced_quote", wantRate: 2000, wantFiat: 0.98 * 2000,
+		},
+		{
+			name: "replay of a settled quote", marketRate: 1900, cryptoAmount: 0.995, paidAt: expiresAt.Add(-time.Minute), alreadyDone: true,
+			wantMethod: "locked_quote", wantRate: 2000 / 0.995, wantFiat: 2000,
+		},
+		{
+			name: "settle error", marketRate: 1900, cryptoAmount: 1, paidAt: expiresAt.Add(-time.Minute), repoErr: errRepo,
+			wantErr: errRepo,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeQuoteRepo{settled: !tt.alreadyDone, err: tt.repoErr}
+			s := &quoteService{
+				quoteRepo: repo,
+				config:    config.QuotesConfig{LatePaymentPolicy: string(tt.policy)},
+				logger:    zerolog.Nop(),
+			}
+			quote := &domain.DepositQuote{
+				SessionID:       "sess_1",
+				FiatCurrency:    "EUR",
+				FiatAmountCents: 200_000,
+				CryptoAmount:    1,
+				ExchangeRate:    2000,
+				PriceUSD:        2200,
+				SlippageBps:     100,
+				Status:          domain.QuoteStatusActive,
+				ExpiresAt:       expiresAt,
+			}
+			market := &domain.ExchangeRateResponse{Rate: tt.marketRate, PriceUSD: tt.marketRate * 1.1, Method: "median"}
+
+			got, err := s.Settle(context.Background(), quote, market, tt.cryptoAmount, tt.paidAt)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Settle() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.Method != tt.wantMethod {
+				t.Errorf("Method = %q, want %q", got.Method, tt.wantMethod)
+			}
+			if !approx(got.Rate, tt.wantRate) {
+				t.Errorf("Rate = %v, want %v", got.Rate, tt.wantRate)
+			}
+			if fiat := tt.cryptoAmount * got.Rate; !approx(fiat, tt.wantFiat) {
+				t.Errorf("credited %v, want %v", fiat, tt.wantFiat)
+			}
+			if got.Method == "locked_quote" && !approx(got.PriceUSD/got.Rate, 1.1) {
+				t.Errorf("PriceUSD = %v not rescaled with Rate %v", got.PriceUSD, got.Rate)
+			}
+
+			wantRepoStatus := tt.wantStatus
+			if tt.alreadyDone {
+				wantRepoStatus = domain.QuoteStatusConsumed
+			}
+			if repo.calls != 1 || repo.status != wantRepoStatus {
+				t.Errorf("SettleQuote called %d times with %q, want once with %q", repo.calls, repo.status, wantRepoStatus)
+			}
+			if tt.alreadyDone {
+				if quote.Status != domain.QuoteStatusActive || !quote.SettledAt.IsZero() {
+					t.Errorf("replay changed the quote to %q at %v", quote.Status, quote.SettledAt)
+				}
+				return
+			}
+			if quote.Status != tt.wantStatus || quote.SettledAt.IsZero() {
+				t.Errorf("quote = %q at %v, want %q", quote.Status, quote.SettledAt, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func approx(got, want float64) bool {
+	return math.Abs(got-want) <= 1e-9*math.Max(1, math.Abs(want))
+}
@@ -0,0 +1,327 @@
+package reviewservice
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tuncanbit/tvs/internal/domain"
+	"github.com/tuncanbit/tvs/pkg/address"
+	"github.com/tuncanbit/tvs/pkg/metrics"
+)
+
+// heldDepositMessage is all the depositor is told about a hold besides its
+// code. The reason, which may be a screening hit, stays in the metadata and
+// the audit log. Deposits kept by a deposit limit or self-exclusion get
+// limitHeldDepositMessage instead.
+const (
+	heldDepositMessage      = "Deposit is held for review"
+	limitHeldDepositMessage = "Deposit is held for refund"
+)
+
+func (s *reviewService) HoldDeposit(ctx context.Context, session domain.DepositSession, hold domain.DepositHold) error {
+	metadata, err := json.Marshal(domain.DepositSessionMetadata{Hold: &hold})
+	if err != nil {
+		return fmt.Errorf("failed to marshal hold for session %s: %w", session.SessionID, err)
+	}
+
+	previousStatus := session.Status
+	session.Status = domain.SessionStatusHeld
+	message := heldDepositMessage
+	if hold.Code.Refundable() {
+		session.Status = domain.SessionStatusLimitHeld
+		message = limitHeldDepositMessage
+	}
+	session.Metadata = metadata
+	session.ErrorMessage = fmt.Sprintf("%s: %s", message, hold.Code)
+	session.UpdatedAt = time.Now()
+	if err := s.sessionRepo.CompleteSession(ctx, session, session.ErrorMessage); err != nil {
+		return err
+	}
+
+	var txHash string
+	if hold.Match != nil {
+		txHash = hold.Match.Transaction.Signature
+	}
+	if session.Status == domain.SessionStatusLimitHeld {
+		metrics.Add("deposits.limit_held", 1)
+	} else {
+		metrics.Add("deposits.review.held", 1)
+	}
+	s.logger.Warn().
+		Str("session_id", session.SessionID).
+		Str("user_id", session.UserID).
+		Str("status", string(session.Status)).
+		Str("from_address", hold.FromAddress).
+		Str("tx_hash", txHash).
+		Str("code", string(hold.Code)).
+		Str("reason", hold.Reason).
+		Msg("Deposit held")
+	s.writeAudit(ctx, auditActionDepositHeld, auditEntityDeposit, session.SessionID, "", domain.RequestInfo{}, map[string]interface{}{
+		"status": previousStatus,
+	}, map[string]interface{}{
+		"status":       session.Status,
+		"code":         hold.Code,
+		"reason":       hold.Reason,
+		"from_address": hold.FromAddress,
+		"tx_hash":      txHash,
+		"screening":    hold.Screening,
+	})
+	s.wsHub.BroadcastDepositSession(depositorView(session))
+	return nil
+}
+
+func (s *reviewService) ListHeldDeposits(ctx context.Context, limit, offset int) ([]domain.DepositSession, error) {
+	return s.sessionRepo.ListHeldSessions(ctx, domain.SessionStatusHeld, limit, offset)
+}
+
+func (s *reviewService) ReleaseDeposit(ctx context.Context, adminID, sessionID string, req domain.ReleaseDepositRequest, info domain.RequestInfo) (*domain.DepositSession, error) {
+	session, hold, err := s.loadHeldDeposit(ctx, sessionID, "", domain.SessionStatusHeld)
+	if err != nil {
+		return nil, err
+	}
+	if session.UserID == adminID {
+		return nil, ErrSelfApproval
+	}
+
+	if err := s.resolveHold(ctx, session, hold, domain.ReviewDecisionApproved, adminID, req.Note, domain.SessionStatusPending, "Released from review"); err != nil {
+		return nil, err
+	}
+
+	metrics.Add("deposits.review.released", 1)
+	s.writeAudit(ctx, auditActionDepositReleased, auditEntityDeposit, session.SessionID, adminID, info, map[string]interface{}{
+		"status": domain.SessionStatusHeld,
+	}, map[string]interface{}{
+		"status": session.Status,
+		"note":   req.Note,
+	})
+	s.wsHub.BroadcastDepositSession(depositorView(*session))
+	return session, nil
+}
+
+func (s *reviewService) RejectDeposit(ctx context.Context, adminID, sessionID string, req domain.RejectDepositRequest, info domain.RequestInfo) (*domain.DepositSession, error) {
+	session, hold, err := s.loadHeldDeposit(ctx, sessionID, "", domain.SessionStatusHeld)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.resolveHold(ctx, session, hold, domain.ReviewDecisionRejected, adminID, req.Reason, domain.SessionStatusFailed, "Rejected in review"); err != nil {
+		return nil, err
+	}
+
+	metrics.Add("deposits.review.rejected", 1)
+	s.writeAudit(ctx, auditActionDepositRejected, auditEntityDeposit, session.SessionID, adminID, info, map[string]interface{}{
+		"status": domain.SessionStatusHeld,
+	}, map[string]interface{}{
+		"status": session.Status,
+		"reason": req.Reason,
+	})
+	s.wsHub.BroadcastDepositSession(depositorView(*session))
+	return session, nil
+}
+
+func (s *reviewService) ListRefundableDeposits(ctx context.Context, limit, offset int) ([]domain.DepositSession, error) {
+	return s.sessionRepo.ListHeldSessions(ctx, domain.SessionStatusLimitHeld, limit, offset)
+}
+
+func (s *reviewService) ListUserRefundableDeposits(ctx context.Context, userID string) ([]domain.DepositSession, error) {
+	sessions, err := s.sessionRepo.ListUserSessions(ctx, userID, domain.SessionStatusLimitHeld)
+	if err != nil {
+		return nil, err
+	}
+	for i := range sessions {
+		sessions[i] = depositorView(sessions[i])
+	}
+	return sessions, nil
+}
+
+func (s *reviewService) RequestDepositRefund(ctx context.Context, userID, sessionID string, req domain.RequestDepositRefundRequest, info domain.RequestInfo) (*domain.DepositSession, error) {
+	session, hold, err := s.loadHeldDeposit(ctx, sessionID, userID, domain.SessionStatusLimitHeld)
+	if err != nil {
+		return nil, err
+	}
+	if hold.Refund != nil && hold.Refund.RequestedAt != nil {
+		return nil, ErrRefundRequested
+	}
+
+	toAddress, err := refundAddress(session.ChainID, req.ToAddress, hold)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	hold.Refund = &domain.DepositRefund{ToAddress: toAddress, RequestedAt: &now}
+	if err := s.updateHold(ctx, session, hold, domain.SessionStatusLimitHeld, session.ErrorMessage); err != nil {
+		return nil, err
+	}
+
+	metrics.Add("deposits.refund.requested", 1)
+	s.logger.Info().
+		Str("session_id", session.SessionID).
+		Str("user_id", userID).
+		Str("to_address", toAddress).
+		Msg("Deposit refund requested")
+	s.writeAudit(ctx, auditActionRefundRequested, auditEntityDeposit, session.SessionID, "", info, nil, map[string]interface{}{
+		"user_id":    userID,
+		"to_address": toAddress,
+	})
+	view := depositorView(*session)
+	s.wsHub.BroadcastDepositSession(view)
+	return &view, nil
+}
+
+func (s *reviewService) MarkDepositRefunded(ctx context.Context, adminID, sessionID string, req domain.MarkDepositRefundedRequest, info domain.RequestInfo) (*domain.DepositSession, error) {
+	session, hold, err := s.loadHeldDeposit(ctx, sessionID, "", domain.SessionStatusLimitHeld)
+	if err != nil {
+		return nil, err
+	}
+
+	refund := hold.Refund
+	if refund == nil {
+		refund = &domain.DepositRefund{}
+	}
+	requested := req.ToAddress
+	if requested == "" {
+		requested = refund.ToAddress
+	}
+	if refund.ToAddress, err = refundAddress(session.ChainID, requested, hold); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	refund.TxHash = req.TxHash
+	refund.RefundedBy = adminID
+	refund.RefundedAt = &now
+	refund.Note = req.Note
+	hold.Refund = refund
+	if err := s.updateHold(ctx, session, hold, domain.SessionStatusRefunded, "Refunded"); err != nil {
+		return nil, err
+	}
+
+	metrics.Add("deposits.refunded", 1)
+	s.logger.Info().
+		Str("session_id", session.SessionID).
+		Str("admin_id", adminID).
+		Str("to_address", refund.ToAddress).
+		Str("tx_hash", refund.TxHash).
+		Msg("Deposit refunded")
+	s.writeAudit(ctx, auditActionDepositRefunded, auditEntityDeposit, session.SessionID, adminID, info, map[string]interface{}{
+		"status": domain.SessionStatusLimitHeld,
+	}, map[string]interface{}{
+		"status":     session.Status,
+		"to_address": refund.ToAddress,
+		"tx_hash":    refund.TxHash,
+		"note":       req.Note,
+	})
+	s.wsHub.BroadcastDepositSession(depositorView(*session))
+	return session, nil
+}
+
+// loadHeldDeposit returns a session in the held status given and its hold.
+// When userID is set, sessions of other users are reported as not found.
+func (s *reviewService) loadHeldDeposit(ctx context.Context, sessionID, userID string, status domain.SessionStatus) (*domain.DepositSession, *domain.DepositHold, error) {
+	session, err := s.sessionRepo.GetBySessionID(ctx, sessionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil, ErrDepositNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if userID != "" && session.UserID != userID {
+		return nil, nil, ErrDepositNotFound
+	}
+	hold := session.Hold()
+	if session.Status != status || hold == nil {
+		if status == domain.SessionStatusLimitHeld {
+			return nil, nil, ErrDepositNotRefundable
+		}
+		return nil, nil, ErrDepositNotHeld
+	}
+	return &session, hold, nil
+}
+
+// refundAddress returns the address a deposit is refunded to: the address
+// it came from. None of the payout controls on withdrawals run on a
+// refund, so a requested address is only accepted if it is that one.
+func refundAddress(chainID, requested string, hold *domain.DepositHold) (string, error) {
+	if hold.FromAddress == "" {
+		return "", fmt.Errorf("%w: the sender is unknown", ErrInvalidRefundAddress)
+	}
+	sender, err := address.Validate(chainID, hold.FromAddress)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidRefundAddress, err)
+	}
+	if requested == "" {
+		return sender, nil
+	}
+	normalized, err := address.Validate(chainID, requested)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidRefundAddress, err)
+	}
+	if normalized != sender {
+		return "", fmt.Errorf("%w: deposits are only refunded to the address they came from", ErrInvalidRefundAddress)
+	}
+	return sender, nil
+}
+
+// resolveHold records the decision on the hold and moves the session out of
+// held, failing with ErrDepositNotHeld if another admin got there first.
+func (s *reviewService) resolveHold(ctx context.Context, session *domain.DepositSession, hold *domain.DepositHold, decision domain.ReviewDecision, adminID, note string, status domain.SessionStatus, message string) error {
+	now := time.Now()
+	hold.Decision = decision
+	hold.DecidedBy = adminID
+	hold.DecidedAt = &now
+	hold.Note = note
+	return s.updateHold(ctx, session, hold, status, message)
+}
+
+// updateHold stores the hold and moves the session from its held status to
+// status, failing if the session left that status in the meantime.
+func (s *reviewService) updateHold(ctx context.Context, session *domain.DepositSession, hold *domain.DepositHold, status domain.SessionStatus, message string) error {
+	metadata, err := json.Marshal(domain.DepositSessionMetadata{Hold: hold})
+	if err != nil {
+		return fmt.Errorf("failed to marshal hold for session %s: %w", session.SessionID, err)
+	}
+
+	updated, err := s.sessionRepo.ResolveHold(ctx, session.SessionID, session.Status, status, metadata, message)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		if session.Status == domain.SessionStatusLimitHeld {
+			return ErrDepositNotRefundable
+		}
+		return ErrDepositNotHeld
+	}
+	session.Status = status
+	session.Metadata = metadata
+	session.ErrorMessage = message
+	session.UpdatedAt = time.Now()
+	return nil
+}
+
+// depositorView is the session as shown to the depositor, with only the
+// code and outcome of its hold, and its refund, in the metadata.
+func depositorView(session domain.DepositSession) domain.DepositSession {
+	hold := session.Hold()
+	session.Metadata = nil
+	if hold != nil {
+		view := &domain.DepositHold{
+			Code:      hold.Code,
+			HeldAt:    hold.HeldAt,
+			Decision:  hold.Decision,
+			DecidedAt: hold.DecidedAt,
+		}
+		if hold.Refund != nil {
+			view.Refund = &domain.DepositRefund{
+				ToAddress:   hold.Refund.ToAddress,
+				RequestedAt: hold.Refund.RequestedAt,
+				TxHash:      hold.Refund.TxHash,
+				RefundedAt:  hold.Refund.RefundedAt,
+			}
+		}
+		session.Metadata, _ = json.Marshal(domain.DepositSessionMetadata{Hold: view})
+	}
+	return session
+}
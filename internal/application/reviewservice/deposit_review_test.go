@@ -0,0 +1,42 @@
+package reviewservice
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tuncanbit/tvs/internal/domain"
+)
+
+func TestRefundAddress(t *testing.T) {
+	const (
+		sender = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+		other  = "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359"
+	)
+	tests := []struct {
+		name      string
+		from      string
+		requested string
+		wantErr   bool
+	}{
+		{name: "defaults to the sender", from: sender},
+		{name: "the sender", from: sender, requested: sender},
+		{name: "the sender in lower case", from: sender, requested: "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"},
+		{name: "another address", from: sender, requested: other, wantErr: true},
+		{name: "an invalid address", from: sender, requested: "0x1234", wantErr: true},
+		{name: "unknown sender", requested: other, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := refundAddress("eth-mainnet", tt.requested, &domain.DepositHold{FromAddress: tt.from})
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidRefundAddress) {
+					t.Errorf("refundAddress() = %q, %v, want ErrInvalidRefundAddress", got, err)
+				}
+				return
+			}
+			if err != nil || got != sender {
+				t.Errorf("refundAddress() = %q, %v, want %q", got, err, sender)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package reviewservice
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tuncanbit/tvs/internal/domain"
+)
+
+var (
+	ErrWithdrawalNotFound   = errors.New("withdrawal not found")
+	ErrNotAwaitingReview    = errors.New("withdrawal is not awaiting admin review")
+	ErrSelfApproval         = errors.New("admins cannot approve their own withdrawals")
+	ErrAlreadyApproved      = errors.New("admin has already approved this withdrawal")
+	ErrNoApproval           = errors.New("admin has no active approval of this withdrawal")
+	ErrDepositNotFound      = errors.New("deposit session not found")
+	ErrDepositNotHeld       = errors.New("deposit session is not held")
+	ErrDepositNotRefundable = errors.New("deposit session is not held for refund")
+	ErrRefundRequested      = errors.New("a refund of this deposit has already been requested")
+	ErrInvalidRefundAddress = errors.New("invalid refund address")
+)
+
+type IReviewService interface {
+	// Start watches review deadlines until ctx is done, escalating or
+	// rejecting withdrawals whose deadline has passed.
+	Start(ctx context.Context)
+	ListQueue(ctx context.Context, limit, offset int) ([]domain.Withdrawal, error)
+	// Approve records the admin's approval and, once the withdrawal has the
+	// approvals its amount requires, returns it to pending so processing
+	// continues.
+	Approve(ctx context.Context, adminID, withdrawalID string, req domain.ApproveWithdrawalRequest, info domain.RequestInfo) (*domain.ApprovalStatus, error)
+	// RevokeApproval withdraws the admin's approval while quorum has not yet
+	// been reached.
+	RevokeApproval(ctx context.Context, adminID, withdrawalID string, info domain.RequestInfo) (*domain.ApprovalStatus, error)
+	// Reject cancels the withdrawal and releases its balance reservation,
+	// unless funds already left on chain in a mismatched transaction.
+	Reject(ctx context.Context, adminID, withdrawalID string, req domain.RejectWithdrawalRequest, info domain.RequestInfo) (*domain.Withdrawal, error)
+	AddNote(ctx context.Context, adminID, withdrawalID string, req domain.ReviewNoteRequest, info domain.RequestInfo) (*domain.Withdrawal, error)
+	// FlagVerificationMismatch holds a withdrawal whose on-chain transaction
+	// did not match it for admin review. Approving it accepts the
+	// transaction as the payout; rejecting it cancels the withdrawal but
+	// keeps the reservation held for manual reconciliation.
+	FlagVerificationMismatch(ctx context.Context, withdrawal *domain.Withdrawal, verification domain.WithdrawalVerification) error
+	// HoldDeposit holds a paid session instead of crediting it, recording
+	// the hold and the transfer in its metadata. Holds with a refundable code
+	// put the session in limit_held for refund; others await admin review.
+	HoldDeposit(ctx context.Context, session domain.DepositSession, hold domain.DepositHold) error
+	ListHeldDeposits(ctx context.Context, limit, offset int) ([]domain.DepositSession, error)
+	// ReleaseDeposit returns a held session to pending so the verifier
+	// credits the transfer recorded in its hold.
+	ReleaseDeposit(ctx context.Context, adminID, sessionID string, req domain.ReleaseDepositRequest, info domain.RequestInfo) (*domain.DepositSession, error)
+	// RejectDeposit fails a held session without crediting it.
+	RejectDeposit(ctx context.Context, adminID, sessionID string, req domain.RejectDepositRequest, info domain.RequestInfo) (*domain.DepositSession, error)
+	// ListRefundableDeposits returns limit-held sessions, longest held first.
+	ListRefundableDeposits(ctx context.Context, limit, offset int) ([]domain.DepositSession, error)
+	// ListUserRefundableDeposits returns the user's limit-held sessions as
+	// the depositor sees them.
+	ListUserRefundableDeposits(ctx context.Context, userID string) ([]domain.DepositSession, error)
+	// RequestDepositRefund records the user's request for a limit-held
+	// deposit to be sent back to the address it came from. It can be asked
+	// for once per deposit.
+	RequestDepositRefund(ctx context.Context, userID, sessionID string, req domain.RequestDepositRefundRequest, info domain.RequestInfo) (*domain.DepositSession, error)
+	// MarkDepositRefunded records the transaction an admin sent a
+	// limit-held deposit back with and moves the session to refunded.
+	MarkDepositRefunded(ctx context.Context, adminID, sessionID string, req domain.MarkDepositRefundedRequest, info domain.RequestInfo) (*domain.DepositSession, error)
+}
@@ -0,0 +1,535 @@
+package reviewservice
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/internal/application/limitservice"
+	"github.com/tuncanbit/tvs/internal/domain"
+	"github.com/tuncanbit/tvs/internal/repositories/auditrepo"
+	"github.com/tuncanbit/tvs/internal/repositories/balancerepo"
+	"github.com/tuncanbit/tvs/internal/repositories/sessionrepo"
+	"github.com/tuncanbit/tvs/internal/repositories/withdrawalrepo"
+	"github.com/tuncanbit/tvs/internal/server/websocket"
+	"github.com/tuncanbit/tvs/pkg/config"
+	"github.com/tuncanbit/tvs/pkg/currency"
+	"github.com/tuncanbit/tvs/pkg/metrics"
+)
+
+const (
+	auditEntityWithdrawal = "withdrawal"
+	auditEntityDeposit    = "deposit_session"
+
+	auditActionApproved        = "withdrawal_review_approved"
+	auditActionApproval        = "withdrawal_review_approval_recorded"
+	auditActionRevoked         = "withdrawal_review_approval_revoked"
+	auditActionRejected        = "withdrawal_review_rejected"
+	auditActionNote            = "withdrawal_review_note"
+	auditActionEscalated       = "withdrawal_review_escalated"
+	auditActionAutoRejected    = "withdrawal_review_auto_rejected"
+	auditActionMismatch        = "withdrawal_review_verification_mismatch"
+	auditActionDepositHeld     = "deposit_review_held"
+	auditActionDepositReleased = "deposit_review_released"
+	auditActionDepositRejected = "deposit_review_rejected"
+	auditActionRefundRequested = "deposit_refund_requested"
+	auditActionDepositRefunded = "deposit_refunded"
+
+	deadlineActionReject = "reject"
+
+	defaultReviewWindow        = 24 * time.Hour
+	defaultReviewCheckInterval = 5 * time.Minute
+)
+
+type reviewService struct {
+	withdrawalRepo withdrawalrepo.IWithdrawalRepository
+	sessionRepo    sessionrepo.ISessionRepository
+	balanceRepo    balancerepo.IBalanceRepository
+	auditRepo      auditrepo.IAuditRepository
+	limitSvc       limitservice.ILimitService
+	wsHub          *websocket.WsHub
+	config         config.WithdrawalsConfig
+	currencyUtils  *currency.CurrencyUtils
+	logger         zerolog.Logger
+}
+
+func New(
+	withdrawalRepo withdrawalrepo.IWithdrawalRepository,
+	sessionRepo sessionrepo.ISessionRepository,
+	balanceRepo balancerepo.IBalanceRepository,
+	auditRepo auditrepo.IAuditRepository,
+	limitSvc limitservice.ILimitService,
+	wsHub *websocket.WsHub,
+	cfg config.WithdrawalsConfig,
+	logger zerolog.Logger,
+) IReviewService {
+	return &reviewService{
+		withdrawalRepo: withdrawalRepo,
+		sessionRepo:    sessionRepo,
+		balanceRepo:    balanceRepo,
+		auditRepo:      auditRepo,
+		limitSvc:       limitSvc,
+		wsHub:          wsHub,
+		config:         cfg,
+		currencyUtils:  currency.NewCurrencyUtils(),
+		logger:         logger.With().Str("component", "review_service").Logger(),
+	}
+}
+
+func (s *reviewService) Start(ctx context.Context) {
+	interval := s.config.ReviewCheckInterval
+	if interval <= 0 {
+		interval = defaultReviewCheckInterval
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.processOverdueReviews(ctx); err != nil {
+					s.logger.Error().Err(err).Msg("Failed to process overdue withdrawal reviews")
+				}
+			}
+		}
+	}()
+}
+
+func (s *reviewService) ListQueue(ctx context.Context, limit, offset int) ([]domain.Withdrawal, error) {
+	return s.withdrawalRepo.ListAwaitingReview(ctx, limit, offset)
+}
+
+func (s *reviewService) Approve(ctx context.Context, adminID, withdrawalID string, req domain.ApproveWithdrawalRequest, info domain.RequestInfo) (*domain.ApprovalStatus, error) {
+	withdrawal, review, err := s.loadForReview(ctx, withdrawalID)
+	if err != nil {
+		return nil, err
+	}
+	if adminID == withdrawal.UserID {
+		return nil, ErrSelfApproval
+	}
+
+	_, err = s.withdrawalRepo.AddApproval(ctx, domain.WithdrawalApproval{
+		WithdrawalID: withdrawalID,
+		AdminID:      adminID,
+		Note:         req.Note,
+		IPAddress:    info.IPAddress,
+		UserAgent:    info.UserAgent,
+	})
+	if errors.Is(err, withdrawalrepo.ErrApprovalExists) {
+		return nil, ErrAlreadyApproved
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := s.approvalStatus(ctx, withdrawal)
+	if err != nil {
+		return nil, err
+	}
+	s.audit(ctx, auditActionApproval, withdrawal, adminID, info, map[string]interface{}{
+		"note":               req.Note,
+		"approvals":          len(status.Approvals),
+		"required_approvals": status.RequiredApprovals,
+	})
+	if !status.QuorumReached {
+		metrics.Add("withdrawals.review.partial_approvals", 1)
+		return status, nil
+	}
+
+	now := time.Now()
+	review.Decision = domain.ReviewDecisionApproved
+	review.DecidedBy = adminID
+	review.DecidedAt = &now
+	if req.Note != "" {
+		review.Notes = append(review.Notes, domain.ReviewNote{AdminID: adminID, Note: req.Note, CreatedAt: now})
+	}
+	if err := s.resolve(ctx, withdrawal, review, domain.WithdrawalStatusPending, adminID, ""); err != nil {
+		return nil, err
+	}
+
+	approvers := make([]string, len(status.Approvals))
+	for i, approval := range status.Approvals {
+		approvers[i] = approval.AdminID
+	}
+	metrics.Add("withdrawals.review.approved", 1)
+	s.audit(ctx, auditActionApproved, withdrawal, adminID, info, map[string]interface{}{
+		"status":    withdrawal.Status,
+		"approvers": approvers,
+	})
+	s.wsHub.BroadcastWithdrawal(*withdrawal)
+	return status, nil
+}
+
+func (s *reviewService) RevokeApproval(ctx context.Context, adminID, withdrawalID string, info domain.RequestInfo) (*domain.ApprovalStatus, error) {
+	withdrawal, _, err := s.loadForReview(ctx, withdrawalID)
+	if err != nil {
+		return nil, err
+	}
+
+	revoked, err := s.withdrawalRepo.RevokeApproval(ctx, withdrawalID, adminID)
+	if err != nil {
+		return nil, err
+	}
+	if !revoked {
+		return nil, ErrNoApproval
+	}
+
+	status, err := s.approvalStatus(ctx, withdrawal)
+	if err != nil {
+		return nil, err
+	}
+	s.audit(ctx, auditActionRevoked, withdrawal, adminID, info, map[string]interface{}{
+		"approvals":          len(status.Approvals),
+		"required_approvals": status.RequiredApprovals,
+	})
+	return status, nil
+}
+
+// approvalStatus counts the active approvals of admins other than the
+// requesting user against the quorum the withdrawal's amount requires.
+func (s *reviewService) approvalStatus(ctx context.Context, withdrawal *domain.Withdrawal) (*domain.ApprovalStatus, error) {
+	approvals, err := s.withdrawalRepo.ListActiveApprovals(ctx, withdrawal.WithdrawalID)
+	if err != nil {
+		return nil, err
+	}
+
+	eligible := make([]domain.WithdrawalApproval, 0, len(approvals))
+	for _, approval := range approvals {
+		if approval.AdminID != withdrawal.UserID {
+			eligible = append(eligible, approval)
+		}
+	}
+
+	required := s.limitSvc.RequiredApprovals(*withdrawal)
+	return &domain.ApprovalStatus{
+		Withdrawal:        withdrawal,
+		Approvals:         eligible,
+		RequiredApprovals: required,
+		QuorumReached:     len(eligible) >= required,
+	}, nil
+}
+
+func (s *reviewService) Reject(ctx context.Context, adminID, withdrawalID string, req domain.RejectWithdrawalRequest, info domain.RequestInfo) (*domain.Withdrawal, error) {
+	withdrawal, review, err := s.loadForReview(ctx, withdrawalID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.reject(ctx, withdrawal, review, adminID, req.Reason); err != nil {
+		return nil, err
+	}
+
+	metrics.Add("withdrawals.review.rejected", 1)
+	s.audit(ctx, auditActionRejected, withdrawal, adminID, info, map[string]interface{}{
+		"status":               withdrawal.Status,
+		"reason":               req.Reason,
+		"reservation_released": withdrawal.ReservationReleased,
+	})
+	return withdrawal, nil
+}
+
+func (s *reviewService) AddNote(ctx context.Context, adminID, withdrawalID string, req domain.ReviewNoteRequest, info domain.RequestInfo) (*domain.Withdrawal, error) {
+	withdrawal, review, err := s.loadForReview(ctx, withdrawalID)
+	if err != nil {
+		return nil, err
+	}
+
+	review.Notes = append(review.Notes, domain.ReviewNote{AdminID: adminID, Note: req.Note, CreatedAt: time.Now()})
+	if err := s.updateReview(ctx, withdrawal, review, time.Time{}); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, auditActionNote, withdrawal, adminID, info, map[string]interface{}{
+		"note": req.Note,
+	})
+	return withdrawal, nil
+}
+
+func (s *reviewService) FlagVerificationMismatch(ctx context.Context, withdrawal *domain.Withdrawal, verification domain.WithdrawalVerification) error {
+	verificationJSON, err := json.Marshal(verification)
+	if err != nil {
+		return fmt.Errorf("failed to marshal verification: %w", err)
+	}
+	deadline := time.Now().Add(s.reviewWindow())
+	flagged, err := s.withdrawalRepo.FlagVerificationMismatch(ctx, withdrawal.WithdrawalID, deadline, verificationJSON)
+	if err != nil {
+		return err
+	}
+	if !flagged {
+		return fmt.Errorf("withdrawal %s is no longer pending or processing", withdrawal.WithdrawalID)
+	}
+
+	previousStatus := withdrawal.Status
+	withdrawal.Status = domain.WithdrawalStatusAwaitingAdminReview
+	withdrawal.RequiresAdminReview = true
+	withdrawal.AdminReviewDeadline = deadline
+	withdrawal.UpdatedAt = time.Now()
+
+	metrics.Add("withdrawals.review.verification_mismatches", 1)
+	s.logger.Warn().
+		Str("withdrawal_id", withdrawal.WithdrawalID).
+		Str("tx_hash", verification.TxHash).
+		Strs("mismatches", verification.Mismatches).
+		Time("deadline", deadline).
+		Msg("Withdrawal transaction does not match, held for admin review")
+	s.auditChange(ctx, auditActionMismatch, withdrawal, "", domain.RequestInfo{}, map[string]interface{}{
+		"status": previousStatus,
+	}, map[string]interface{}{
+		"status":                withdrawal.Status,
+		"tx_hash":               verification.TxHash,
+		"mismatches":            verification.Mismatches,
+		"admin_review_deadline": deadline,
+	})
+	s.wsHub.BroadcastWithdrawal(*withdrawal)
+	return nil
+}
+
+// processOverdueReviews escalates withdrawals whose review deadline passed,
+// granting another review window, and rejects them once the escalations are
+// used up or the deadline action is reject. A MaxEscalations of zero never
+// runs out, so withdrawals are only rejected by an admin or a reject action.
+func (s *reviewService) processOverdueReviews(ctx context.Context) error {
+	overdue, err := s.withdrawalRepo.ListOverdueReviews(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for i := range overdue {
+		withdrawal := &overdue[i]
+		review := reviewOf(*withdrawal)
+
+		if s.config.ReviewDeadlineAction == deadlineActionReject || (s.config.MaxEscalations > 0 && review.Escalations >= s.config.MaxEscalations) {
+			reason := fmt.Sprintf("admin review deadline %s missed", withdrawal.AdminReviewDeadline.Format(time.RFC3339))
+			if err := s.reject(ctx, withdrawal, review, "", reason); err != nil {
+				s.logger.Error().Err(err).Str("withdrawal_id", withdrawal.WithdrawalID).Msg("Failed to auto-reject overdue withdrawal")
+				continue
+			}
+			metrics.Add("withdrawals.review.auto_rejected", 1)
+			s.audit(ctx, auditActionAutoRejected, withdrawal, "", domain.RequestInfo{}, map[string]interface{}{
+				"status":      withdrawal.Status,
+				"reason":      reason,
+				"escalations": review.Escalations,
+			})
+			continue
+		}
+
+		review.Escalations++
+		deadline := time.Now().Add(s.reviewWindow())
+		if err := s.updateReview(ctx, withdrawal, review, deadline); err != nil {
+			s.logger.Error().Err(err).Str("withdrawal_id", withdrawal.WithdrawalID).Msg("Failed to escalate overdue withdrawal")
+			continue
+		}
+		withdrawal.AdminReviewDeadline = deadline
+
+		metrics.Add("withdrawals.review.escalated", 1)
+		s.logger.Error().
+			Str("withdrawal_id", withdrawal.WithdrawalID).
+			Str("user_id", withdrawal.UserID).
+			Int("escalation", review.Escalations).
+			Time("new_deadline", deadline).
+			Msg("ESCALATION: withdrawal review deadline missed")
+		s.audit(ctx, auditActionEscalated, withdrawal, "", domain.RequestInfo{}, map[string]interface{}{
+			"escalations":           review.Escalations,
+			"admin_review_deadline": deadline,
+		})
+	}
+	return nil
+}
+
+// reject cancels the withdrawal and returns its reservation to the user in
+// one transaction. A withdrawal held for a verification mismatch has already
+// sent funds on chain, so its reservation stays held until the transfer is
+// reconciled by hand rather than refunding what the user may have received.
+func (s *reviewService) reject(ctx context.Context, withdrawal *domain.Withdrawal, review *domain.WithdrawalReview, adminID, reason string) error {
+	now := time.Now()
+	review.Decision = domain.ReviewDecisionRejected
+	review.DecidedBy = adminID
+	review.DecidedAt = &now
+	review.Reason = reason
+	reviewJSON, err := json.Marshal(review)
+	if err != nil {
+		return fmt.Errorf("failed to marshal review: %w", err)
+	}
+
+	fiatCurrency := fiatCurrencyOf(*withdrawal)
+	sentTxHash := mismatchTxHash(*withdrawal)
+	var releaseCents int64
+	if !withdrawal.ReservationReleased && sentTxHash == "" {
+		releaseCents = withdrawal.AmountReservedCents
+	}
+	rejected, err := s.withdrawalRepo.RejectReview(ctx, withdrawal.WithdrawalID, withdrawal.UserID, adminID, "Rejected in admin review: "+reason, reviewJSON, fiatCurrency, releaseCents)
+	if err != nil {
+		return err
+	}
+	if !rejected {
+		return ErrNotAwaitingReview
+	}
+
+	withdrawal.Status = domain.WithdrawalStatusCancelled
+	withdrawal.AdminID = adminID
+	withdrawal.RequiresAdminReview = false
+	withdrawal.UpdatedAt = now
+	if sentTxHash != "" && !withdrawal.ReservationReleased {
+		metrics.Add("withdrawals.review.reconciliation_required", 1)
+		s.logger.Error().
+			Str("withdrawal_id", withdrawal.WithdrawalID).
+			Str("tx_hash", sentTxHash).
+			Int64("reserved_cents", withdrawal.AmountReservedCents).
+			Msg("RECONCILIATION: rejected withdrawal already moved funds, reservation kept")
+	}
+	if releaseCents > 0 {
+		withdrawal.ReservationReleased = true
+		withdrawal.ReservationReleasedAt = now
+	}
+
+	if releaseCents > 0 {
+		if err := s.balanceRepo.LogBalanceChange(ctx, &domain.BalanceLog{
+			ID:           uuid.New().String(),
+			UserID:       withdrawal.UserID,
+			Component:    "withdrawal",
+			CurrencyCode: fiatCurrency,
+			ChangeCents:  releaseCents,
+			ChangeUnits:  s.currencyUtils.MinorUnitsToMajor(releaseCents, fiatCurrency),
+			Description:  fmt.Sprintf("Released reserved balance for rejected withdrawal %s: %s", withdrawal.WithdrawalID, reason),
+			Timestamp:    now,
+		}); err != nil {
+			s.logger.Err(err).Msg("Failed to log balance release")
+		}
+	}
+	s.wsHub.BroadcastWithdrawal(*withdrawal)
+	return nil
+}
+
+func (s *reviewService) resolve(ctx context.Context, withdrawal *domain.Withdrawal, review *domain.WithdrawalReview, status domain.WithdrawalStatus, adminID, errorMessage string) error {
+	reviewJSON, err := json.Marshal(review)
+	if err != nil {
+		return fmt.Errorf("failed to marshal review: %w", err)
+	}
+	resolved, err := s.withdrawalRepo.ResolveReview(ctx, withdrawal.WithdrawalID, status, adminID, errorMessage, reviewJSON)
+	if err != nil {
+		return err
+	}
+	if !resolved {
+		return ErrNotAwaitingReview
+	}
+
+	withdrawal.Status = status
+	withdrawal.AdminID = adminID
+	withdrawal.RequiresAdminReview = false
+	withdrawal.UpdatedAt = time.Now()
+	return nil
+}
+
+func (s *reviewService) updateReview(ctx context.Context, withdrawal *domain.Withdrawal, review *domain.WithdrawalReview, deadline time.Time) error {
+	reviewJSON, err := json.Marshal(review)
+	if err != nil {
+		return fmt.Errorf("failed to marshal review: %w", err)
+	}
+	updated, err := s.withdrawalRepo.UpdateReview(ctx, withdrawal.WithdrawalID, reviewJSON, deadline)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return ErrNotAwaitingReview
+	}
+	return nil
+}
+
+// audit writes the decision to audit_logs. A failed write is logged rather
+// than returned because the decision itself has already been applied.
+func (s *reviewService) audit(ctx context.Context, action string, withdrawal *domain.Withdrawal, adminID string, info domain.RequestInfo, newValues map[string]interface{}) {
+	s.auditChange(ctx, action, withdrawal, adminID, info, map[string]interface{}{
+		"status":                domain.WithdrawalStatusAwaitingAdminReview,
+		"admin_review_deadline": withdrawal.AdminReviewDeadline,
+	}, newValues)
+}
+
+func (s *reviewService) auditChange(ctx context.Context, action string, withdrawal *domain.Withdrawal, adminID string, info domain.RequestInfo, oldValues, newValues map[string]interface{}) {
+	s.writeAudit(ctx, action, auditEntityWithdrawal, withdrawal.WithdrawalID, adminID, info, oldValues, newValues)
+}
+
+func (s *reviewService) writeAudit(ctx context.Context, action, entityType, entityID, adminID string, info domain.RequestInfo, oldValues, newValues map[string]interface{}) {
+	oldJSON, _ := json.Marshal(oldValues)
+	newJSON, _ := json.Marshal(newValues)
+
+	if err := s.auditRepo.CreateAuditLog(ctx, domain.AuditLog{
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		AdminID:    adminID,
+		OldValues:  oldJSON,
+		NewValues:  newJSON,
+		IPAddress:  info.IPAddress,
+		UserAgent:  info.UserAgent,
+	}); err != nil {
+		metrics.Add("audit.write_failures", 1)
+		s.logger.Error().
+			Err(err).
+			Str("action", action).
+			Str("entity_type", entityType).
+			Str("entity_id", entityID).
+			Msg("Failed to write audit log")
+	}
+}
+
+func (s *reviewService) loadForReview(ctx context.Context, withdrawalID string) (*domain.Withdrawal, *domain.WithdrawalReview, error) {
+	withdrawal, err := s.withdrawalRepo.GetByWithdrawalID(ctx, withdrawalID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil, ErrWithdrawalNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if withdrawal.Status != domain.WithdrawalStatusAwaitingAdminReview {
+		return nil, nil, ErrNotAwaitingReview
+	}
+	return withdrawal, reviewOf(*withdrawal), nil
+}
+
+func (s *reviewService) reviewWindow() time.Duration {
+	if s.config.AdminReviewWindow > 0 {
+		return s.config.AdminReviewWindow
+	}
+	return defaultReviewWindow
+}
+
+func reviewOf(withdrawal domain.Withdrawal) *domain.WithdrawalReview {
+	var metadata domain.WithdrawalMetadata
+	if len(withdrawal.Metadata) > 0 {
+		_ = json.Unmarshal(withdrawal.Metadata, &metadata)
+	}
+	if metadata.Review == nil {
+		return &domain.WithdrawalReview{}
+	}
+	return metadata.Review
+}
+
+// mismatchTxHash is the transaction a withdrawal was held for when it is in
+// review for a verification mismatch, and empty otherwise.
+func mismatchTxHash(withdrawal domain.Withdrawal) string {
+	var metadata domain.WithdrawalMetadata
+	if len(withdrawal.Metadata) > 0 {
+		_ = json.Unmarshal(withdrawal.Metadata, &metadata)
+	}
+	if metadata.Verification == nil {
+		return ""
+	}
+	return metadata.Verification.TxHash
+}
+
+func fiatCurrencyOf(withdrawal domain.Withdrawal) string {
+	var metadata domain.WithdrawalMetadata
+	if len(withdrawal.Metadata) > 0 {
+		_ = json.Unmarshal(withdrawal.Metadata, &metadata)
+	}
+	if metadata.FiatCurrency == "" {
+		return "USD"
+	}
+	return metadata.FiatCurrency
+}
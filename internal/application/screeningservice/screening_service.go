@@ -0,0 +1,16 @@
+package screeningservice
+
+import (
+	"context"
+
+	"github.com/tuncanbit/tvs/internal/domain"
+)
+
+type IScreeningService interface {
+	// Start loads the configured lists and keeps reloading them until ctx
+	// is done, so list files can be replaced without a restart.
+	Start(ctx context.Context)
+	// Screen returns the match for a counterparty address, or nil if it is
+	// on none of the lists or screening is disabled.
+	Screen(address string) *domain.ScreeningMatch
+}
@@ -0,0 +1,283 @@
+package screeningservice
+
+import (
+	"bufio"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/internal/domain"
+	"github.com/tuncanbit/tvs/pkg/config"
+	"github.com/tuncanbit/tvs/pkg/metrics"
+)
+
+const defaultReloadInterval = 10 * time.Minute
+
+type listEntry struct {
+	list   string
+	reason string
+}
+
+// loadedList is the content of a list file as of its modification time.
+type loadedList struct {
+	modTime time.Time
+	entries map[string]string // normalized address -> reason
+}
+
+type screeningService struct {
+	config config.ScreeningConfig
+	logger zerolog.Logger
+
+	mu      sync.RWMutex
+	lists   map[string]loadedList
+	entries map[string]listEntry
+}
+
+func New(cfg config.ScreeningConfig, logger zerolog.Logger) IScreeningService {
+	return &screeningService{
+		config:  cfg,
+		logger:  logger.With().Str("component", "screening_service").Logger(),
+		lists:   make(map[string]loadedList),
+		entries: make(map[string]listEntry),
+	}
+}
+
+func (s *screeningService) Start(ctx context.Context) {
+	if !s.config.Enabled {
+		s.logger.Warn().Msg("Counterparty screening is disabled")
+		return
+	}
+	s.reload()
+
+	interval := s.config.ReloadInterval
+	if interval <= 0 {
+		interval = defaultReloadInterval
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.reload()
+			}
+		}
+	}()
+}
+
+func (s *screeningService) Screen(address string) *domain.ScreeningMatch {
+	if !s.config.Enabled || address == "" {
+		return nil
+	}
+	s.mu.RLock()
+	entry, listed := s.entries[normalize(address)]
+	s.mu.RUnlock()
+	if !listed {
+		return nil
+	}
+
+	metrics.Add("screening.hits", 1)
+	return &domain.ScreeningMatch{
+		Address:   address,
+		List:      entry.list,
+		Reason:    entry.reason,
+		MatchedAt: time.Now(),
+	}
+}
+
+// reload re-reads the list files that changed since they were last loaded.
+// A list that fails to load keeps its previous entries, so a file being
+// rewritten or briefly missing does not drop addresses from screening.
+func (s *screeningService) reload() {
+	s.mu.RLock()
+	lists := make(map[string]loadedList, len(s.lists))
+	for name, list := range s.lists {
+		lists[name] = list
+	}
+	s.mu.RUnlock()
+
+	changed := false
+	for _, listCfg := range s.config.Lists {
+		info, err := os.Stat(listCfg.Path)
+		if err != nil {
+			metrics.Add("screening.load_failures", 1)
+			s.logger.Error().Err(err).Str("list", listCfg.Name).Msg("Failed to read screening list, keeping previous entries")
+			continue
+		}
+		if previous, ok := lists[listCfg.Name]; ok && previous.modTime.Equal(info.ModTime()) {
+			continue
+		}
+		entries, err := loadList(listCfg)
+		if err != nil {
+			metrics.Add("screening.load_failures", 1)
+			s.logger.Error().Err(err).Str("list", listCfg.Name).Msg("Failed to load screening list, keeping previous entries")
+			continue
+		}
+		lists[listCfg.Name] = loadedList{modTime: info.ModTime(), entries: entries}
+		changed = true
+		s.logger.Info().
+			Str("list", listCfg.Name).
+			Str("path", listCfg.Path).
+			Int("addresses", len(entries)).
+			Msg("Screening list loaded")
+	}
+	if !changed {
+		return
+	}
+
+	// Lists are merged in configured order, so an address on several lists
+	// is attributed to the first.
+	merged := make(map[string]listEntry)
+	for i := len(s.config.Lists) - 1; i >= 0; i-- {
+		name := s.config.Lists[i].Name
+		for address, reason := range lists[name].entries {
+			merged[address] = listEntry{list: name, reason: reason}
+		}
+	}
+
+	s.mu.Lock()
+	s.lists, s.entries = lists, merged
+	s.mu.Unlock()
+}
+
+const (
+	formatText       = "text"
+	formatOFACSDNXML = "ofac_sdn_xml"
+)
+
+// sdnDigitalCurrencyID prefixes the idType of the SDN identifiers that hold
+// a blockchain address, e.g. "Digital Currency Address - ETH".
+const sdnDigitalCurrencyID = "Digital Currency Address"
+
+func loadList(listCfg config.ScreeningListConfig) (map[string]string, error) {
+	file, err := os.Open(listCfg.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	switch listCfg.Format {
+	case "", formatText:
+		return readTextList(file, listCfg)
+	case formatOFACSDNXML:
+		return readSDNList(file, listCfg)
+	default:
+		return nil, fmt.Errorf("%s: unknown list format %q", listCfg.Path, listCfg.Format)
+	}
+}
+
+func readTextList(r io.Reader, listCfg config.ScreeningListConfig) (map[string]string, error) {
+	entries := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		address, reason, _ := strings.Cut(line, ",")
+		address, reason = strings.TrimSpace(address), strings.TrimSpace(reason)
+		if strings.ContainsAny(address, " \t") {
+			return nil, fmt.Errorf("%s:%d: malformed address %q", listCfg.Path, lineNo, address)
+		}
+		if reason == "" {
+			reason = listCfg.Reason
+		}
+		entries[normalize(address)] = reason
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", listCfg.Path, err)
+	}
+	return entries, nil
+}
+
+type sdnEntry struct {
+	UID       string   `xml:"uid"`
+	FirstName string   `xml:"firstName"`
+	LastName  string   `xml:"lastName"`
+	Programs  []string `xml:"programList>program"`
+	IDs       []struct {
+		Type   string `xml:"idType"`
+		Number string `xml:"idNumber"`
+	} `xml:"idList>id"`
+}
+
+// readSDNList reads the digital currency addresses out of the OFAC SDN XML
+// export. Each address is listed with the name and sanctions programs of
+// its entry. A file without any SDN entries is rejected, so a truncated
+// download or an error page does not empty the list.
+func readSDNList(r io.Reader, listCfg config.ScreeningListConfig) (map[string]string, error) {
+	entries := make(map[string]string)
+	sdnEntries := 0
+	decoder := xml.NewDecoder(r)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", listCfg.Path, err)
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "sdnEntry" {
+			continue
+		}
+		var entry sdnEntry
+		if err := decoder.DecodeElement(&entry, &start); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", listCfg.Path, err)
+		}
+		sdnEntries++
+
+		for _, id := range entry.IDs {
+			if !strings.HasPrefix(id.Type, sdnDigitalCurrencyID) {
+				continue
+			}
+			address := strings.TrimSpace(id.Number)
+			if address == "" || strings.ContainsAny(address, " \t") {
+				return nil, fmt.Errorf("%s: SDN entry %s: malformed address %q", listCfg.Path, entry.UID, address)
+			}
+			key := normalize(address)
+			if _, listed := entries[key]; !listed {
+				entries[key] = sdnReason(entry, listCfg.Reason)
+			}
+		}
+	}
+	if sdnEntries == 0 {
+		return nil, fmt.Errorf("%s: no SDN entries found", listCfg.Path)
+	}
+	return entries, nil
+}
+
+// sdnReason describes an SDN entry as "<list reason>: <name> [<programs>]".
+func sdnReason(entry sdnEntry, listReason string) string {
+	reason := strings.TrimSpace(entry.FirstName + " " + entry.LastName)
+	if len(entry.Programs) > 0 {
+		reason = strings.TrimSpace(reason + " [" + strings.Join(entry.Programs, ", ") + "]")
+	}
+	switch {
+	case reason == "":
+		return listReason
+	case listReason == "":
+		return reason
+	default:
+		return listReason + ": " + reason
+	}
+}
+
+// normalize maps the spellings of an address to one key. Hex EVM addresses
+// and bech32 Bitcoin addresses are case-insensitive; base58 addresses are
+// not and are kept as they are.
+func normalize(address string) string {
+	lower := strings.ToLower(address)
+	if strings.HasPrefix(lower, "0x") || strings.HasPrefix(lower, "bc1") || strings.HasPrefix(lower, "tb1") {
+		return lower
+	}
+	return address
+}
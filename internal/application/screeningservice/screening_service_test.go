@@ -0,0 +1,216 @@
+package screeningservice
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/pkg/config"
+)
+
+const sdnExport = `<?xml version="1.0" standalone="yes"?>
+<sdnList xmlns="https://sanctionslistservice.ofac.treas.gov/api/PublicationPreview/exports/XML">
+  <publshInformation><Publish_Date>10/17/2026</Publish_Date></publshInformation>
+  <sdnEntry>
+    <uid>100</uid>
+    <lastName>LAZARUS GROUP</lastName>
+    <sdnType>Entity</sdnType>
+    <programList><program>DPRK3</program></programList>
+    <idList>
+      <id><uid>1</uid><idType>Digital Currency Address - ETH</idType><idNumber>0xAbC0000000000000000000000000000000000001</idNumber></id>
+      <id><uid>2</uid><idType>Registration Number</idType><idNumber>12345</idNumber></id>
+      <id><uid>3</uid><idType>Digital Currency Address - XBT</idType><idNumber>1SdnBase58Address</idNumber></id>
+    </idList>
+  </sdnEntry>
+  <sdnEntry>
+    <uid>200</uid>
+    <firstName>Ivan</firstName>
+    <lastName>PETROV</lastName>
+    <sdnType>Individual</sdnType>
+  </sdnEntry>
+</sdnList>
+`
+
+type wantMatch struct {
+	address string
+	list    string // empty if the address must not match
+	reason  string
+}
+
+func TestScreen(t *testing.T) {
+	tests := []struct {
+		name    string
+		lists   []config.ScreeningListConfig // Path is the file name in a temp dir
+		files   map[string]string
+		rewrite map[string]string // written after the first load, then reloaded
+		want    []wantMatch
+	}{
+		{
+			name:  "comments, blank lines and reason fallback",
+			lists: []config.ScreeningListConfig{{Name: "internal", Path: "internal.txt", Reason: "Internal blocklist"}},
+			files: map[string]string{"internal.txt": "# header\n\n  0x1111111111111111111111111111111111111111 , mixer\n1KeepAsIs\n   \n# 1Commented\n"},
+			want: []wantMatch{
+				{address: "0x1111111111111111111111111111111111111111", list: "internal", reason: "mixer"},
+				{address: "1KeepAsIs", list: "internal", reason: "Internal blocklist"},
+				{address: "1Commented"},
+				{address: "# header"},
+			},
+		},
+		{
+			name: "malformed line rejects the whole list",
+			lists: []config.ScreeningListConfig{
+				{Name: "broken", Path: "broken.txt"},
+				{Name: "internal", Path: "internal.txt"},
+			},
+			files: map[string]string{
+				"broken.txt":   "0x2222222222222222222222222222222222222222\n0x33 33,split address\n",
+				"internal.txt": "1Good\n",
+			},
+			want: []wantMatch{
+				{address: "0x2222222222222222222222222222222222222222"},
+				{address: "1Good", list: "internal"},
+			},
+		},
+		{
+			name:  "case-insensitive EVM and bech32, case-sensitive base58",
+			lists: []config.ScreeningListConfig{{Name: "internal", Path: "internal.txt"}},
+			files: map[string]string{"internal.txt": "0xABCDEF0000000000000000000000000000000001\nBC1QXY2KGDYGJRSQTZQ2N0YRF2493P83KKFJHX0WLH\ntb1qtestaddress\n1BoUrl7z4GkdYfMAtfbGfz5pYPnYVo3fNd\n"},
+			want: []wantMatch{
+				{address: "0xabcdef0000000000000000000000000000000001", list: "internal"},
+				{address: "0XABCDEF0000000000000000000000000000000001", list: "internal"},
+				{address: "bc1qxy2kgdygjrsqtzq2n0yrf2493p83kkfjhx0wlh", list: "internal"},
+				{address: "TB1QTESTADDRESS", list: "internal"},
+				{address: "1BoUrl7z4GkdYfMAtfbGfz5pYPnYVo3fNd", list: "internal"},
+				{address: "1bourl7z4gkdyfmatfbgfz5pypnyvo3fnd"},
+			},
+		},
+		{
+			name: "address on two lists is attributed to the first",
+			lists: []config.ScreeningListConfig{
+				{Name: "ofac_sdn", Path: "ofac.txt", Reason: "OFAC SDN list"},
+				{Name: "internal", Path: "internal.txt", Reason: "Internal blocklist"},
+			},
+			files: map[string]string{
+				"ofac.txt":     "0x4444444444444444444444444444444444444444\n",
+				"internal.txt": "0X4444444444444444444444444444444444444444,fraud\n1OnlyInternal\n",
+			},
+			want: []wantMatch{
+				{address: "0x4444444444444444444444444444444444444444", list: "ofac_sdn", reason: "OFAC SDN list"},
+				{address: "1OnlyInternal", list: "internal", reason: "Internal blocklist"},
+			},
+		},
+		{
+			name:    "failed reload keeps prior entries",
+			lists:   []config.ScreeningListConfig{{Name: "internal", Path: "internal.txt", Reason: "Internal blocklist"}},
+			files:   map[string]string{"internal.txt": "1Before\n"},
+			rewrite: map[string]string{"internal.txt": "1After\nnot an address\n"},
+			want: []wantMatch{
+				{address: "1Before", list: "internal", reason: "Internal blocklist"},
+				{address: "1After"},
+			},
+		},
+		{
+			name:    "successful reload replaces entries",
+			lists:   []config.ScreeningListConfig{{Name: "internal", Path: "internal.txt"}},
+			files:   map[string]string{"internal.txt": "1Before\n"},
+			rewrite: map[string]string{"internal.txt": "1After\n"},
+			want: []wantMatch{
+				{address: "1Before"},
+				{address: "1After", list: "internal"},
+			},
+		},
+		{
+			name:  "OFAC SDN export",
+			lists: []config.ScreeningListConfig{{Name: "ofac_sdn", Path: "sdn.xml", Format: formatOFACSDNXML, Reason: "OFAC SDN list"}},
+			files: map[string]string{"sdn.xml": sdnExport},
+			want: []wantMatch{
+				{address: "0xabc0000000000000000000000000000000000001", list: "ofac_sdn", reason: "OFAC SDN list: LAZARUS GROUP [DPRK3]"},
+				{address: "1SdnBase58Address", list: "ofac_sdn", reason: "OFAC SDN list: LAZARUS GROUP [DPRK3]"},
+				{address: "12345"},
+			},
+		},
+		{
+			name:    "SDN export without entries keeps prior entries",
+			lists:   []config.ScreeningListConfig{{Name: "ofac_sdn", Path: "sdn.xml", Format: formatOFACSDNXML}},
+			files:   map[string]string{"sdn.xml": sdnExport},
+			rewrite: map[string]string{"sdn.xml": "<html><body>Service Unavailable</body></html>"},
+			want: []wantMatch{
+				{address: "1SdnBase58Address", list: "ofac_sdn", reason: "LAZARUS GROUP [DPRK3]"},
+			},
+		},
+		{
+			name:  "unknown format",
+			lists: []config.ScreeningListConfig{{Name: "internal", Path: "internal.csv", Format: "csv"}},
+			files: map[string]string{"internal.csv": "1Listed\n"},
+			want:  []wantMatch{{address: "1Listed"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			cfg := config.ScreeningConfig{Enabled: true}
+			for _, list := range tt.lists {
+				list.Path = filepath.Join(dir, list.Path)
+				cfg.Lists = append(cfg.Lists, list)
+			}
+			writeFiles(t, dir, tt.files, time.Now().Add(-time.Hour))
+
+			s := New(cfg, zerolog.Nop()).(*screeningService)
+			s.reload()
+			if tt.rewrite != nil {
+				writeFiles(t, dir, tt.rewrite, time.Now())
+				s.reload()
+			}
+
+			for _, want := range tt.want {
+				match := s.Screen(want.address)
+				if want.list == "" {
+					if match != nil {
+						t.Errorf("Screen(%q) = %+v, want no match", want.address, match)
+					}
+					continue
+				}
+				if match == nil {
+					t.Errorf("Screen(%q) = nil, want match on %s", want.address, want.list)
+					continue
+				}
+				if match.List != want.list || (want.reason != "" && match.Reason != want.reason) {
+					t.Errorf("Screen(%q) = %s/%q, want %s/%q", want.address, match.List, match.Reason, want.list, want.reason)
+				}
+				if match.Address != want.address {
+					t.Errorf("Screen(%q).Address = %q", want.address, match.Address)
+				}
+			}
+		})
+	}
+}
+
+func TestScreenDisabled(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"internal.txt": "1Listed\n"}, time.Now())
+	s := New(config.ScreeningConfig{
+		Lists: []config.ScreeningListConfig{{Name: "internal", Path: filepath.Join(dir, "internal.txt")}},
+	}, zerolog.Nop()).(*screeningService)
+	s.reload()
+	if match := s.Screen("1Listed"); match != nil {
+		t.Errorf("Screen() = %+v with screening disabled, want nil", match)
+	}
+}
+
+// writeFiles writes each file into dir and sets its modification time, so
+// a rewrite is seen as a change regardless of the filesystem's timestamp
+// resolution.
+func writeFiles(t *testing.T, dir string, files map[string]string, modTime time.Time) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
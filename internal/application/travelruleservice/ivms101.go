@@ -0,0 +1,95 @@
+package travelruleservice
+
+import (
+	"strings"
+
+	"github.com/tuncanbit/tvs/internal/domain"
+)
+
+// ivms101 maps a Travel Rule record to an IVMS101 identity payload. The
+// originator is identified by name, home address and customer ID; the
+// beneficiary by name and the destination address.
+func (s *travelRuleService) ivms101(data *domain.TravelRuleData) domain.IVMS101Payload {
+	originator := data.Originator
+	payload := domain.IVMS101Payload{
+		Originator: domain.IVMS101Originator{
+			OriginatorPersons: []domain.IVMS101Person{{NaturalPerson: &domain.IVMS101NaturalPerson{
+				Name:                   naturalPersonName(originator.LastName, originator.FirstName),
+				GeographicAddress:      homeAddress(originator),
+				CustomerIdentification: originator.UserID,
+				CountryOfResidence:     data.Jurisdiction,
+			}}},
+			AccountNumber: []string{originator.UserID},
+		},
+		Beneficiary: domain.IVMS101Beneficiary{
+			BeneficiaryPersons: []domain.IVMS101Person{{NaturalPerson: &domain.IVMS101NaturalPerson{
+				Name: naturalPersonName(data.Beneficiary.BeneficiaryName, ""),
+			}}},
+			AccountNumber: []string{data.ToAddress},
+		},
+	}
+	if s.config.VASPName != "" {
+		payload.OriginatingVASP = &domain.IVMS101OriginatingVASP{
+			OriginatingVASP: legalPerson(s.config.VASPName, s.config.VASPLEI, s.config.VASPCountry),
+		}
+	}
+	if data.Beneficiary.WalletType == domain.TravelRuleWalletHosted {
+		payload.BeneficiaryVASP = &domain.IVMS101BeneficiaryVASP{
+			BeneficiaryVASP: legalPerson(data.Beneficiary.VASPName, data.Beneficiary.VASPLEI, ""),
+		}
+	}
+	return payload
+}
+
+// naturalPersonName uses the given name as the primary identifier when there
+// is no family name, since IVMS101 requires one.
+func naturalPersonName(familyName, givenName string) domain.IVMS101NaturalPersonName {
+	familyName, givenName = strings.TrimSpace(familyName), strings.TrimSpace(givenName)
+	if familyName == "" {
+		familyName, givenName = givenName, ""
+	}
+	return domain.IVMS101NaturalPersonName{
+		NameIdentifier: []domain.IVMS101NaturalPersonNameID{{
+			PrimaryIdentifier:   familyName,
+			SecondaryIdentifier: givenName,
+			NameIdentifierType:  domain.IVMS101NameTypeLegal,
+		}},
+	}
+}
+
+func homeAddress(profile domain.UserProfile) []domain.IVMS101Address {
+	country := strings.ToUpper(strings.TrimSpace(profile.Country))
+	if country == "" {
+		return nil
+	}
+	address := domain.IVMS101Address{
+		AddressType:        domain.IVMS101AddressTypeHome,
+		PostCode:           profile.PostalCode,
+		TownName:           profile.City,
+		CountrySubDivision: profile.State,
+		Country:            country,
+	}
+	if profile.StreetAddress != "" {
+		address.AddressLine = []string{profile.StreetAddress}
+	}
+	return []domain.IVMS101Address{address}
+}
+
+func legalPerson(name, lei, country string) domain.IVMS101Person {
+	person := &domain.IVMS101LegalPerson{
+		Name: domain.IVMS101LegalPersonName{
+			NameIdentifier: []domain.IVMS101LegalPersonNameID{{
+				LegalPersonName:               name,
+				LegalPersonNameIdentifierType: domain.IVMS101NameTypeLegal,
+			}},
+		},
+		CountryOfRegistration: country,
+	}
+	if lei != "" {
+		person.NationalIdentification = &domain.IVMS101NationalIdentification{
+			NationalIdentifier:     lei,
+			NationalIdentifierType: domain.IVMS101IdentifierTypeLEI,
+		}
+	}
+	return domain.IVMS101Person{LegalPerson: person}
+}
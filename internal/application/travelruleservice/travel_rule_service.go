@@ -0,0 +1,27 @@
+package travelruleservice
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tuncanbit/tvs/internal/domain"
+)
+
+var (
+	ErrTravelRuleRequired   = errors.New("travel rule information is required for this withdrawal")
+	ErrInvalidTravelRule    = errors.New("invalid travel rule information")
+	ErrOriginatorIncomplete = errors.New("account profile is missing the name the travel rule requires")
+	ErrWithdrawalNotFound   = errors.New("withdrawal not found")
+	ErrNoTravelRule         = errors.New("withdrawal has no travel rule information")
+)
+
+type ITravelRuleService interface {
+	// Capture checks a withdrawal worth usdCents against the threshold of
+	// the user's country and seals the beneficiary information in req
+	// together with the user's profile as originator. It returns nil when
+	// no information was given and none is required.
+	Capture(ctx context.Context, userID, withdrawalID, toAddress string, usdCents int64, req *domain.TravelRuleRequest) (*domain.WithdrawalTravelRule, error)
+	// Export decrypts a withdrawal's Travel Rule record into IVMS101 form.
+	// Every export is audited.
+	Export(ctx context.Context, adminID, withdrawalID string, info domain.RequestInfo) (*domain.TravelRuleExport, error)
+}
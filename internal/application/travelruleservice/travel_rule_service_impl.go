@@ -0,0 +1,248 @@
+package travelruleservice
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/internal/domain"
+	"github.com/tuncanbit/tvs/internal/repositories/auditrepo"
+	"github.com/tuncanbit/tvs/internal/repositories/configrepo"
+	"github.com/tuncanbit/tvs/internal/repositories/userrepo"
+	"github.com/tuncanbit/tvs/internal/repositories/withdrawalrepo"
+	"github.com/tuncanbit/tvs/pkg/config"
+	"github.com/tuncanbit/tvs/pkg/currency"
+	"github.com/tuncanbit/tvs/pkg/metrics"
+	"github.com/tuncanbit/tvs/pkg/sealer"
+)
+
+const (
+	travelRuleThresholdKey = "travel_rule_threshold"
+
+	auditEntityWithdrawal = "withdrawal"
+	auditActionExported   = "withdrawal_travel_rule_exported"
+)
+
+// defaultThreshold applies when the threshold config cannot be read, so a
+// broken row does not let withdrawals through without the information. USD
+// 1,000 is the FATF de minimis threshold.
+var defaultThreshold = domain.TravelRuleThreshold{DefaultUSDCents: 100_000}
+
+type travelRuleService struct {
+	configRepo     configrepo.IConfigRepository
+	userRepo       userrepo.IUserRepository
+	withdrawalRepo withdrawalrepo.IWithdrawalRepository
+	auditRepo      auditrepo.IAuditRepository
+	sealer         *sealer.Sealer
+	config         config.TravelRuleConfig
+	currencyUtils  *currency.CurrencyUtils
+	logger         zerolog.Logger
+}
+
+func New(
+	configRepo configrepo.IConfigRepository,
+	userRepo userrepo.IUserRepository,
+	withdrawalRepo withdrawalrepo.IWithdrawalRepository,
+	auditRepo auditrepo.IAuditRepository,
+	sealer *sealer.Sealer,
+	cfg config.TravelRuleConfig,
+	logger zerolog.Logger,
+) ITravelRuleService {
+	return &travelRuleService{
+		configRepo:     configRepo,
+		userRepo:       userRepo,
+		withdrawalRepo: withdrawalRepo,
+		auditRepo:      auditRepo,
+		sealer:         sealer,
+		config:         cfg,
+		currencyUtils:  currency.NewCurrencyUtils(),
+		logger:         logger.With().Str("component", "travel_rule_service").Logger(),
+	}
+}
+
+func (s *travelRuleService) Capture(ctx context.Context, userID, withdrawalID, toAddress string, usdCents int64, req *domain.TravelRuleRequest) (*domain.WithdrawalTravelRule, error) {
+	profile, err := s.userRepo.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	jurisdiction := strings.ToUpper(strings.TrimSpace(profile.Country))
+	threshold := s.threshold(ctx).For(jurisdiction)
+	required := usdCents >= threshold
+
+	if req == nil {
+		if required {
+			return nil, fmt.Errorf("%w: withdrawals of %s or more need beneficiary details", ErrTravelRuleRequired, s.currencyUtils.Format(threshold, "USD"))
+		}
+		return nil, nil
+	}
+	beneficiary, err := validate(*req)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(profile.FirstName) == "" && strings.TrimSpace(profile.LastName) == "" {
+		return nil, ErrOriginatorIncomplete
+	}
+
+	now := time.Now()
+	data := domain.TravelRuleData{
+		Originator:        *profile,
+		Beneficiary:       beneficiary,
+		ToAddress:         toAddress,
+		USDAmountCents:    usdCents,
+		CapturedAt:        now,
+		Jurisdiction:      jurisdiction,
+		ThresholdUSDCents: threshold,
+	}
+	if beneficiary.OwnershipAttested {
+		data.AttestedAt = &now
+	}
+	plaintext, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal travel rule data: %w", err)
+	}
+	nonce, ciphertext, err := s.sealer.Seal(plaintext, []byte(withdrawalID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to seal travel rule data: %w", err)
+	}
+
+	metrics.Add("withdrawals.travel_rule.captured", 1)
+	return &domain.WithdrawalTravelRule{
+		Jurisdiction:      jurisdiction,
+		ThresholdUSDCents: threshold,
+		Required:          required,
+		Nonce:             base64.StdEncoding.EncodeToString(nonce),
+		Ciphertext:        base64.StdEncoding.EncodeToString(ciphertext),
+		CapturedAt:        now,
+	}, nil
+}
+
+func (s *travelRuleService) Export(ctx context.Context, adminID, withdrawalID string, info domain.RequestInfo) (*domain.TravelRuleExport, error) {
+	withdrawal, err := s.withdrawalRepo.GetByWithdrawalID(ctx, withdrawalID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrWithdrawalNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var metadata domain.WithdrawalMetadata
+	if len(withdrawal.Metadata) > 0 {
+		if err := json.Unmarshal(withdrawal.Metadata, &metadata); err != nil {
+			return nil, fmt.Errorf("invalid metadata on withdrawal %s: %w", withdrawal.WithdrawalID, err)
+		}
+	}
+	if metadata.TravelRule == nil {
+		return nil, ErrNoTravelRule
+	}
+
+	data, err := s.open(withdrawal.WithdrawalID, metadata.TravelRule)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.Add("withdrawals.travel_rule.exported", 1)
+	s.writeAudit(ctx, withdrawal.WithdrawalID, adminID, info, map[string]interface{}{
+		"user_id":      withdrawal.UserID,
+		"jurisdiction": data.Jurisdiction,
+		"wallet_type":  data.Beneficiary.WalletType,
+	})
+	return &domain.TravelRuleExport{
+		WithdrawalID:      withdrawal.WithdrawalID,
+		ChainID:           withdrawal.ChainID,
+		CryptoCurrency:    withdrawal.CryptoCurrency,
+		CryptoAmount:      withdrawal.CryptoAmount,
+		USDAmountCents:    data.USDAmountCents,
+		TxHash:            withdrawal.TxHash,
+		WalletType:        data.Beneficiary.WalletType,
+		OwnershipAttested: data.Beneficiary.OwnershipAttested,
+		AttestedAt:        data.AttestedAt,
+		CapturedAt:        data.CapturedAt,
+		IVMS101:           s.ivms101(data),
+	}, nil
+}
+
+func (s *travelRuleService) open(withdrawalID string, record *domain.WithdrawalTravelRule) (*domain.TravelRuleData, error) {
+	nonce, err := base64.StdEncoding.DecodeString(record.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("invalid travel rule nonce on withdrawal %s: %w", withdrawalID, err)
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(record.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("invalid travel rule ciphertext on withdrawal %s: %w", withdrawalID, err)
+	}
+	plaintext, err := s.sealer.Open(nonce, ciphertext, []byte(withdrawalID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open travel rule data of withdrawal %s: %w", withdrawalID, err)
+	}
+	var data domain.TravelRuleData
+	if err := json.Unmarshal(plaintext, &data); err != nil {
+		return nil, fmt.Errorf("invalid travel rule data on withdrawal %s: %w", withdrawalID, err)
+	}
+	return &data, nil
+}
+
+// threshold reads the threshold config on every call, so edits to the row
+// apply to the next withdrawal.
+func (s *travelRuleService) threshold(ctx context.Context) domain.TravelRuleThreshold {
+	raw, err := s.configRepo.GetConfig(ctx, travelRuleThresholdKey)
+	if err != nil {
+		s.logger.Warn().Err(err).Msg("Failed to load travel rule threshold, using default")
+		return defaultThreshold
+	}
+	var threshold domain.TravelRuleThreshold
+	if err := json.Unmarshal(raw, &threshold); err != nil {
+		s.logger.Warn().Err(err).Msg("Invalid travel rule threshold, using default")
+		return defaultThreshold
+	}
+	return threshold
+}
+
+func (s *travelRuleService) writeAudit(ctx context.Context, withdrawalID, adminID string, info domain.RequestInfo, newValues map[string]interface{}) {
+	newJSON, _ := json.Marshal(newValues)
+	if err := s.auditRepo.CreateAuditLog(ctx, domain.AuditLog{
+		Action:     auditActionExported,
+		EntityType: auditEntityWithdrawal,
+		EntityID:   withdrawalID,
+		AdminID:    adminID,
+		NewValues:  newJSON,
+		IPAddress:  info.IPAddress,
+		UserAgent:  info.UserAgent,
+	}); err != nil {
+		metrics.Add("audit.write_failures", 1)
+		s.logger.Error().
+			Err(err).
+			Str("action", auditActionExported).
+			Str("entity_id", withdrawalID).
+			Msg("Failed to write audit log")
+	}
+}
+
+// validate normalises the beneficiary information: a hosted wallet must name
+// its VASP, and a self-hosted one must be attested as the beneficiary's.
+func validate(req domain.TravelRuleRequest) (domain.TravelRuleRequest, error) {
+	req.BeneficiaryName = strings.TrimSpace(req.BeneficiaryName)
+	req.VASPName = strings.TrimSpace(req.VASPName)
+	req.VASPLEI = strings.ToUpper(strings.TrimSpace(req.VASPLEI))
+	if req.BeneficiaryName == "" {
+		return req, fmt.Errorf("%w: beneficiary_name is required", ErrInvalidTravelRule)
+	}
+	switch req.WalletType {
+	case domain.TravelRuleWalletHosted:
+		if req.VASPName == "" {
+			return req, fmt.Errorf("%w: vasp_name is required for hosted wallets", ErrInvalidTravelRule)
+		}
+	case domain.TravelRuleWalletSelfHosted:
+		if !req.OwnershipAttested {
+			return req, fmt.Errorf("%w: self-hosted wallets need an ownership attestation", ErrInvalidTravelRule)
+		}
+		req.VASPName, req.VASPLEI = "", ""
+	default:
+		return req, fmt.Errorf("%w: unknown wallet_type %q", ErrInvalidTravelRule, req.WalletType)
+	}
+	return req, nil
+}
@@ -0,0 +1,56 @@
+package travelruleservice
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/internal/domain"
+	"github.com/tuncanbit/tvs/internal/repositories/withdrawalrepo"
+)
+
+type stubWithdrawals struct {
+	withdrawalrepo.IWithdrawalRepository
+	withdrawal domain.Withdrawal
+}
+
+func (s stubWithdrawals) GetByWithdrawalID(context.Context, string) (*domain.Withdrawal, error) {
+	withdrawal := s.withdrawal
+	return &withdrawal, nil
+}
+
+func TestExport(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata json.RawMessage
+		wantErr  error
+	}{
+		{name: "no metadata", wantErr: ErrNoTravelRule},
+		{name: "no travel rule", metadata: json.RawMessage(`{"fiat_currency":"USD"}`), wantErr: ErrNoTravelRule},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &travelRuleService{
+				withdrawalRepo: stubWithdrawals{withdrawal: domain.Withdrawal{WithdrawalID: "wd_123", Metadata: tt.metadata}},
+				logger:         zerolog.Nop(),
+			}
+			if _, err := s.Export(context.Background(), "", "wd_123", domain.RequestInfo{}); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Export() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExportInvalidMetadata(t *testing.T) {
+	s := &travelRuleService{
+		withdrawalRepo: stubWithdrawals{withdrawal: domain.Withdrawal{WithdrawalID: "wd_123", Metadata: json.RawMessage(`{"travel_rule":`)}},
+		logger:         zerolog.Nop(),
+	}
+	_, err := s.Export(context.Background(), "", "wd_123", domain.RequestInfo{})
+	var syntaxErr *json.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		t.Errorf("Export() error = %v, want the metadata's JSON error", err)
+	}
+}
@@ -2,11 +2,16 @@ package verificationservice
 
 import (
 	"context"
+	"errors"
 
 	"github.com/tuncanbit/tvs/internal/domain"
 )
 
+var ErrInvalidWebhookPayload = errors.New("invalid webhook payload")
+
 type IVerificationService interface {
 	StartTransactionVerification(ctx context.Context) error
+	// VerifyTransactionFromPDMWebhook rejects payloads whose addresses are
+	// malformed for the payload's chain.
 	VerifyTransactionFromPDMWebhook(ctx context.Context, req domain.PDMWebhookRequest) error
 }
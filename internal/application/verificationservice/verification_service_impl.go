@@ -3,6 +3,7 @@ package verificationservice
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"net"
@@ -12,29 +13,50 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/internal/application/accountpolicyservice"
+	"github.com/tuncanbit/tvs/internal/application/conversionservice"
+	"github.com/tuncanbit/tvs/internal/application/feeservice"
+	"github.com/tuncanbit/tvs/internal/application/limitservice"
+	"github.com/tuncanbit/tvs/internal/application/playerprotectionservice"
+	"github.com/tuncanbit/tvs/internal/application/pricingservice"
+	"github.com/tuncanbit/tvs/internal/application/quoteservice"
+	"github.com/tuncanbit/tvs/internal/application/reviewservice"
+	"github.com/tuncanbit/tvs/internal/application/screeningservice"
 	"github.com/tuncanbit/tvs/internal/domain"
-	"github.com/tuncanbit/tvs/internal/infrastructure/clients"
 	"github.com/tuncanbit/tvs/internal/infrastructure/rpc"
 	"github.com/tuncanbit/tvs/internal/repositories/balancerepo"
 	"github.com/tuncanbit/tvs/internal/repositories/sessionrepo"
 	"github.com/tuncanbit/tvs/internal/repositories/transactionrepo"
+	"github.com/tuncanbit/tvs/internal/repositories/userrepo"
 	"github.com/tuncanbit/tvs/internal/repositories/withdrawalrepo"
 	"github.com/tuncanbit/tvs/internal/server/websocket"
+	"github.com/tuncanbit/tvs/pkg/address"
+	"github.com/tuncanbit/tvs/pkg/circuitbreaker"
 	"github.com/tuncanbit/tvs/pkg/config"
 	"github.com/tuncanbit/tvs/pkg/currency"
+	"github.com/tuncanbit/tvs/pkg/solana"
 )
 
 type verificationService struct {
-	sessionRepo       sessionrepo.ISessionRepository
-	transactionRepo   transactionrepo.ITransactionRepository
-	balanceRepo       balancerepo.IBalanceRepository
-	withdrawalRepo    withdrawalrepo.IWithdrawalRepository
-	config            config.VerificationConfig
-	logger            zerolog.Logger
-	heliusClient      *rpc.HeliusClient
-	exchangeAPIClient *clients.ExchangeAPIClient
-	currencyUtils     *currency.CurrencyUtils
-	wsHub             *websocket.WsHub
+	sessionRepo         sessionrepo.ISessionRepository
+	transactionRepo     transactionrepo.ITransactionRepository
+	balanceRepo         balancerepo.IBalanceRepository
+	withdrawalRepo      withdrawalrepo.IWithdrawalRepository
+	userRepo            userrepo.IUserRepository
+	config              config.VerificationConfig
+	logger              zerolog.Logger
+	heliusClient        *rpc.HeliusClient
+	pricingSvc          pricingservice.IPricingService
+	conversionSvc       conversionservice.IConversionService
+	quoteSvc            quoteservice.IQuoteService
+	limitSvc            limitservice.ILimitService
+	reviewSvc           reviewservice.IReviewService
+	screeningSvc        screeningservice.IScreeningService
+	policySvc           accountpolicyservice.IAccountPolicyService
+	playerProtectionSvc playerprotectionservice.IPlayerProtectionService
+	feeSvc              feeservice.IFeeService
+	currencyUtils       *currency.CurrencyUtils
+	wsHub               *websocket.WsHub
 }
 
 func New(
@@ -42,23 +64,41 @@ func New(
 	transactionRepo transactionrepo.ITransactionRepository,
 	balanceRepo balancerepo.IBalanceRepository,
 	withdrawalRepo withdrawalrepo.IWithdrawalRepository,
+	userRepo userrepo.IUserRepository,
 	cfg config.VerificationConfig,
 	logger zerolog.Logger,
 	heliusClient *rpc.HeliusClient,
-	exchangeAPIClient *clients.ExchangeAPIClient,
+	pricingSvc pricingservice.IPricingService,
+	conversionSvc conversionservice.IConversionService,
+	quoteSvc quoteservice.IQuoteService,
+	limitSvc limitservice.ILimitService,
+	reviewSvc reviewservice.IReviewService,
+	screeningSvc screeningservice.IScreeningService,
+	policySvc accountpolicyservice.IAccountPolicyService,
+	playerProtectionSvc playerprotectionservice.IPlayerProtectionService,
+	feeSvc feeservice.IFeeService,
 	wsHub *websocket.WsHub,
 ) IVerificationService {
 	return &verificationService{
-		sessionRepo:       sessionRepo,
-		transactionRepo:   transactionRepo,
-		balanceRepo:       balanceRepo,
-		withdrawalRepo:    withdrawalRepo,
-		config:            cfg,
-		logger:            logger,
-		heliusClient:      heliusClient,
-		exchangeAPIClient: exchangeAPIClient,
-		currencyUtils:     currency.NewCurrencyUtils(),
-		wsHub:             wsHub,
+		sessionRepo:         sessionRepo,
+		transactionRepo:     transactionRepo,
+		balanceRepo:         balanceRepo,
+		withdrawalRepo:      withdrawalRepo,
+		userRepo:            userRepo,
+		config:              cfg,
+		logger:              logger,
+		heliusClient:        heliusClient,
+		pricingSvc:          pricingSvc,
+		conversionSvc:       conversionSvc,
+		quoteSvc:            quoteSvc,
+		limitSvc:            limitSvc,
+		reviewSvc:           reviewSvc,
+		screeningSvc:        screeningSvc,
+		policySvc:           policySvc,
+		playerProtectionSvc: playerProtectionSvc,
+		feeSvc:              feeSvc,
+		currencyUtils:       currency.NewCurrencyUtils(),
+		wsHub:               wsHub,
 	}
 }
 
@@ -115,7 +155,9 @@ func (s *verificationService) processPendingSessions(ctx context.Context) error
 				continue
 			}
 
-			if time.Since(session.CreatedAt) > time.Duration(s.config.SessionTimeoutHours)*time.Hour {
+			// A session released from review was paid before it was held,
+			// so it is credited however long the review took.
+			if time.Since(session.CreatedAt) > time.Duration(s.config.SessionTimeoutHours)*time.Hour && session.ReleasedHold() == nil {
 				session.Status = domain.SessionStatusExpired
 				if err := s.sessionRepo.UpdateDepositSessionStatus(ctx, session.SessionID, string(domain.SessionStatusExpired), "Session expired"); err != nil {
 					s.logger.Error().
@@ -126,6 +168,25 @@ func (s *verificationService) processPendingSessions(ctx context.Context) error
 				s.wsHub.BroadcastDepositSession(session)
 				continue
 			}
+
+			// A malformed deposit address can never receive the payment,
+			// so the session fails now rather than expiring unpaid.
+			if _, err := address.Validate(session.ChainID, session.WalletAddress); err != nil && !errors.Is(err, address.ErrUnsupportedChain) {
+				s.logger.Warn().
+					Str("session_id", session.SessionID).
+					Str("wallet_address", session.WalletAddress).
+					Err(err).
+					Msg("Invalid deposit wallet address")
+				session.Status = domain.SessionStatusFailed
+				if err := s.sessionRepo.UpdateDepositSessionStatus(ctx, session.SessionID, string(domain.SessionStatusFailed), fmt.Sprintf("Invalid wallet address: %v", err)); err != nil {
+					s.logger.Error().
+						Str("session_id", session.SessionID).
+						Err(err).
+						Msg("Failed to mark session as failed")
+				}
+				s.wsHub.BroadcastDepositSession(session)
+				continue
+			}
 			chainSessions[session.ChainID] = append(chainSessions[session.ChainID], session)
 		}
 
@@ -135,6 +196,13 @@ func (s *verificationService) processPendingSessions(ctx context.Context) error
 	for chainID, sessions := range chainSessions {
 		switch chainID {
 		case "sol-mainnet", "sol-testnet":
+			if !s.heliusClient.Available() {
+				s.logger.Warn().
+					Str("chain_id", chainID).
+					Int("session_count", len(sessions)).
+					Msg("Provider circuit breaker open, pausing session verification")
+				continue
+			}
 			go s.processSolanaSessions(ctx, sessions)
 		case "eth-mainnet", "eth-testnet":
 			go s.processEthereumSessions(ctx, sessions)
@@ -166,6 +234,18 @@ func (s *verificationService) processPendingWithdrawals(ctx context.Context) err
 		}
 
 		for _, withdrawal := range withdrawals {
+			approved, err := s.limitSvc.EnforceWithdrawalLimits(ctx, &withdrawal)
+			if err != nil {
+				s.logger.Error().
+					Str("withdrawal_id", withdrawal.WithdrawalID).
+					Err(err).
+					Msg("Failed to check withdrawal limits, will retry")
+				continue
+			}
+			if !approved {
+				s.wsHub.BroadcastWithdrawal(withdrawal)
+				continue
+			}
 			if isPDMChain(withdrawal.ChainID) {
 				s.logger.Info().
 					Str("withdrawal_id", withdrawal.WithdrawalID).
@@ -179,13 +259,27 @@ func (s *verificationService) processPendingWithdrawals(ctx context.Context) err
 					Msg("Skipping completed withdrawal")
 				continue
 			}
-			if withdrawal.TxHash == "" && time.Since(withdrawal.CreatedAt) < time.Duration(s.config.PollingInterval)*2*time.Second {
+			if withdrawal.TxHash == "" && time.Now().Before(s.txHashDeadline(withdrawal)) {
 				s.logger.Info().
 					Str("withdrawal_id", withdrawal.WithdrawalID).
 					Msg("No transaction hash for withdrawal, too early to verify")
 				continue
 			}
 			if withdrawal.TxHash == "" {
+				pending, err := s.broadcastMayLand(ctx, withdrawal)
+				if err != nil {
+					s.logger.Error().
+						Str("withdrawal_id", withdrawal.WithdrawalID).
+						Err(err).
+						Msg("Failed to check broadcast transaction, will retry")
+					continue
+				}
+				if pending {
+					s.logger.Info().
+						Str("withdrawal_id", withdrawal.WithdrawalID).
+						Msg("Broadcast transaction may still land, not failing withdrawal")
+					continue
+				}
 				s.logger.Warn().
 					Str("withdrawal_id", withdrawal.WithdrawalID).
 					Msg("No transaction hash for withdrawal, marking as failed")
@@ -206,6 +300,13 @@ func (s *verificationService) processPendingWithdrawals(ctx context.Context) err
 	for chainID, withdrawals := range chainWithdrawals {
 		switch chainID {
 		case "sol-mainnet", "sol-testnet":
+			if !s.heliusClient.Available() {
+				s.logger.Warn().
+					Str("chain_id", chainID).
+					Int("withdrawal_count", len(withdrawals)).
+					Msg("Provider circuit breaker open, pausing withdrawal verification")
+				continue
+			}
 			go s.processSolanaWithdrawals(ctx, withdrawals)
 		case "eth-mainnet", "eth-testnet":
 			go s.processEthereumWithdrawals(ctx, withdrawals)
@@ -304,7 +405,7 @@ func (s *verificationService) verifySolanaSession(ctx context.Context, session d
 		return nil
 	}
 
-	tokenType, err := mapCryptoToSPLTokenType(session.CryptoCurrency)
+	tokenType, err := rpc.TokenTypeForCrypto(session.CryptoCurrency)
 	if err != nil {
 		s.logger.Info().
 			Str("session_id", session.SessionID).
@@ -313,7 +414,7 @@ func (s *verificationService) verifySolanaSession(ctx context.Context, session d
 		return nil
 	}
 
-	clusterType, err := mapChainIDToClusterType(session.ChainID)
+	clusterType, err := rpc.ClusterTypeForChain(session.ChainID)
 	if err != nil {
 		return fmt.Errorf("invalid chain_id %s: %w", session.ChainID, err)
 	}
@@ -323,12 +424,30 @@ func (s *verificationService) verifySolanaSession(ctx context.Context, session d
 		return fmt.Errorf("failed to get decimals for %s on %s: %w", tokenType, clusterType, err)
 	}
 
-	exchangeRate, err := s.exchangeAPIClient.GetExchangeRate(ctx, session.CryptoCurrency, "USD")
+	requiredAmount := session.Amount
+	quote, err := s.quoteSvc.GetSessionQuote(ctx, session.SessionID)
 	if err != nil {
-		return fmt.Errorf("Failed to get exchange rate, using default rate, %v", err)
+		return fmt.Errorf("failed to get quote for session %s: %w", session.SessionID, err)
+	}
+	if quote != nil {
+		requiredAmount = s.quoteSvc.MinCryptoAmount(quote)
 	}
 
-	requiredAmount := session.Amount
+	// A session released from review is credited from the transfer recorded
+	// when it was held, without searching for or screening it again. The
+	// release only clears the hold it was reviewed for, so the user's deposit
+	// limits and self-exclusion are checked again first.
+	if hold := session.ReleasedHold(); hold != nil && hold.Match != nil {
+		limitHold, err := s.limitHold(ctx, session, hold.FromAddress, hold.Match, decimals)
+		if err != nil {
+			s.resetSession(ctx, session.SessionID, fmt.Sprintf("Deposit checks failed: %v", err))
+			return fmt.Errorf("failed to check released deposit for session %s: %w", session.SessionID, err)
+		}
+		if limitHold != nil {
+			return s.holdDeposit(ctx, session, *limitHold)
+		}
+		return s.creditDeposit(ctx, session, quote, *hold.Match, tokenType, decimals)
+	}
 
 	params := rpc.VerifyDepositParams{
 		Address:        session.WalletAddress,
@@ -340,7 +459,7 @@ func (s *verificationService) verifySolanaSession(ctx context.Context, session d
 	const maxRetries = 3
 	var backoffBase = time.Duration(s.config.PollingInterval) * time.Second
 	for attempt := 0; attempt <= maxRetries; attempt++ {
-		isVerified, transactions, err := s.heliusClient.VerifyDeposit(ctx, params)
+		isVerified, match, err := s.heliusClient.VerifyDeposit(ctx, params)
 		if err == nil {
 			if isVerified {
 				existingTx, txErr := s.transactionRepo.GetByDepositSessionID(ctx, session.SessionID)
@@ -351,23 +470,15 @@ func (s *verificationService) verifySolanaSession(ctx context.Context, session d
 						Msg("Session transaction created during verification, skipping")
 					return nil
 				}
-				if processErr := s.processVerifiedDeposit(ctx, session, transactions, tokenType, requiredAmount, clusterType, exchangeRate.Rate, decimals); processErr != nil {
-					if strings.Contains(processErr.Error(), "duplicate key value violates unique constraint \"unique_deposit_transaction\"") {
-						s.logger.Info().
-							Str("session_id", session.SessionID).
-							Str("tx_hash", transactions[0].Signature).
-							Msg("Transaction already created by another process, skipping")
-						return nil
-					}
-					if resetErr := s.sessionRepo.UpdateDepositSessionStatus(ctx, session.SessionID, string(domain.SessionStatusPending), fmt.Sprintf("Processing failed: %v", processErr)); resetErr != nil {
-						s.logger.Error().
-							Str("session_id", session.SessionID).
-							Err(resetErr).
-							Msg("Failed to reset session to pending after processing error")
-					}
-					return fmt.Errorf("failed to process verified deposit for session %s: %w", session.SessionID, processErr)
+				hold, holdErr := s.depositHold(ctx, session, match, tokenType, decimals)
+				if holdErr != nil {
+					s.resetSession(ctx, session.SessionID, fmt.Sprintf("Deposit checks failed: %v", holdErr))
+					return fmt.Errorf("failed to check deposit for session %s: %w", session.SessionID, holdErr)
 				}
-				return nil
+				if hold != nil {
+					return s.holdDeposit(ctx, session, *hold)
+				}
+				return s.creditDeposit(ctx, session, quote, *match, tokenType, decimals)
 			}
 			s.logger.Warn().
 				Str("session_id", session.SessionID).
@@ -459,7 +570,7 @@ func (s *verificationService) verifySolanaWithdrawal(ctx context.Context, withdr
 		return s.markWithdrawalFailed(ctx, withdrawal, "No transaction hash provided")
 	}
 
-	tokenType, err := mapCryptoToSPLTokenType(withdrawal.CryptoCurrency)
+	tokenType, err := rpc.TokenTypeForCrypto(withdrawal.CryptoCurrency)
 	if err != nil {
 		s.logger.Info().
 			Str("withdrawal_id", withdrawal.WithdrawalID).
@@ -468,7 +579,7 @@ func (s *verificationService) verifySolanaWithdrawal(ctx context.Context, withdr
 		return nil
 	}
 
-	clusterType, err := mapChainIDToClusterType(withdrawal.ChainID)
+	clusterType, err := rpc.ClusterTypeForChain(withdrawal.ChainID)
 	if err != nil {
 		return fmt.Errorf("invalid chain_id %s: %w", withdrawal.ChainID, err)
 	}
@@ -477,11 +588,16 @@ func (s *verificationService) verifySolanaWithdrawal(ctx context.Context, withdr
 	if err != nil {
 		return fmt.Errorf("failed to parse withdrawal amount %s: %w", withdrawal.CryptoAmount, err)
 	}
+	decimals, err := s.heliusClient.GetDecimals(clusterType, tokenType)
+	if err != nil {
+		return fmt.Errorf("failed to get decimals of %s: %w", tokenType, err)
+	}
 
 	params := rpc.VerifyWithdrawalParams{
 		TxHash:      withdrawal.TxHash,
+		FromAddress: withdrawal.SourceWalletAddress,
 		ToAddress:   withdrawal.ToAddress,
-		Amount:      amount,
+		Amount:      uint64(math.Round(amount * math.Pow10(decimals))),
 		TokenType:   tokenType,
 		ClusterType: clusterType,
 	}
@@ -489,8 +605,16 @@ func (s *verificationService) verifySolanaWithdrawal(ctx context.Context, withdr
 	const maxRetries = 3
 	var backoffBase = time.Duration(s.config.PollingInterval) * time.Second
 	for attempt := 0; attempt <= maxRetries; attempt++ {
-		isVerified, transaction, err := s.heliusClient.VerifyWithdrawal(ctx, params)
+		isVerified, transaction, mismatches, err := s.heliusClient.VerifyWithdrawal(ctx, params)
 		if err == nil {
+			if !isVerified && mismatchApproved(withdrawal) {
+				s.logger.Warn().
+					Str("withdrawal_id", withdrawal.WithdrawalID).
+					Str("tx_hash", withdrawal.TxHash).
+					Strs("mismatches", mismatches).
+					Msg("Completing withdrawal whose transaction mismatch was approved in review")
+				isVerified = true
+			}
 			if isVerified {
 				existingTx, txErr := s.transactionRepo.GetByWithdrawalID(ctx, withdrawal.WithdrawalID)
 				if txErr == nil && existingTx.ID != "" && existingTx.WithdrawalID == withdrawal.WithdrawalID {
@@ -518,13 +642,14 @@ func (s *verificationService) verifySolanaWithdrawal(ctx context.Context, withdr
 				}
 				return nil
 			}
-			s.logger.Warn().
-				Str("withdrawal_id", withdrawal.WithdrawalID).
-				Int("attempt", attempt).
-				Msg("No matching transaction found")
-			if attempt == maxRetries {
-				return s.markWithdrawalFailed(ctx, withdrawal, "No matching transaction found after retries")
-			}
+			// The transaction exists but does not pay out this withdrawal as
+			// recorded. Funds may have moved, so neither complete nor fail it
+			// automatically.
+			return s.reviewSvc.FlagVerificationMismatch(ctx, &withdrawal, domain.WithdrawalVerification{
+				TxHash:     withdrawal.TxHash,
+				Mismatches: mismatches,
+				CheckedAt:  time.Now(),
+			})
 		} else {
 			if isTransientError(err) {
 				s.logger.Warn().
@@ -574,7 +699,8 @@ func (s *verificationService) markWithdrawalFailed(ctx context.Context, withdraw
 	}
 
 	if !withdrawal.ReservationReleased {
-		if err := s.balanceRepo.ReleaseReservedBalance(ctx, withdrawal.UserID, "USD", withdrawal.AmountReservedCents); err != nil {
+		fiatCurrency := withdrawalFiatCurrency(withdrawal)
+		if err := s.balanceRepo.ReleaseReservedBalance(ctx, withdrawal.UserID, fiatCurrency, withdrawal.AmountReservedCents); err != nil {
 			s.logger.Error().
 				Str("withdrawal_id", withdrawal.WithdrawalID).
 				Err(err).
@@ -584,9 +710,9 @@ func (s *verificationService) markWithdrawalFailed(ctx context.Context, withdraw
 				ID:           uuid.New().String(),
 				UserID:       withdrawal.UserID,
 				Component:    "withdrawal",
-				CurrencyCode: "USD",
+				CurrencyCode: fiatCurrency,
 				ChangeCents:  withdrawal.AmountReservedCents,
-				ChangeUnits:  float64(withdrawal.AmountReservedCents) / 100.0,
+				ChangeUnits:  s.currencyUtils.MinorUnitsToMajor(withdrawal.AmountReservedCents, fiatCurrency),
 				Description:  fmt.Sprintf("Released reserved balance for failed withdrawal %s: %s", withdrawal.WithdrawalID, reason),
 				Timestamp:    time.Now(),
 			}
@@ -612,48 +738,97 @@ func (s *verificationService) markWithdrawalFailed(ctx context.Context, withdraw
 	return nil
 }
 
-func (s *verificationService) processVerifiedDeposit(ctx context.Context, session domain.DepositSession, transactions []domain.HeliusTransaction, tokenType domain.SPLTokenType, requiredAmount float64, clusterType domain.SolanaClusterType, exchangeRate float64, decimals int) error {
-	var matchedTx domain.HeliusTransaction
-	var txAmount float64
-	for _, tx := range transactions {
-		if tx.Type == "TRANSFER" {
-			if tokenType == domain.SPLTokenTypeSOL {
-				for _, transfer := range tx.NativeTransfers {
-					adjustedAmount := float64(transfer.Amount) / math.Pow(10, float64(decimals))
-					if transfer.ToUserAccount == session.WalletAddress && adjustedAmount >= requiredAmount {
-						matchedTx = tx
-						txAmount = adjustedAmount
-						break
-					}
-				}
-			} else {
-				targetMint, err := s.heliusClient.GetMintAddress(clusterType, tokenType)
-				if err != nil {
-					return fmt.Errorf("failed to get mint address for %s: %w", tokenType, err)
-				}
-				for _, transfer := range tx.TokenTransfers {
-					s.logger.Debug().
-						Str("transaction", tx.Signature).
-						Str("to", transfer.ToUserAccount).
-						Str("mint", transfer.Mint).
-						Float64("amount", transfer.TokenAmount).
-						Msg("Inspecting token transfer in processVerifiedDeposit")
-
-					if transfer.ToUserAccount == session.WalletAddress && transfer.Mint == targetMint && transfer.TokenAmount >= requiredAmount {
-						matchedTx = tx
-						txAmount = transfer.TokenAmount
-						break
-					}
-				}
-			}
-			if matchedTx.Signature != "" {
-				break
-			}
-		}
+// creditDeposit processes a matched transfer, returning the session to
+// pending if that fails so it is retried.
+func (s *verificationService) creditDeposit(ctx context.Context, session domain.DepositSession, quote *domain.DepositQuote, match domain.DepositMatch, tokenType domain.SPLTokenType, decimals int) error {
+	processErr := s.processVerifiedDeposit(ctx, session, quote, match, tokenType, decimals)
+	if processErr == nil {
+		return nil
+	}
+	if strings.Contains(processErr.Error(), "duplicate key value violates unique constraint \"unique_deposit_transaction\"") {
+		s.logger.Info().
+			Str("session_id", session.SessionID).
+			Str("tx_hash", match.Transaction.Signature).
+			Msg("Transaction already created by another process, skipping")
+		return nil
+	}
+	s.resetSession(ctx, session.SessionID, fmt.Sprintf("Processing failed: %v", processErr))
+	return fmt.Errorf("failed to process verified deposit for session %s: %w", session.SessionID, processErr)
+}
+
+// depositHold returns why a matched transfer must be held instead of
+// credited, or nil if it may be credited: its sender is on a screening list,
+// it breaches the user's deposit limits or self-exclusion, or the account
+// policy restricts the user's deposits. Deposit limits are checked ahead of
+// the account policy so a deposit that can only be refunded is not sent to
+// admin review first.
+func (s *verificationService) depositHold(ctx context.Context, session domain.DepositSession, match *domain.DepositMatch, tokenType domain.SPLTokenType, decimals int) (*domain.DepositHold, error) {
+	fromAddress := getFromAddress(match.Transaction, tokenType)
+	hold := &domain.DepositHold{FromAddress: fromAddress, Match: match, HeldAt: time.Now()}
+	if screening := s.screeningSvc.Screen(fromAddress); screening != nil {
+		hold.Code = domain.RestrictionComplianceReview
+		hold.Reason = fmt.Sprintf("sender %s is on the %s screening list: %s", screening.Address, screening.List, screening.Reason)
+		hold.Screening = screening
+		return hold, nil
+	}
+
+	if limitHold, err := s.limitHold(ctx, session, fromAddress, match, decimals); limitHold != nil || err != nil {
+		return limitHold, err
+	}
+	amount := float64(match.Amount) / math.Pow(10, float64(decimals))
+	restriction, err := s.policySvc.CheckDeposit(ctx, session.UserID, session.CryptoCurrency, amount)
+	if err != nil || restriction == nil {
+		return nil, err
+	}
+	hold.Code = restriction.Code
+	hold.Reason = restriction.Message
+	return hold, nil
+}
+
+// limitHold returns the hold for a matched transfer that breaches the
+// user's deposit limits or self-exclusion, or nil if it does not.
+func (s *verificationService) limitHold(ctx context.Context, session domain.DepositSession, fromAddress string, match *domain.DepositMatch, decimals int) (*domain.DepositHold, error) {
+	amount := float64(match.Amount) / math.Pow(10, float64(decimals))
+	restriction, err := s.playerProtectionSvc.CheckDeposit(ctx, session.UserID, session.CryptoCurrency, amount)
+	if err != nil || restriction == nil {
+		return nil, err
+	}
+	return &domain.DepositHold{FromAddress: fromAddress, Match: match, HeldAt: time.Now(), Code: restriction.Code, Reason: restriction.Message}, nil
+}
+
+// holdDeposit holds a paid session for admin review instead of crediting it.
+func (s *verificationService) holdDeposit(ctx context.Context, session domain.DepositSession, hold domain.DepositHold) error {
+	if err := s.reviewSvc.HoldDeposit(ctx, session, hold); err != nil {
+		s.resetSession(ctx, session.SessionID, "Hold failed")
+		return fmt.Errorf("failed to hold deposit for session %s: %w", session.SessionID, err)
+	}
+	return nil
+}
+
+// resetSession returns a session to pending so the next poll retries it.
+func (s *verificationService) resetSession(ctx context.Context, sessionID, message string) {
+	if err := s.sessionRepo.UpdateDepositSessionStatus(ctx, sessionID, string(domain.SessionStatusPending), message); err != nil {
+		s.logger.Error().
+			Str("session_id", sessionID).
+			Err(err).
+			Msg("Failed to reset session to pending")
 	}
+}
 
-	if matchedTx.Signature == "" {
-		return fmt.Errorf("no matching transaction found despite verification for session %s", session.SessionID)
+// processVerifiedDeposit credits the amount the deposit address actually
+// received, which for a Token-2022 mint with a transfer fee is less than what
+// was sent.
+func (s *verificationService) processVerifiedDeposit(ctx context.Context, session domain.DepositSession, quote *domain.DepositQuote, match domain.DepositMatch, tokenType domain.SPLTokenType, decimals int) error {
+	matchedTx := match.Transaction
+	txAmount := float64(match.Amount) / math.Pow(10, float64(decimals))
+	if match.Sent > match.Amount {
+		s.logger.Info().
+			Str("session_id", session.SessionID).
+			Str("tx_hash", matchedTx.Signature).
+			Int64("sent", match.Sent).
+			Int64("received", match.Amount).
+			Str("mint", match.Mint).
+			Msg("Transfer fee withheld from deposit")
 	}
 
 	existingTx, err := s.transactionRepo.GetByHash(ctx, session.ChainID, matchedTx.Signature)
@@ -666,7 +841,41 @@ func (s *verificationService) processVerifiedDeposit(ctx context.Context, sessio
 		return nil
 	}
 
-	usdAmountCents := s.currencyUtils.CryptoToUSDCents(txAmount, exchangeRate)
+	fiatCurrency := s.balanceCurrency(ctx, session.UserID)
+	if quote != nil {
+		fiatCurrency = quote.FiatCurrency
+	}
+	paidAt := time.Unix(matchedTx.Timestamp, 0)
+	rate, err := s.pricingSvc.GetDepositRate(ctx, session, paidAt, fiatCurrency)
+	if err != nil {
+		return fmt.Errorf("failed to get exchange rate for session %s: %w", session.SessionID, err)
+	}
+	if quote != nil {
+		if rate, err = s.quoteSvc.Settle(ctx, quote, rate, txAmount, paidAt); err != nil {
+			return fmt.Errorf("failed to settle quote for session %s: %w", session.SessionID, err)
+		}
+	}
+	if rate.Stale {
+		s.logger.Warn().
+			Str("session_id", session.SessionID).
+			Dur("rate_age", rate.Age).
+			Msg("Using stale exchange rate for deposit valuation")
+	}
+	exchangeRate := rate.Rate
+
+	usdConversion := s.conversionSvc.Convert(ctx, txAmount, rate.PriceUSD, "USD")
+	fiatConversion := usdConversion
+	if fiatCurrency != "USD" {
+		fiatConversion = s.conversionSvc.Convert(ctx, txAmount, exchangeRate, fiatCurrency)
+	}
+	usdAmountCents := usdConversion.Converted
+	fiatAmount := fiatConversion.Converted
+
+	networkFee, err := s.feeSvc.AssessNetworkFee(ctx, session.ChainID, domain.TypeDeposit, matchedTx.Signature, matchedTx.FeePayer, matchedTx.Fee, "")
+	if err != nil {
+		return fmt.Errorf("failed to assess network fee for session %s: %w", session.SessionID, err)
+	}
+	networkFee.DepositSessionID = session.SessionID
 
 	tx := domain.Transaction{
 		ID:               uuid.New().String(),
@@ -679,8 +888,8 @@ func (s *verificationService) processVerifiedDeposit(ctx context.Context, sessio
 		ToAddress:        session.WalletAddress,
 		Amount:           fmt.Sprintf("%.18f", txAmount),
 		USDAmountCents:   usdAmountCents,
-		ExchangeRate:     fmt.Sprintf("%.6f", exchangeRate),
-		Fee:              fmt.Sprintf("%.18f", float64(matchedTx.Fee)/math.Pow(10, 9)),
+		ExchangeRate:     fmt.Sprintf("%.6f", rate.PriceUSD),
+		Fee:              networkFee.Amount,
 		BlockNumber:      matchedTx.Slot,
 		Status:           domain.StatusVerified,
 		Confirmations:    1,
@@ -699,23 +908,44 @@ func (s *verificationService) processVerifiedDeposit(ctx context.Context, sessio
 			Str("session_id", session.SessionID).
 			Str("transaction_hash", matchedTx.Signature).
 			Msg("Failed to marshal metadata")
-		tx.Metadata = json.RawMessage("{}")
-		session.Metadata = json.RawMessage("{}")
-	} else {
-		tx.Metadata = metadata
-		session.Metadata = metadata
+		metadata = json.RawMessage("{}")
 	}
+	session.Metadata = metadata
+
+	txMetadata, err := json.Marshal(domain.TransactionMetadata{
+		Chain:        metadata,
+		Pricing:      rate,
+		Quote:        quote,
+		NetworkFee:   networkFee,
+		Mint:         match.Mint,
+		TokenProgram: match.TokenProgram,
+		TransferFee:  match.Sent - match.Amount,
+	})
+	if err != nil {
+		s.logger.Error().
+			Err(err).
+			Str("session_id", session.SessionID).
+			Str("transaction_hash", matchedTx.Signature).
+			Msg("Failed to marshal transaction metadata")
+		txMetadata = json.RawMessage("{}")
+	}
+	tx.Metadata = txMetadata
 
 	if err := s.transactionRepo.Create(ctx, tx); err != nil {
 		return fmt.Errorf("failed to create transaction record for session %s: %w", session.SessionID, err)
 	}
+	s.recordDepositRemainders(ctx, session.ChainID, tx.TxHash, usdConversion, fiatConversion, networkFee.Conversions)
+	s.feeSvc.RecordNetworkFee(ctx, *networkFee)
 
-	balance, err := s.balanceRepo.GetBalance(ctx, session.UserID)
+	if err := s.balanceRepo.EnsureBalance(ctx, session.UserID, fiatCurrency); err != nil {
+		return fmt.Errorf("failed to create %s balance for user %s: %w", fiatCurrency, session.UserID, err)
+	}
+	balance, err := s.balanceRepo.GetBalance(ctx, session.UserID, fiatCurrency)
 	if err != nil {
 		return fmt.Errorf("failed to get balance for user %s: %w", session.UserID, err)
 	}
 
-	newAmountCents := balance.AmountCents + usdAmountCents
+	newAmountCents := balance.AmountCents + fiatAmount
 	currentAmountUnits, err := strconv.ParseFloat(balance.AmountUnits, 64)
 	if err != nil {
 		s.logger.Warn().
@@ -730,14 +960,14 @@ func (s *verificationService) processVerifiedDeposit(ctx context.Context, sessio
 		return fmt.Errorf("invalid balance update for session %s: negative balance", session.SessionID)
 	}
 
-	if err := s.balanceRepo.UpdateBalance(ctx, session.UserID, "USD", newAmountCents, newAmountUnits); err != nil {
+	if err := s.balanceRepo.UpdateBalance(ctx, session.UserID, fiatCurrency, newAmountCents, newAmountUnits); err != nil {
 		return fmt.Errorf("failed to update balance for user %s: %w", session.UserID, err)
 	}
 
 	updatedBalance := domain.Balance{
 		ID:            balance.ID,
 		UserID:        session.UserID,
-		CurrencyCode:  "USD",
+		CurrencyCode:  fiatCurrency,
 		AmountCents:   newAmountCents,
 		AmountUnits:   newAmountUnits,
 		ReservedCents: balance.ReservedCents,
@@ -757,6 +987,7 @@ func (s *verificationService) processVerifiedDeposit(ctx context.Context, sessio
 		Str("transaction_hash", matchedTx.Signature).
 		Float64("amount", txAmount).
 		Int64("usd_amount_cents", usdAmountCents).
+		Str("credited", s.currencyUtils.Format(fiatAmount, fiatCurrency)).
 		Msg("Transaction verified, session updated, and balance updated")
 
 	s.wsHub.BroadcastDepositSession(session)
@@ -785,7 +1016,6 @@ func (s *verificationService) processVerifiedWithdrawal(ctx context.Context, wit
 			Msg("Failed to marshal metadata")
 		metadata = json.RawMessage("{}")
 	}
-
 	amount, err := strconv.ParseFloat(withdrawal.CryptoAmount, 64)
 	if err != nil {
 		return fmt.Errorf("failed to parse withdrawal amount %s: %w", withdrawal.CryptoAmount, err)
@@ -795,6 +1025,49 @@ func (s *verificationService) processVerifiedWithdrawal(ctx context.Context, wit
 		return fmt.Errorf("failed to parse exchange rate %s: %w", withdrawal.ExchangeRate, err)
 	}
 
+	// The fee is assessed before anything is written; a withdrawal with a
+	// transaction is never processed again.
+	fiatCurrency := withdrawalFiatCurrency(withdrawal)
+	networkFee, err := s.feeSvc.AssessNetworkFee(ctx, withdrawal.ChainID, domain.TypeWithdrawal, transaction.Signature, transaction.FeePayer, transaction.Fee, fiatCurrency)
+	if err != nil {
+		return fmt.Errorf("failed to assess network fee for withdrawal %s: %w", withdrawal.WithdrawalID, err)
+	}
+	networkFee.WithdrawalID = withdrawal.WithdrawalID
+
+	// The transaction record, the balance, the reservation, the withdrawal
+	// and its network fee are written together, so a retry after any
+	// failure starts again from nothing and a fee is never posted without
+	// its charge.
+	dbTx, err := s.withdrawalRepo.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction for withdrawal %s: %w", withdrawal.WithdrawalID, err)
+	}
+	defer dbTx.Rollback()
+
+	balance, err := s.balanceRepo.GetBalanceForUpdateTx(ctx, dbTx, withdrawal.UserID, fiatCurrency)
+	if err != nil {
+		return fmt.Errorf("failed to get balance for user %s: %w", withdrawal.UserID, err)
+	}
+	reservedCents, chargedCents, feeCents, ok := withdrawalSettlement(withdrawal, balance.AmountCents, networkFee.ChargedCents)
+	if !ok {
+		return fmt.Errorf("insufficient balance for withdrawal %s", withdrawal.WithdrawalID)
+	}
+	if feeCents < networkFee.ChargedCents {
+		s.logger.Warn().
+			Str("withdrawal_id", withdrawal.WithdrawalID).
+			Int64("network_fee_cents", networkFee.ChargedCents).
+			Int64("charged_cents", feeCents).
+			Msg("Balance does not cover the network fee, charging what is available")
+		networkFee.ChargedCents = feeCents
+	}
+
+	txMetadataDoc := domain.TransactionMetadata{Chain: metadata, NetworkFee: networkFee}
+	s.describeTokenTransfer(ctx, withdrawal, transaction, amount, &txMetadataDoc)
+	txMetadata, err := json.Marshal(txMetadataDoc)
+	if err != nil {
+		txMetadata = json.RawMessage("{}")
+	}
+
 	tx := domain.Transaction{
 		ID:              uuid.New().String(),
 		WithdrawalID:    withdrawal.WithdrawalID,
@@ -807,7 +1080,7 @@ func (s *verificationService) processVerifiedWithdrawal(ctx context.Context, wit
 		Amount:          withdrawal.CryptoAmount,
 		USDAmountCents:  withdrawal.USDAmountCents,
 		ExchangeRate:    withdrawal.ExchangeRate,
-		Fee:             fmt.Sprintf("%.18f", float64(transaction.Fee)/math.Pow(10, 9)),
+		Fee:             networkFee.Amount,
 		BlockNumber:     transaction.Slot,
 		Status:          domain.StatusVerified,
 		Confirmations:   1,
@@ -815,61 +1088,46 @@ func (s *verificationService) processVerifiedWithdrawal(ctx context.Context, wit
 		VerifiedAt:      time.Now(),
 		Processor:       domain.ProcessorInternal,
 		TransactionType: domain.TypeWithdrawal,
-		Metadata:        metadata,
+		Metadata:        txMetadata,
 		CreatedAt:       time.Now(),
 		UpdatedAt:       time.Now(),
 	}
-
-	if err := s.transactionRepo.Create(ctx, tx); err != nil {
+	if err := s.transactionRepo.CreateTx(ctx, dbTx, tx); err != nil {
 		return fmt.Errorf("failed to create transaction record for withdrawal %s: %w", withdrawal.WithdrawalID, err)
 	}
 
-	balance, err := s.balanceRepo.GetBalance(ctx, withdrawal.UserID)
-	if err != nil {
-		return fmt.Errorf("failed to get balance for user %s: %w", withdrawal.UserID, err)
-	}
-	newAmountCents := balance.AmountCents - withdrawal.USDAmountCents
-	currentAmountUnits, err := strconv.ParseFloat(balance.AmountUnits, 64)
-	if err != nil {
-		s.logger.Warn().
-			Str("user_id", withdrawal.UserID).
-			Str("amount_units", balance.AmountUnits).
-			Msg("Invalid balance.AmountUnits, defaulting to 0")
-		currentAmountUnits = 0
-	}
-	newAmountUnits := fmt.Sprintf("%.18f", currentAmountUnits-(amount*exchangeRate))
-	if newAmountCents < 0 || currentAmountUnits-(amount*exchangeRate) < 0 {
+	debitedUnits := fmt.Sprintf("%.18f", amount*exchangeRate)
+	updatedBalance, err := s.balanceRepo.SettleReservedBalanceTx(ctx, dbTx, withdrawal.UserID, fiatCurrency, reservedCents, chargedCents, debitedUnits)
+	if errors.Is(err, balancerepo.ErrInsufficientBalance) {
 		return fmt.Errorf("insufficient balance for withdrawal %s", withdrawal.WithdrawalID)
 	}
-
-	if err := s.balanceRepo.UpdateBalance(ctx, withdrawal.UserID, "USD", newAmountCents, newAmountUnits); err != nil {
-		return fmt.Errorf("failed to update balance for user %s: %w", withdrawal.UserID, err)
-	}
-
-	updatedBalance := domain.Balance{
-		ID:            balance.ID,
-		UserID:        withdrawal.UserID,
-		CurrencyCode:  "USD",
-		AmountCents:   newAmountCents,
-		AmountUnits:   newAmountUnits,
-		ReservedCents: balance.ReservedCents,
-		ReservedUnits: balance.ReservedUnits,
-		UpdatedAt:     time.Now(),
+	if err != nil {
+		return fmt.Errorf("failed to settle balance for withdrawal %s: %w", withdrawal.WithdrawalID, err)
 	}
-
 	if !withdrawal.ReservationReleased {
-		if err := s.balanceRepo.ReleaseReservedBalance(ctx, withdrawal.UserID, "USD", withdrawal.AmountReservedCents); err != nil {
-			return fmt.Errorf("failed to release reserved balance for withdrawal %s: %w", withdrawal.WithdrawalID, err)
-		}
 		withdrawal.ReservationReleased = true
 		withdrawal.ReservationReleasedAt = time.Now()
 	}
 
+	feeJSON, err := json.Marshal(networkFee)
+	if err != nil {
+		return fmt.Errorf("failed to marshal network fee for withdrawal %s: %w", withdrawal.WithdrawalID, err)
+	}
 	withdrawal.Status = domain.WithdrawalStatusCompleted
 	withdrawal.UpdatedAt = time.Now()
-	if err := s.withdrawalRepo.UpdateWithdrawal(ctx, withdrawal); err != nil {
+	if err := s.withdrawalRepo.UpdateWithdrawalTx(ctx, dbTx, withdrawal); err != nil {
 		return fmt.Errorf("failed to update withdrawal %s status: %w", withdrawal.WithdrawalID, err)
 	}
+	if err := s.feeSvc.RecordNetworkFeeTx(ctx, dbTx, *networkFee); err != nil {
+		return fmt.Errorf("failed to record network fee for withdrawal %s: %w", withdrawal.WithdrawalID, err)
+	}
+	if err := s.withdrawalRepo.SetNetworkFeeTx(ctx, dbTx, withdrawal.WithdrawalID, feeJSON); err != nil {
+		return err
+	}
+	if err := dbTx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit completion of withdrawal %s: %w", withdrawal.WithdrawalID, err)
+	}
+	s.recordWithdrawalNetworkFee(ctx, &withdrawal, *networkFee, feeJSON)
 
 	s.logger.Info().
 		Str("withdrawal_id", withdrawal.WithdrawalID).
@@ -879,11 +1137,238 @@ func (s *verificationService) processVerifiedWithdrawal(ctx context.Context, wit
 		Msg("Withdrawal verified, transaction recorded, and balance updated")
 
 	s.wsHub.BroadcastWithdrawal(withdrawal)
-	s.wsHub.BroadcastBalance(updatedBalance)
+	s.wsHub.BroadcastBalance(*updatedBalance)
 
 	return nil
 }
 
+// withdrawalSettlement returns how completing a withdrawal moves the user's
+// balance. The reservation already holds amount and fee, so it is consumed
+// and only the network fee the user pays, capped at what is available,
+// comes out of the available balance. A reservation released earlier is
+// charged to the available balance instead, and ok is false if that does
+// not cover it.
+func withdrawalSettlement(withdrawal domain.Withdrawal, availableCents, networkFeeCents int64) (reservedCents, chargedCents, feeCents int64, ok bool) {
+	reservedCents = withdrawal.AmountReservedCents
+	if withdrawal.ReservationReleased {
+		reservedCents, chargedCents = 0, withdrawal.AmountReservedCents
+	}
+	availableCents -= chargedCents
+	if availableCents < 0 {
+		return 0, 0, 0, false
+	}
+	feeCents = min(networkFeeCents, availableCents)
+	return reservedCents, chargedCents + feeCents, feeCents, true
+}
+
+// describeTokenTransfer records the mint and token program of an SPL
+// withdrawal, and any Token-2022 transfer fee withheld before the payout
+// reached the destination. The details are informational, so a lookup that
+// fails is logged and skipped.
+func (s *verificationService) describeTokenTransfer(ctx context.Context, withdrawal domain.Withdrawal, transaction domain.HeliusTransaction, amount float64, metadata *domain.TransactionMetadata) {
+	tokenType, err := rpc.TokenTypeForCrypto(withdrawal.CryptoCurrency)
+	if err != nil || tokenType == domain.SPLTokenTypeSOL {
+		return
+	}
+	clusterType, err := rpc.ClusterTypeForChain(withdrawal.ChainID)
+	if err != nil {
+		return
+	}
+	mintAddress, err := s.heliusClient.GetMintAddress(clusterType, tokenType)
+	if err != nil {
+		return
+	}
+	metadata.Mint = mintAddress
+
+	mint, err := solana.ParsePublicKey(mintAddress)
+	if err == nil {
+		var program solana.PublicKey
+		if program, err = s.heliusClient.MintProgram(ctx, clusterType, mint); err == nil {
+			metadata.TokenProgram = program.String()
+		}
+	}
+	if err != nil {
+		s.logger.Warn().
+			Err(err).
+			Str("withdrawal_id", withdrawal.WithdrawalID).
+			Str("mint", mintAddress).
+			Msg("Failed to look up token program of withdrawal")
+	}
+
+	decimals, err := s.heliusClient.GetDecimals(clusterType, tokenType)
+	if err != nil {
+		return
+	}
+	sent := int64(math.Round(amount * math.Pow10(decimals)))
+	if received, ok := rpc.ReceivedTokenAmount(transaction, withdrawal.ToAddress, mintAddress); ok && received < sent {
+		metadata.TransferFee = sent - received
+	}
+}
+
+// recordWithdrawalNetworkFee follows up the network fee of a completed
+// withdrawal once it is committed: it records the rounding remainders of the
+// fee's conversions, logs any charge to the user's balance and adds the fee
+// to the withdrawal's metadata so it shows in the broadcast update.
+func (s *verificationService) recordWithdrawalNetworkFee(ctx context.Context, withdrawal *domain.Withdrawal, fee domain.NetworkFee, feeJSON json.RawMessage) {
+	for _, conversion := range fee.Conversions {
+		s.conversionSvc.RecordRemainder(ctx, withdrawal.ID, domain.ConversionTypeWithdrawal, conversion)
+	}
+
+	if fee.ChargedCents > 0 {
+		if err := s.balanceRepo.LogBalanceChange(ctx, &domain.BalanceLog{
+			ID:           uuid.New().String(),
+			UserID:       withdrawal.UserID,
+			Component:    "withdrawal",
+			CurrencyCode: fee.ChargedCurrency,
+			ChangeCents:  -fee.ChargedCents,
+			ChangeUnits:  -s.currencyUtils.MinorUnitsToMajor(fee.ChargedCents, fee.ChargedCurrency),
+			Description:  fmt.Sprintf("Network fee of %s %s for withdrawal %s", fee.Amount, fee.Currency, withdrawal.WithdrawalID),
+			Timestamp:    time.Now(),
+		}); err != nil {
+			s.logger.Err(err).Msg("Failed to log network fee charge")
+		}
+	}
+
+	var metadata map[string]json.RawMessage
+	if len(withdrawal.Metadata) == 0 || json.Unmarshal(withdrawal.Metadata, &metadata) != nil {
+		metadata = map[string]json.RawMessage{}
+	}
+	metadata["network_fee"] = feeJSON
+	if updated, err := json.Marshal(metadata); err == nil {
+		withdrawal.Metadata = updated
+	}
+}
+
+// recordDepositRemainders stores the rounding remainders of a deposit's USD
+// valuation, of its fiat credit when credited in another currency, and of
+// its network fee valuation. The transaction row gets its ID from the
+// database, so it is looked up by hash first.
+func (s *verificationService) recordDepositRemainders(ctx context.Context, chainID, txHash string, usdConversion, fiatConversion domain.Conversion, feeConversions []domain.Conversion) {
+	created, err := s.transactionRepo.GetByHash(ctx, chainID, txHash)
+	if err != nil {
+		s.logger.Error().
+			Err(err).
+			Str("transaction_hash", txHash).
+			Msg("Failed to look up transaction to record conversion remainders")
+		return
+	}
+
+	s.conversionSvc.RecordRemainder(ctx, created.ID, domain.ConversionTypeDeposit, usdConversion)
+	if fiatConversion.CurrencyCode != usdConversion.CurrencyCode {
+		s.conversionSvc.RecordRemainder(ctx, created.ID, domain.ConversionTypeDeposit, fiatConversion)
+	}
+	for _, conversion := range feeConversions {
+		s.conversionSvc.RecordRemainder(ctx, created.ID, domain.ConversionTypeDeposit, conversion)
+	}
+}
+
+// balanceCurrency returns the fiat currency deposits for the user are
+// credited in: their default currency when it is supported, otherwise USD.
+func (s *verificationService) balanceCurrency(ctx context.Context, userID string) string {
+	currency, err := s.userRepo.GetDefaultCurrency(ctx, userID)
+	if err != nil {
+		s.logger.Warn().
+			Err(err).
+			Str("user_id", userID).
+			Msg("Failed to get user default currency, using USD")
+		return "USD"
+	}
+	if currency == "" {
+		return "USD"
+	}
+	if !s.pricingSvc.SupportsFiat(currency) {
+		s.logger.Warn().
+			Str("user_id", userID).
+			Str("currency", currency).
+			Msg("User default currency is not supported, using USD")
+		return "USD"
+	}
+	return currency
+}
+
+// txHashDeadline is when a withdrawal without a transaction hash is failed.
+// Withdrawals created through the API are paid out afterwards, by the
+// withdrawal executor or another broadcaster, and get
+// WithdrawalBroadcastTimeout from when they became payable or were last
+// broadcast; others are expected to arrive with their hash.
+func (s *verificationService) txHashDeadline(withdrawal domain.Withdrawal) time.Time {
+	grace := time.Duration(s.config.PollingInterval) * 2 * time.Second
+	var metadata domain.WithdrawalMetadata
+	if len(withdrawal.Metadata) == 0 || json.Unmarshal(withdrawal.Metadata, &metadata) != nil ||
+		metadata.Origin != domain.WithdrawalOriginAPI || s.config.WithdrawalBroadcastTimeout <= grace {
+		return withdrawal.CreatedAt.Add(grace)
+	}
+	start := metadata.PayableSince(withdrawal.CreatedAt)
+	if metadata.Broadcast != nil && metadata.Broadcast.SignedAt.After(start) {
+		start = metadata.Broadcast.SignedAt
+	}
+	return start.Add(s.config.WithdrawalBroadcastTimeout)
+}
+
+// broadcastMayLand reports whether the payout transaction recorded for a
+// withdrawal without a hash confirmed or may still confirm, in which case
+// failing the withdrawal could refund a user who has been paid. A confirmed
+// transaction has its hash written so the next pass verifies it.
+func (s *verificationService) broadcastMayLand(ctx context.Context, withdrawal domain.Withdrawal) (bool, error) {
+	var metadata domain.WithdrawalMetadata
+	if len(withdrawal.Metadata) > 0 {
+		if err := json.Unmarshal(withdrawal.Metadata, &metadata); err != nil {
+			return false, fmt.Errorf("invalid metadata: %w", err)
+		}
+	}
+	if metadata.Broadcast == nil || metadata.Broadcast.Signature == "" {
+		return false, nil
+	}
+	broadcast := metadata.Broadcast
+	clusterType, err := rpc.ClusterTypeForChain(withdrawal.ChainID)
+	if err != nil {
+		return false, err
+	}
+
+	status, err := s.heliusClient.GetSignatureStatus(ctx, clusterType, broadcast.Signature)
+	if err != nil {
+		return false, fmt.Errorf("failed to get status of %s: %w", broadcast.Signature, err)
+	}
+	if status != nil {
+		if status.Err != nil {
+			return false, nil
+		}
+		if status.ConfirmationStatus == "confirmed" || status.ConfirmationStatus == "finalized" {
+			if _, err := s.withdrawalRepo.SetTxHash(ctx, withdrawal.WithdrawalID, broadcast.Signature); err != nil {
+				return false, err
+			}
+		}
+		return true, nil
+	}
+
+	height, err := s.heliusClient.GetBlockHeight(ctx, clusterType)
+	if err != nil {
+		return false, fmt.Errorf("failed to get block height: %w", err)
+	}
+	return height <= broadcast.LastValidBlockHeight, nil
+}
+
+// mismatchApproved reports whether an admin approved the withdrawal after
+// its current transaction was flagged as not matching it.
+func mismatchApproved(withdrawal domain.Withdrawal) bool {
+	var metadata domain.WithdrawalMetadata
+	if len(withdrawal.Metadata) == 0 || json.Unmarshal(withdrawal.Metadata, &metadata) != nil {
+		return false
+	}
+	verification, review := metadata.Verification, metadata.Review
+	return verification != nil && verification.TxHash == withdrawal.TxHash &&
+		review != nil && review.Decision == domain.ReviewDecisionApproved &&
+		review.DecidedAt != nil && review.DecidedAt.After(verification.CheckedAt)
+}
+
+func withdrawalFiatCurrency(withdrawal domain.Withdrawal) string {
+	var metadata domain.WithdrawalMetadata
+	if len(withdrawal.Metadata) > 0 && json.Unmarshal(withdrawal.Metadata, &metadata) == nil && metadata.FiatCurrency != "" {
+		return metadata.FiatCurrency
+	}
+	return "USD"
+}
+
 func (s *verificationService) processEthereumSessions(ctx context.Context, sessions []domain.DepositSession) {
 	for _, session := range sessions {
 		s.logger.Warn().
@@ -921,6 +1406,16 @@ func (s *verificationService) processTronWithdrawals(ctx context.Context, withdr
 }
 
 func (s *verificationService) VerifyTransactionFromPDMWebhook(ctx context.Context, req domain.PDMWebhookRequest) error {
+	chainID := req.Payload[domain.PDMPayloadChainID]
+	for _, field := range domain.PDMPayloadAddressFields {
+		value, ok := req.Payload[field]
+		if !ok {
+			continue
+		}
+		if _, err := address.Validate(chainID, value); err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrInvalidWebhookPayload, field, err)
+		}
+	}
 	return nil
 }
 
@@ -941,7 +1436,8 @@ func isTransientError(err error) bool {
 		}
 	}
 
-	if strings.Contains(err.Error(), "invalid chain_id") ||
+	if strings.Contains(err.Error(), circuitbreaker.ErrOpen.Error()) ||
+		strings.Contains(err.Error(), "invalid chain_id") ||
 		strings.Contains(err.Error(), "failed to parse JSON response") ||
 		strings.Contains(err.Error(), "no mint address configured") ||
 		strings.Contains(err.Error(), "no Helius base URL configured") {
@@ -959,30 +1455,6 @@ func isPDMChain(chainID string) bool {
 	return chainID == "btc-mainnet" || chainID == "btc-testnet"
 }
 
-func mapCryptoToSPLTokenType(crypto string) (domain.SPLTokenType, error) {
-	switch crypto {
-	case "SOL":
-		return domain.SPLTokenTypeSOL, nil
-	case "USDC":
-		return domain.SPLTokenTypeUSDC, nil
-	case "USDT":
-		return domain.SPLTokenTypeUSDT, nil
-	default:
-		return "", fmt.Errorf("unsupported crypto currency: %s", crypto)
-	}
-}
-
-func mapChainIDToClusterType(chainID string) (domain.SolanaClusterType, error) {
-	switch chainID {
-	case "sol-mainnet":
-		return domain.SolanaClusterTypeMainnet, nil
-	case "sol-testnet":
-		return domain.SolanaClusterTypeTestnet, nil
-	default:
-		return "", fmt.Errorf("unsupported Solana chain: %s", chainID)
-	}
-}
-
 func getFromAddress(tx domain.HeliusTransaction, tokenType domain.SPLTokenType) string {
 	if tokenType == domain.SPLTokenTypeSOL {
 		for _, transfer := range tx.NativeTransfers {
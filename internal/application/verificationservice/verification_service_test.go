@@ -0,0 +1,45 @@
+package verificationservice
+
+import (
+	"testing"
+
+	"github.com/tuncanbit/tvs/internal/domain"
+)
+
+func TestWithdrawalSettlement(t *testing.T) {
+	tests := []struct {
+		name         string
+		released     bool
+		available    int64
+		networkFee   int64
+		wantReserved int64
+		wantCharged  int64
+		wantFee      int64
+		wantOK       bool
+	}{
+		// 10,000 reserved for amount and fee.
+		{name: "full balance withdrawn", available: 0, wantReserved: 10_000, wantOK: true},
+		{name: "network fee charged", available: 500, networkFee: 120, wantReserved: 10_000, wantCharged: 120, wantFee: 120, wantOK: true},
+		{name: "network fee capped", available: 50, networkFee: 120, wantReserved: 10_000, wantCharged: 50, wantFee: 50, wantOK: true},
+		{name: "network fee with nothing available", available: 0, networkFee: 120, wantReserved: 10_000, wantOK: true},
+		{name: "released reservation", released: true, available: 12_000, networkFee: 120, wantCharged: 10_120, wantFee: 120, wantOK: true},
+		{name: "released reservation, fee capped", released: true, available: 10_050, networkFee: 120, wantCharged: 10_050, wantFee: 50, wantOK: true},
+		{name: "released reservation not covered", released: true, available: 9_999},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withdrawal := domain.Withdrawal{AmountReservedCents: 10_000, ReservationReleased: tt.released}
+			reserved, charged, fee, ok := withdrawalSettlement(withdrawal, tt.available, tt.networkFee)
+			if ok != tt.wantOK {
+				t.Fatalf("withdrawalSettlement() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if reserved != tt.wantReserved || charged != tt.wantCharged || fee != tt.wantFee {
+				t.Errorf("withdrawalSettlement() = %d reserved, %d charged, %d fee; want %d, %d, %d",
+					reserved, charged, fee, tt.wantReserved, tt.wantCharged, tt.wantFee)
+			}
+		})
+	}
+}
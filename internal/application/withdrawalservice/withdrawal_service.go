@@ -0,0 +1,38 @@
+package withdrawalservice
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tuncanbit/tvs/internal/domain"
+)
+
+var (
+	ErrInvalidIdempotencyKey = errors.New("idempotency key must be 1 to 128 characters")
+	ErrIdempotencyConflict   = errors.New("idempotency key was already used for a different withdrawal request")
+	ErrUnsupportedChain      = errors.New("withdrawals are not supported on this chain")
+	ErrUnsupportedCurrency   = errors.New("unsupported currency")
+	ErrInvalidAddress        = errors.New("invalid destination address")
+	ErrDestinationNotAllowed = errors.New("destination address is not allowed by the address book")
+	ErrAccountRestricted     = errors.New("withdrawals are not allowed for this account")
+	ErrTravelRuleRequired    = errors.New("travel rule information is required")
+	ErrInvalidTravelRule     = errors.New("invalid travel rule information")
+	ErrAmountTooSmall        = errors.New("withdrawal amount is too small")
+	ErrInsufficientBalance   = errors.New("insufficient balance")
+	ErrPriceUnavailable      = errors.New("no fresh price available to quote")
+	ErrWithdrawalNotFound    = errors.New("withdrawal not found")
+)
+
+type IWithdrawalService interface {
+	// CreateWithdrawal quotes the crypto amount for the request, reserves the
+	// amount plus fee from the user's balance together with inserting the
+	// withdrawal, and applies the withdrawal limits. Travel Rule information
+	// is checked against the threshold and stored sealed in the metadata.
+	// Repeating a request with the same idempotency key returns the original
+	// withdrawal and reports created as false.
+	CreateWithdrawal(ctx context.Context, userID string, req domain.CreateWithdrawalRequest) (withdrawal *domain.Withdrawal, created bool, err error)
+	// EstimateFees returns the platform and network fees a withdrawal would
+	// be charged, valued in the requested or the user's balance currency.
+	EstimateFees(ctx context.Context, userID string, req domain.WithdrawalFeeEstimateRequest) (*domain.WithdrawalFeeEstimate, error)
+	GetWithdrawal(ctx context.Context, userID, withdrawalID string) (*domain.Withdrawal, error)
+}
@@ -0,0 +1,445 @@
+package withdrawalservice
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/internal/application/accountpolicyservice"
+	"github.com/tuncanbit/tvs/internal/application/addressbookservice"
+	"github.com/tuncanbit/tvs/internal/application/conversionservice"
+	"github.com/tuncanbit/tvs/internal/application/feeservice"
+	"github.com/tuncanbit/tvs/internal/application/limitservice"
+	"github.com/tuncanbit/tvs/internal/application/pricingservice"
+	"github.com/tuncanbit/tvs/internal/application/travelruleservice"
+	"github.com/tuncanbit/tvs/internal/domain"
+	"github.com/tuncanbit/tvs/internal/infrastructure/rpc"
+	"github.com/tuncanbit/tvs/internal/repositories/balancerepo"
+	"github.com/tuncanbit/tvs/internal/repositories/userrepo"
+	"github.com/tuncanbit/tvs/internal/repositories/withdrawalrepo"
+	"github.com/tuncanbit/tvs/internal/server/websocket"
+	"github.com/tuncanbit/tvs/pkg/address"
+	"github.com/tuncanbit/tvs/pkg/config"
+	"github.com/tuncanbit/tvs/pkg/currency"
+	"github.com/tuncanbit/tvs/pkg/metrics"
+)
+
+const maxIdempotencyKeyLength = 128
+
+type withdrawalService struct {
+	withdrawalRepo withdrawalrepo.IWithdrawalRepository
+	balanceRepo    balancerepo.IBalanceRepository
+	userRepo       userrepo.IUserRepository
+	pricingSvc     pricingservice.IPricingService
+	conversionSvc  conversionservice.IConversionService
+	feeSvc         feeservice.IFeeService
+	limitSvc       limitservice.ILimitService
+	policySvc      accountpolicyservice.IAccountPolicyService
+	addressBookSvc addressbookservice.IAddressBookService
+	travelRuleSvc  travelruleservice.ITravelRuleService
+	heliusClient   *rpc.HeliusClient
+	wsHub          *websocket.WsHub
+	config         config.WithdrawalsConfig
+	currencyUtils  *currency.CurrencyUtils
+	logger         zerolog.Logger
+}
+
+func New(
+	withdrawalRepo withdrawalrepo.IWithdrawalRepository,
+	balanceRepo balancerepo.IBalanceRepository,
+	userRepo userrepo.IUserRepository,
+	pricingSvc pricingservice.IPricingService,
+	conversionSvc conversionservice.IConversionService,
+	feeSvc feeservice.IFeeService,
+	limitSvc limitservice.ILimitService,
+	policySvc accountpolicyservice.IAccountPolicyService,
+	addressBookSvc addressbookservice.IAddressBookService,
+	travelRuleSvc travelruleservice.ITravelRuleService,
+	heliusClient *rpc.HeliusClient,
+	wsHub *websocket.WsHub,
+	cfg config.WithdrawalsConfig,
+	logger zerolog.Logger,
+) IWithdrawalService {
+	return &withdrawalService{
+		withdrawalRepo: withdrawalRepo,
+		balanceRepo:    balanceRepo,
+		userRepo:       userRepo,
+		pricingSvc:     pricingSvc,
+		conversionSvc:  conversionSvc,
+		feeSvc:         feeSvc,
+		limitSvc:       limitSvc,
+		policySvc:      policySvc,
+		addressBookSvc: addressBookSvc,
+		travelRuleSvc:  travelRuleSvc,
+		heliusClient:   heliusClient,
+		wsHub:          wsHub,
+		config:         cfg,
+		currencyUtils:  currency.NewCurrencyUtils(),
+		logger:         logger.With().Str("component", "withdrawal_service").Logger(),
+	}
+}
+
+func (s *withdrawalService) CreateWithdrawal(ctx context.Context, userID string, req domain.CreateWithdrawalRequest) (*domain.Withdrawal, bool, error) {
+	if req.IdempotencyKey == "" || len(req.IdempotencyKey) > maxIdempotencyKeyLength {
+		return nil, false, ErrInvalidIdempotencyKey
+	}
+
+	fiatCurrency, err := s.fiatCurrencyFor(ctx, userID, req.FiatCurrency)
+	if err != nil {
+		return nil, false, err
+	}
+	amountCents := s.currencyUtils.MajorToMinorUnits(req.Amount, fiatCurrency)
+	if amountCents <= 0 {
+		return nil, false, ErrAmountTooSmall
+	}
+
+	withdrawalID := withdrawalIDFor(userID, req.IdempotencyKey)
+	requestHash := requestHashOf(req, fiatCurrency, amountCents)
+	if existing, err := s.replay(ctx, withdrawalID, requestHash); existing != nil || err != nil {
+		return existing, false, err
+	}
+
+	// Restrictions that only hold the withdrawal are applied by the limit
+	// check, which sends it to admin review.
+	restriction, err := s.policySvc.CheckWithdrawal(ctx, userID)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to check account policy: %w", err)
+	}
+	if restriction != nil && restriction.Action == domain.RestrictionBlock {
+		return nil, false, fmt.Errorf("%w: %s (%s)", ErrAccountRestricted, restriction.Message, restriction.Code)
+	}
+
+	clusterType, err := rpc.ClusterTypeForChain(req.ChainID)
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: %s", ErrUnsupportedChain, req.ChainID)
+	}
+	tokenType, err := rpc.TokenTypeForCrypto(req.CryptoCurrency)
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, req.CryptoCurrency)
+	}
+	toAddress, err := address.Validate(req.ChainID, req.ToAddress)
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: %v", ErrInvalidAddress, err)
+	}
+	addressBookEntry, err := s.addressBookSvc.CheckDestination(ctx, userID, req.ChainID, toAddress)
+	switch {
+	case errors.Is(err, addressbookservice.ErrAddressNotAllowlisted),
+		errors.Is(err, addressbookservice.ErrAddressCoolingDown):
+		return nil, false, fmt.Errorf("%w: %v", ErrDestinationNotAllowed, err)
+	case err != nil:
+		return nil, false, fmt.Errorf("failed to check address book: %w", err)
+	}
+	sourceWallet := s.config.HotWallets[req.ChainID]
+	if sourceWallet == "" {
+		return nil, false, fmt.Errorf("%w: no source wallet configured for %s", ErrUnsupportedChain, req.ChainID)
+	}
+	decimals, err := s.heliusClient.GetDecimals(clusterType, tokenType)
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: %v", ErrUnsupportedChain, err)
+	}
+	var destination *domain.TokenDestination
+	if tokenType != domain.SPLTokenTypeSOL {
+		if destination, err = s.checkTokenDestination(ctx, clusterType, tokenType, toAddress); err != nil {
+			return nil, false, err
+		}
+	}
+
+	rate, err := s.pricingSvc.GetExchangeRate(ctx, req.CryptoCurrency, fiatCurrency)
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: %v", ErrPriceUnavailable, err)
+	}
+	if rate.Stale || rate.Rate <= 0 {
+		return nil, false, ErrPriceUnavailable
+	}
+
+	scale := math.Pow(10, float64(decimals))
+	cryptoAmount := math.Floor(s.currencyUtils.MinorUnitsToMajor(amountCents, fiatCurrency)/rate.Rate*scale) / scale
+	if cryptoAmount <= 0 {
+		return nil, false, ErrAmountTooSmall
+	}
+
+	// The user is debited the amount asked for while the crypto sent is
+	// floored to the token's decimals; what the floor left over is this
+	// conversion's remainder.
+	debited := s.conversionSvc.Convert(ctx, cryptoAmount, rate.Rate, fiatCurrency)
+	debited.Converted = amountCents
+	debited.Remainder = debited.Exact - float64(amountCents)
+	var usdConversion *domain.Conversion
+	usdCents := amountCents
+	if fiatCurrency != "USD" {
+		conversion := s.conversionSvc.Convert(ctx, cryptoAmount, rate.PriceUSD, "USD")
+		usdConversion = &conversion
+		usdCents = conversion.Converted
+	}
+	travelRule, err := s.travelRuleSvc.Capture(ctx, userID, withdrawalID, toAddress, usdCents, req.TravelRule)
+	switch {
+	case errors.Is(err, travelruleservice.ErrTravelRuleRequired),
+		errors.Is(err, travelruleservice.ErrOriginatorIncomplete):
+		return nil, false, fmt.Errorf("%w: %v", ErrTravelRuleRequired, err)
+	case errors.Is(err, travelruleservice.ErrInvalidTravelRule):
+		return nil, false, fmt.Errorf("%w: %v", ErrInvalidTravelRule, err)
+	case err != nil:
+		return nil, false, fmt.Errorf("failed to capture travel rule information: %w", err)
+	}
+
+	feeCents := s.feeCents(amountCents, fiatCurrency, rate)
+	withdrawalMetadata := domain.WithdrawalMetadata{
+		FiatCurrency:    fiatCurrency,
+		FiatAmountCents: amountCents,
+		Origin:          domain.WithdrawalOriginAPI,
+		IdempotencyKey:  req.IdempotencyKey,
+		RequestHash:     requestHash,
+		Destination:     destination,
+		TravelRule:      travelRule,
+	}
+	if addressBookEntry != nil {
+		withdrawalMetadata.AddressBookID = addressBookEntry.ID
+	}
+	metadata, err := json.Marshal(withdrawalMetadata)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to marshal withdrawal metadata: %w", err)
+	}
+
+	withdrawal, err := s.withdrawalRepo.CreateWithReservation(ctx, domain.Withdrawal{
+		UserID:              userID,
+		WithdrawalID:        withdrawalID,
+		ChainID:             req.ChainID,
+		Network:             string(clusterType),
+		CryptoCurrency:      req.CryptoCurrency,
+		USDAmountCents:      usdCents,
+		CryptoAmount:        strconv.FormatFloat(cryptoAmount, 'f', decimals, 64),
+		ExchangeRate:        strconv.FormatFloat(rate.Rate, 'f', 6, 64),
+		FeeCents:            feeCents,
+		ToAddress:           toAddress,
+		SourceWalletAddress: sourceWallet,
+		AmountReservedCents: amountCents + feeCents,
+		Metadata:            metadata,
+	}, fiatCurrency)
+	switch {
+	case errors.Is(err, withdrawalrepo.ErrInsufficientBalance):
+		return nil, false, fmt.Errorf("%w: %s needed", ErrInsufficientBalance, s.currencyUtils.Format(amountCents+feeCents, fiatCurrency))
+	case errors.Is(err, withdrawalrepo.ErrWithdrawalExists):
+		// A concurrent request with the same key won the insert.
+		existing, replayErr := s.replay(ctx, withdrawalID, requestHash)
+		if replayErr != nil {
+			return nil, false, replayErr
+		}
+		if existing == nil {
+			return nil, false, err
+		}
+		return existing, false, nil
+	case err != nil:
+		return nil, false, err
+	}
+
+	if err := s.balanceRepo.LogBalanceChange(ctx, &domain.BalanceLog{
+		ID:           uuid.New().String(),
+		UserID:       userID,
+		Component:    "withdrawal",
+		CurrencyCode: fiatCurrency,
+		ChangeCents:  -withdrawal.AmountReservedCents,
+		ChangeUnits:  -s.currencyUtils.MinorUnitsToMajor(withdrawal.AmountReservedCents, fiatCurrency),
+		Description:  fmt.Sprintf("Reserved balance for withdrawal %s", withdrawal.WithdrawalID),
+		Timestamp:    time.Now(),
+	}); err != nil {
+		s.logger.Err(err).Msg("Failed to log balance reservation")
+	}
+	s.conversionSvc.RecordRemainder(ctx, withdrawal.ID, domain.ConversionTypeWithdrawal, debited)
+	if usdConversion != nil {
+		s.conversionSvc.RecordRemainder(ctx, withdrawal.ID, domain.ConversionTypeWithdrawal, *usdConversion)
+	}
+
+	if _, err := s.limitSvc.EnforceWithdrawalLimits(ctx, withdrawal); err != nil {
+		// The verifier evaluates pending withdrawals that have no limit check
+		// yet, so the withdrawal is not let through unchecked.
+		s.logger.Warn().
+			Str("withdrawal_id", withdrawal.WithdrawalID).
+			Err(err).
+			Msg("Failed to check withdrawal limits, deferring to verifier")
+	}
+
+	metrics.Add("withdrawals.created", 1)
+	s.wsHub.BroadcastWithdrawal(*withdrawal)
+	s.logger.Info().
+		Str("withdrawal_id", withdrawal.WithdrawalID).
+		Str("user_id", userID).
+		Str("amount", s.currencyUtils.Format(amountCents, fiatCurrency)).
+		Str("fee", s.currencyUtils.Format(feeCents, fiatCurrency)).
+		Str("crypto_amount", withdrawal.CryptoAmount).
+		Str("status", string(withdrawal.Status)).
+		Msg("Withdrawal created")
+	return withdrawal, true, nil
+}
+
+func (s *withdrawalService) EstimateFees(ctx context.Context, userID string, req domain.WithdrawalFeeEstimateRequest) (*domain.WithdrawalFeeEstimate, error) {
+	fiatCurrency, err := s.fiatCurrencyFor(ctx, userID, req.FiatCurrency)
+	if err != nil {
+		return nil, err
+	}
+	var amountCents int64
+	if req.Amount > 0 {
+		if amountCents = s.currencyUtils.MajorToMinorUnits(req.Amount, fiatCurrency); amountCents <= 0 {
+			return nil, ErrAmountTooSmall
+		}
+	}
+
+	networkFee, err := s.feeSvc.EstimateNetworkFee(ctx, req.ChainID, req.CryptoCurrency, req.ToAddress, fiatCurrency)
+	switch {
+	case errors.Is(err, feeservice.ErrUnsupportedChain):
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedChain, req.ChainID)
+	case errors.Is(err, feeservice.ErrUnsupportedCurrency):
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, req.CryptoCurrency)
+	case errors.Is(err, feeservice.ErrInvalidAddress):
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAddress, err)
+	case errors.Is(err, feeservice.ErrPriceUnavailable):
+		return nil, fmt.Errorf("%w: %v", ErrPriceUnavailable, err)
+	case err != nil:
+		return nil, err
+	}
+
+	// The minimum platform fee is converted with the FX rate of the fiat
+	// currency, which any crypto rate into it carries.
+	rate, err := s.pricingSvc.GetExchangeRate(ctx, networkFee.Currency, fiatCurrency)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrPriceUnavailable, err)
+	}
+	if rate.Stale || rate.Rate <= 0 {
+		return nil, ErrPriceUnavailable
+	}
+
+	estimate := &domain.WithdrawalFeeEstimate{
+		FiatCurrency:     fiatCurrency,
+		AmountCents:      amountCents,
+		PlatformFeeCents: s.feeCents(amountCents, fiatCurrency, rate),
+		NetworkFee:       *networkFee,
+	}
+	estimate.TotalFeeCents = estimate.PlatformFeeCents
+	if networkFee.PaidBy == domain.NetworkFeePaidByUser {
+		estimate.TotalFeeCents += networkFee.FiatCents
+	}
+	metrics.Add("withdrawals.fee_estimates", 1)
+	return estimate, nil
+}
+
+func (s *withdrawalService) GetWithdrawal(ctx context.Context, userID, withdrawalID string) (*domain.Withdrawal, error) {
+	withdrawal, err := s.withdrawalRepo.GetByWithdrawalID(ctx, withdrawalID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrWithdrawalNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if withdrawal.UserID != userID {
+		return nil, ErrWithdrawalNotFound
+	}
+	return withdrawal, nil
+}
+
+// replay returns the withdrawal an earlier request with the same idempotency
+// key created, or nil if there is none. Reusing a key for a different request
+// is a conflict rather than a replay.
+func (s *withdrawalService) replay(ctx context.Context, withdrawalID, requestHash string) (*domain.Withdrawal, error) {
+	existing, err := s.withdrawalRepo.GetByWithdrawalID(ctx, withdrawalID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata domain.WithdrawalMetadata
+	if len(existing.Metadata) > 0 {
+		_ = json.Unmarshal(existing.Metadata, &metadata)
+	}
+	if metadata.RequestHash != requestHash {
+		return nil, ErrIdempotencyConflict
+	}
+	metrics.Add("withdrawals.idempotent_replays", 1)
+	return existing, nil
+}
+
+// checkTokenDestination rejects SPL withdrawals to addresses that cannot
+// receive the token, such as programs or token accounts of another mint.
+// When the node cannot be reached and the executor is enabled, the
+// withdrawal is accepted without a recorded destination since the executor
+// checks again before paying out. Without the executor nothing would, so
+// the withdrawal is refused.
+func (s *withdrawalService) checkTokenDestination(ctx context.Context, clusterType domain.SolanaClusterType, tokenType domain.SPLTokenType, address string) (*domain.TokenDestination, error) {
+	destination, err := s.heliusClient.InspectTokenDestination(ctx, clusterType, tokenType, address)
+	switch {
+	case errors.Is(err, rpc.ErrInvalidDestination),
+		errors.Is(err, rpc.ErrDestinationNotWallet),
+		errors.Is(err, rpc.ErrDestinationMintMismatch),
+		errors.Is(err, rpc.ErrDestinationFrozen):
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAddress, err)
+	case errors.Is(err, rpc.ErrUnsupportedTokenProgram):
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedCurrency, err)
+	case err != nil && !s.config.Executor.Enabled:
+		return nil, fmt.Errorf("failed to check withdrawal destination: %w", err)
+	case err != nil:
+		s.logger.Warn().
+			Err(err).
+			Str("to_address", address).
+			Msg("Failed to check withdrawal destination, deferring to executor")
+		return nil, nil
+	}
+	return destination, nil
+}
+
+// fiatCurrencyFor returns the currency a withdrawal is denominated in: the
+// requested one, else the user's balance currency, else USD.
+func (s *withdrawalService) fiatCurrencyFor(ctx context.Context, userID, requested string) (string, error) {
+	fiatCurrency := requested
+	if fiatCurrency == "" {
+		var err error
+		fiatCurrency, err = s.userRepo.GetDefaultCurrency(ctx, userID)
+		if err != nil || fiatCurrency == "" {
+			fiatCurrency = "USD"
+		}
+	}
+	if !s.pricingSvc.SupportsFiat(fiatCurrency) {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedCurrency, fiatCurrency)
+	}
+	return fiatCurrency, nil
+}
+
+// feeCents is the platform fee on a withdrawal of amountCents: FeeBps of the
+// amount, rounded up, but no less than MinFeeUSDCents in the withdrawal
+// currency.
+func (s *withdrawalService) feeCents(amountCents int64, fiatCurrency string, rate *domain.ExchangeRateResponse) int64 {
+	fee := (amountCents*s.config.FeeBps + 9999) / 10000
+
+	minFee := s.config.MinFeeUSDCents
+	if minFee > 0 && fiatCurrency != "USD" {
+		if rate.FXRate <= 0 {
+			minFee = 0
+		} else {
+			minFee = s.currencyUtils.MajorToMinorUnits(s.currencyUtils.MinorUnitsToMajor(minFee, "USD")*rate.FXRate, fiatCurrency)
+		}
+	}
+	if fee < minFee {
+		fee = minFee
+	}
+	return fee
+}
+
+// withdrawalIDFor derives the withdrawal ID from the user and idempotency key,
+// so the unique withdrawal_id constraint also enforces idempotency.
+func withdrawalIDFor(userID, idempotencyKey string) string {
+	sum := sha256.Sum256([]byte(userID + ":" + idempotencyKey))
+	return "wd_" + hex.EncodeToString(sum[:])[:32]
+}
+
+func requestHashOf(req domain.CreateWithdrawalRequest, fiatCurrency string, amountCents int64) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%s|%d|%s", req.ChainID, req.CryptoCurrency, fiatCurrency, amountCents, req.ToAddress)))
+	return hex.EncodeToString(sum[:])
+}
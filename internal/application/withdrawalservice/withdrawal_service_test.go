@@ -0,0 +1,70 @@
+package withdrawalservice
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/internal/domain"
+	"github.com/tuncanbit/tvs/internal/infrastructure/rpc"
+	"github.com/tuncanbit/tvs/pkg/circuitbreaker"
+	"github.com/tuncanbit/tvs/pkg/config"
+	"github.com/tuncanbit/tvs/pkg/ratelimit"
+)
+
+// unreachableNode returns a Helius client whose node answers every call
+// with a JSON-RPC error.
+func unreachableNode(t *testing.T) *rpc.HeliusClient {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32005,"message":"node is behind"}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := &config.Config{
+		Helius: config.HeliusConfig{
+			RPCURLs: map[string]string{string(domain.SolanaClusterTypeDevnet): server.URL},
+			Timeout: 5 * time.Second,
+		},
+		MintAddresses: map[string]map[string]string{
+			string(domain.SolanaClusterTypeDevnet): {string(domain.SPLTokenTypeUSDC): "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"},
+		},
+	}
+	logger := zerolog.Nop()
+	return rpc.NewHeliusClient(cfg, ratelimit.NewRegistry(nil, logger), circuitbreaker.NewRegistry(nil, logger), logger)
+}
+
+func TestCheckTokenDestinationWhenNodeFails(t *testing.T) {
+	const wallet = "FVen3X669xLzsi6N2V91DoiyzHzg1uAgqiT8jZ9nS96Z"
+	heliusClient := unreachableNode(t)
+
+	tests := []struct {
+		name            string
+		executorEnabled bool
+		wantErr         bool
+	}{
+		// The executor inspects the destination again before paying out.
+		{"executor enabled", true, false},
+		// Nothing would check the destination, so the withdrawal is refused.
+		{"executor disabled", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &withdrawalService{
+				heliusClient: heliusClient,
+				config:       config.WithdrawalsConfig{Executor: config.ExecutorConfig{Enabled: tt.executorEnabled}},
+				logger:       zerolog.Nop(),
+			}
+			destination, err := s.checkTokenDestination(context.Background(), domain.SolanaClusterTypeDevnet, domain.SPLTokenTypeUSDC, wallet)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkTokenDestination() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if destination != nil {
+				t.Errorf("checkTokenDestination() = %+v, want no destination", destination)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package domain
+
+// KYC statuses of users.kyc_status.
+const (
+	KYCStatusPending  = "PENDING"
+	KYCStatusVerified = "VERIFIED"
+	KYCStatusRejected = "REJECTED"
+)
+
+// Account statuses of users.status.
+const (
+	AccountStatusActive    = "ACTIVE"
+	AccountStatusInactive  = "INACTIVE"
+	AccountStatusSuspended = "SUSPENDED"
+)
+
+type AccountStatus struct {
+	KYCStatus string `json:"kyc_status"`
+	Status    string `json:"status"`
+}
+
+// RestrictionCode says why a deposit or withdrawal is held or blocked. Codes
+// are shown to the user, so they never name a screening list.
+type RestrictionCode string
+
+const (
+	RestrictionComplianceReview RestrictionCode = "compliance_review"
+	RestrictionKYCPending       RestrictionCode = "kyc_pending"
+	RestrictionKYCRejected      RestrictionCode = "kyc_rejected"
+	RestrictionKYCDepositCap    RestrictionCode = "kyc_deposit_cap"
+	RestrictionAccountInactive  RestrictionCode = "account_inactive"
+	RestrictionAccountSuspended RestrictionCode = "account_suspended"
+	// Player protection codes. Deposits held under them are refunded rather
+	// than reviewed; see SessionStatusLimitHeld.
+	RestrictionDepositLimit RestrictionCode = "deposit_limit_exceeded"
+	RestrictionSelfExcluded RestrictionCode = "self_excluded"
+)
+
+// Refundable reports whether a deposit held under the code is kept for
+// refund instead of admin review.
+func (c RestrictionCode) Refundable() bool {
+	return c == RestrictionDepositLimit || c == RestrictionSelfExcluded
+}
+
+type RestrictionAction string
+
+const (
+	// RestrictionHold sends the item to admin review.
+	RestrictionHold RestrictionAction = "hold"
+	// RestrictionBlock refuses new withdrawals outright and keeps approved
+	// ones from being paid out.
+	RestrictionBlock RestrictionAction = "block"
+)
+
+// AccountRestriction is the account policy's decision on a deposit or
+// withdrawal that may not go ahead as usual.
+type AccountRestriction struct {
+	Code    RestrictionCode   `json:"code"`
+	Action  RestrictionAction `json:"action"`
+	Message string            `json:"message"`
+}
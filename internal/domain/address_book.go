@@ -0,0 +1,76 @@
+package domain
+
+import "time"
+
+// WithdrawalAddress is an entry in a user's withdrawal address book. It can
+// be withdrawn to from AvailableAt, the end of the cool-down started when it
+// was added.
+type WithdrawalAddress struct {
+	ID          string    `json:"id"`
+	UserID      string    `json:"user_id"`
+	ChainID     string    `json:"chain_id"`
+	Address     string    `json:"address"`
+	Label       string    `json:"label"`
+	AvailableAt time.Time `json:"available_at"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Available reports whether the address's cool-down has passed at now.
+func (a WithdrawalAddress) Available(now time.Time) bool {
+	return !now.Before(a.AvailableAt)
+}
+
+// AddressBookSettings holds a user's address book mode. Under strict mode
+// withdrawals may only go to available address book entries. Turning strict
+// mode off is delayed by a cool-down: StrictModeEndsAt is when it lapses.
+type AddressBookSettings struct {
+	UserID           string     `json:"user_id"`
+	StrictMode       bool       `json:"strict_mode"`
+	StrictModeEndsAt *time.Time `json:"strict_mode_ends_at,omitempty"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// Strict reports whether strict mode is in force at now.
+func (s AddressBookSettings) Strict(now time.Time) bool {
+	return s.StrictMode && (s.StrictModeEndsAt == nil || now.Before(*s.StrictModeEndsAt))
+}
+
+// AddressBook is a user's saved withdrawal addresses and settings.
+type AddressBook struct {
+	Settings  AddressBookSettings `json:"settings"`
+	Addresses []WithdrawalAddress `json:"addresses"`
+}
+
+type AddressBookAction string
+
+const (
+	AddressBookActionAdded           AddressBookAction = "address_added"
+	AddressBookActionLabelUpdated    AddressBookAction = "address_label_updated"
+	AddressBookActionRemoved         AddressBookAction = "address_removed"
+	AddressBookActionSettingsUpdated AddressBookAction = "settings_updated"
+)
+
+// AddressBookEvent is pushed to the user's WebSocket connections when their
+// address book changes. Address is set for entry changes and Settings for
+// mode changes.
+type AddressBookEvent struct {
+	UserID   string               `json:"user_id"`
+	Action   AddressBookAction    `json:"action"`
+	Address  *WithdrawalAddress   `json:"address,omitempty"`
+	Settings *AddressBookSettings `json:"settings,omitempty"`
+}
+
+type AddWithdrawalAddressRequest struct {
+	ChainID string `json:"chain_id" binding:"required"`
+	Address string `json:"address" binding:"required"`
+	Label   string `json:"label" binding:"max=100"`
+}
+
+type UpdateWithdrawalAddressRequest struct {
+	Label string `json:"label" binding:"max=100"`
+}
+
+type AddressBookSettingsRequest struct {
+	StrictMode *bool `json:"strict_mode" binding:"required"`
+}
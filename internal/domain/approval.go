@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// WithdrawalApproval is one admin's active approval of a withdrawal that
+// needs a quorum of distinct admins.
+type WithdrawalApproval struct {
+	ID           string    `json:"id"`
+	WithdrawalID string    `json:"withdrawal_id"`
+	AdminID      string    `json:"admin_id"`
+	Note         string    `json:"note,omitempty"`
+	IPAddress    string    `json:"ip_address,omitempty"`
+	UserAgent    string    `json:"user_agent,omitempty"`
+	ApprovedAt   time.Time `json:"approved_at"`
+}
+
+// ApprovalStatus reports where a withdrawal stands against its approval
+// quorum after an approval is recorded or revoked.
+type ApprovalStatus struct {
+	Withdrawal        *Withdrawal          `json:"withdrawal"`
+	Approvals         []WithdrawalApproval `json:"approvals"`
+	RequiredApprovals int                  `json:"required_approvals"`
+	QuorumReached     bool                 `json:"quorum_reached"`
+}
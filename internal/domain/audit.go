@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditLog is an entry in audit_logs. AdminID is empty for actions taken by
+// the system itself, such as automatic rejections.
+type AuditLog struct {
+	ID         string          `json:"id"`
+	Action     string          `json:"action"`
+	EntityType string          `json:"entity_type"`
+	EntityID   string          `json:"entity_id"`
+	AdminID    string          `json:"admin_id,omitempty"`
+	OldValues  json.RawMessage `json:"old_values,omitempty"`
+	NewValues  json.RawMessage `json:"new_values,omitempty"`
+	IPAddress  string          `json:"ip_address,omitempty"`
+	UserAgent  string          `json:"user_agent,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// RequestInfo identifies where an admin action came from, for auditing.
+type RequestInfo struct {
+	IPAddress string
+	UserAgent string
+}
@@ -7,6 +7,12 @@ import (
 	"github.com/google/uuid"
 )
 
+const (
+	UserTypePlayer    = "PLAYER"
+	UserTypeAdmin     = "ADMIN"
+	UserTypeAffiliate = "AFFILIATE"
+)
+
 type User struct {
 	ID          uuid.UUID `json:"id"`
 	Username    string    `json:"username"`
@@ -19,6 +25,18 @@ type User struct {
 	UpdatedAt   time.Time `json:"updated_at"`
 }
 
+// UserProfile is a user's name and home address as held on their account.
+type UserProfile struct {
+	UserID        string `json:"user_id"`
+	FirstName     string `json:"first_name"`
+	LastName      string `json:"last_name"`
+	StreetAddress string `json:"street_address,omitempty"`
+	City          string `json:"city,omitempty"`
+	State         string `json:"state,omitempty"`
+	PostalCode    string `json:"postal_code,omitempty"`
+	Country       string `json:"country,omitempty"`
+}
+
 type UserSession struct {
 	ID                    uuid.UUID `json:"id"`
 	UserID                uuid.UUID `json:"user_id"`
@@ -0,0 +1,35 @@
+package domain
+
+type ConversionType string
+
+const (
+	ConversionTypeDeposit    ConversionType = "deposit"
+	ConversionTypeWithdrawal ConversionType = "withdrawal"
+	ConversionTypeExchange   ConversionType = "exchange"
+)
+
+// ConversionSettings mirrors system_config.conversion_settings.
+type ConversionSettings struct {
+	RoundingMethod        string `json:"rounding_method"` // bankers, half_up or floor
+	IntermediatePrecision int    `json:"intermediate_precision"`
+}
+
+// Conversion is the result of converting a crypto amount into the minor
+// units of a fiat currency. Remainder is Exact minus Converted, both in minor
+// units.
+type Conversion struct {
+	CurrencyCode string  `json:"currency_code"`
+	Exact        float64 `json:"exact"`
+	Converted    int64   `json:"converted"`
+	Remainder    float64 `json:"remainder"`
+}
+
+// RoundingDrift is the cumulative rounding remainder, in minor units, for one
+// currency and conversion type.
+type RoundingDrift struct {
+	CurrencyCode      string         `json:"currency_code"`
+	ConversionType    ConversionType `json:"conversion_type"`
+	Conversions       int64          `json:"conversions"`
+	NetRemainder      float64        `json:"net_remainder"`
+	AbsoluteRemainder float64        `json:"absolute_remainder"`
+}
@@ -0,0 +1,10 @@
+package domain
+
+// FeeHistory is the result of eth_feeHistory with quantities decoded to wei
+// per gas. Reward holds one entry per block, one value per requested
+// percentile.
+type FeeHistory struct {
+	OldestBlock   uint64
+	BaseFeePerGas []uint64
+	Reward        [][]uint64
+}
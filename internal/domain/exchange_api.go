@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 type CoinCapResponse struct {
 	Data      []CoinCapAsset `json:"data"`
 	Timestamp int64          `json:"timestamp"`
@@ -20,10 +22,42 @@ type CoinCapAsset struct {
 }
 
 type ExchangeRateResponse struct {
-	CryptoCurrency string  `json:"crypto_currency"`
-	FiatCurrency   string  `json:"fiat_currency"`
-	Rate           float64 `json:"rate"`
-	LastUpdated    string  `json:"last_updated"`
-	PriceUSD       float64 `json:"price_usd"`
-	Change24Hr     float64 `json:"change_24hr"`
+	CryptoCurrency string             `json:"crypto_currency"`
+	FiatCurrency   string             `json:"fiat_currency"`
+	Rate           float64            `json:"rate"`
+	LastUpdated    string             `json:"last_updated"`
+	PriceUSD       float64            `json:"price_usd"`
+	FXRate         float64            `json:"fx_rate,omitempty"` // USD -> FiatCurrency, set when FiatCurrency is not USD
+	Change24Hr     float64            `json:"change_24hr"`
+	FetchedAt      time.Time          `json:"fetched_at"`
+	Age            time.Duration      `json:"age"`
+	Stale          bool               `json:"stale"`
+	PricedAt       time.Time          `json:"priced_at"`
+	Policy         PricingPolicy      `json:"policy,omitempty"`
+	Method         string             `json:"method,omitempty"`
+	Sources        []PriceSourceQuote `json:"sources,omitempty"`
+}
+
+// PriceSourceQuote is one source's contribution to an aggregated price.
+type PriceSourceQuote struct {
+	Source   string  `json:"source"`
+	Price    float64 `json:"price,omitempty"`
+	Included bool    `json:"included"`
+	Error    string  `json:"error,omitempty"`
 }
+
+type ExchangeRate struct {
+	FromCurrency string    `json:"from_currency"`
+	ToCurrency   string    `json:"to_currency"`
+	Rate         float64   `json:"rate"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// PricingPolicy selects which moment a deposit is valued at.
+type PricingPolicy string
+
+const (
+	PricingPolicyOnChainTime      PricingPolicy = "onchain_time"
+	PricingPolicySessionTime      PricingPolicy = "session_created"
+	PricingPolicyVerificationTime PricingPolicy = "verification_time"
+)
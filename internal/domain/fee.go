@@ -0,0 +1,75 @@
+package domain
+
+import "time"
+
+// NetworkFeePaidBy says who bears a transaction's on-chain fee: the user,
+// whose balance is charged for it, or the house, which absorbs it.
+type NetworkFeePaidBy string
+
+const (
+	NetworkFeePaidByUser  NetworkFeePaidBy = "user"
+	NetworkFeePaidByHouse NetworkFeePaidBy = "house"
+)
+
+// NetworkFee is the on-chain fee of a deposit or withdrawal transaction as
+// posted to the network fee account. BaseUnits is exact; Amount is the same
+// fee in the native asset's major unit. USDCents values it at the time the
+// transaction was processed, and ChargedCents is what was taken from the
+// user's balance in ChargedCurrency.
+type NetworkFee struct {
+	ChainID          string           `json:"chain_id"`
+	TxHash           string           `json:"tx_hash"`
+	TransactionType  TransactionType  `json:"transaction_type"`
+	WithdrawalID     string           `json:"withdrawal_id,omitempty"`
+	DepositSessionID string           `json:"deposit_session_id,omitempty"`
+	Currency         string           `json:"currency"`
+	BaseUnits        int64            `json:"base_units"`
+	Amount           string           `json:"amount"`
+	Payer            string           `json:"payer"`
+	PaidBy           NetworkFeePaidBy `json:"paid_by"`
+	PriceUSD         float64          `json:"price_usd"`
+	USDCents         int64            `json:"usd_cents"`
+	ChargedCurrency  string           `json:"charged_currency,omitempty"`
+	ChargedCents     int64            `json:"charged_cents"`
+	PricedAt         time.Time        `json:"priced_at"`
+	Conversions      []Conversion     `json:"-"` // USD valuation, then the charge when there is one
+}
+
+// NetworkFeeSummary totals the network fees of one chain, transaction type
+// and payer over a reporting window.
+type NetworkFeeSummary struct {
+	ChainID         string           `json:"chain_id"`
+	Currency        string           `json:"currency"`
+	TransactionType TransactionType  `json:"transaction_type"`
+	PaidBy          NetworkFeePaidBy `json:"paid_by"`
+	ChargedCurrency string           `json:"charged_currency,omitempty"`
+	Transactions    int64            `json:"transactions"`
+	BaseUnits       int64            `json:"base_units"`
+	USDCents        int64            `json:"usd_cents"`
+	ChargedCents    int64            `json:"charged_cents"`
+}
+
+// NetworkFeeEstimate is the expected on-chain cost of a withdrawal
+// transaction. BaseUnits covers the base and priority fee; PriorityFee is the
+// per-unit price it assumes, in micro-lamports per compute unit on Solana and
+// wei per gas on EVM chains. Destination describes where an SPL payout lands;
+// when the destination has no token account yet, the payout must create one
+// and TokenAccountRent is the rent deposit that takes, paid by the hot
+// wallet. Fiat values are in FiatCurrency minor units.
+type NetworkFeeEstimate struct {
+	ChainID               string            `json:"chain_id"`
+	CryptoCurrency        string            `json:"crypto_currency"`
+	Currency              string            `json:"currency"`
+	BaseUnits             int64             `json:"base_units"`
+	Amount                string            `json:"amount"`
+	PriorityFee           uint64            `json:"priority_fee"`
+	PaidBy                NetworkFeePaidBy  `json:"paid_by"`
+	Destination           *TokenDestination `json:"destination,omitempty"`
+	RequiresTokenAccount  bool              `json:"requires_token_account"`
+	TokenAccountRent      string            `json:"token_account_rent,omitempty"`
+	FiatCurrency          string            `json:"fiat_currency"`
+	FiatCents             int64             `json:"fiat_cents"`
+	TokenAccountRentCents int64             `json:"token_account_rent_cents,omitempty"`
+	EstimatedAt           time.Time         `json:"estimated_at"`
+	ExpiresAt             time.Time         `json:"expires_at"`
+}
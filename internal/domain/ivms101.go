@@ -0,0 +1,90 @@
+package domain
+
+// IVMS101Payload is an IVMS101 IdentityPayload: the originator, beneficiary
+// and the VASPs on either side of a transfer. Only the elements this service
+// has data for are modelled.
+type IVMS101Payload struct {
+	Originator      IVMS101Originator       `json:"originator"`
+	Beneficiary     IVMS101Beneficiary      `json:"beneficiary"`
+	OriginatingVASP *IVMS101OriginatingVASP `json:"originatingVASP,omitempty"`
+	BeneficiaryVASP *IVMS101BeneficiaryVASP `json:"beneficiaryVASP,omitempty"`
+}
+
+type IVMS101Originator struct {
+	OriginatorPersons []IVMS101Person `json:"originatorPersons"`
+	AccountNumber     []string        `json:"accountNumber,omitempty"`
+}
+
+type IVMS101Beneficiary struct {
+	BeneficiaryPersons []IVMS101Person `json:"beneficiaryPersons"`
+	AccountNumber      []string        `json:"accountNumber,omitempty"`
+}
+
+type IVMS101OriginatingVASP struct {
+	OriginatingVASP IVMS101Person `json:"originatingVASP"`
+}
+
+type IVMS101BeneficiaryVASP struct {
+	BeneficiaryVASP IVMS101Person `json:"beneficiaryVASP"`
+}
+
+// IVMS101Person is either a natural or a legal person.
+type IVMS101Person struct {
+	NaturalPerson *IVMS101NaturalPerson `json:"naturalPerson,omitempty"`
+	LegalPerson   *IVMS101LegalPerson   `json:"legalPerson,omitempty"`
+}
+
+type IVMS101NaturalPerson struct {
+	Name                   IVMS101NaturalPersonName `json:"name"`
+	GeographicAddress      []IVMS101Address         `json:"geographicAddress,omitempty"`
+	CustomerIdentification string                   `json:"customerIdentification,omitempty"`
+	CountryOfResidence     string                   `json:"countryOfResidence,omitempty"`
+}
+
+type IVMS101NaturalPersonName struct {
+	NameIdentifier []IVMS101NaturalPersonNameID `json:"nameIdentifier"`
+}
+
+// IVMS101NaturalPersonNameID is a name: the primary identifier is the family
+// name, or the full name when it cannot be split.
+type IVMS101NaturalPersonNameID struct {
+	PrimaryIdentifier   string `json:"primaryIdentifier"`
+	SecondaryIdentifier string `json:"secondaryIdentifier,omitempty"`
+	NameIdentifierType  string `json:"nameIdentifierType"`
+}
+
+type IVMS101LegalPerson struct {
+	Name                   IVMS101LegalPersonName         `json:"name"`
+	NationalIdentification *IVMS101NationalIdentification `json:"nationalIdentification,omitempty"`
+	CountryOfRegistration  string                         `json:"countryOfRegistration,omitempty"`
+}
+
+type IVMS101LegalPersonName struct {
+	NameIdentifier []IVMS101LegalPersonNameID `json:"nameIdentifier"`
+}
+
+type IVMS101LegalPersonNameID struct {
+	LegalPersonName               string `json:"legalPersonName"`
+	LegalPersonNameIdentifierType string `json:"legalPersonNameIdentifierType"`
+}
+
+type IVMS101NationalIdentification struct {
+	NationalIdentifier     string `json:"nationalIdentifier"`
+	NationalIdentifierType string `json:"nationalIdentifierType"`
+}
+
+type IVMS101Address struct {
+	AddressType        string   `json:"addressType"`
+	AddressLine        []string `json:"addressLine,omitempty"`
+	PostCode           string   `json:"postCode,omitempty"`
+	TownName           string   `json:"townName,omitempty"`
+	CountrySubDivision string   `json:"countrySubDivision,omitempty"`
+	Country            string   `json:"country"`
+}
+
+// IVMS101 code values used in exports.
+const (
+	IVMS101NameTypeLegal     = "LEGL"
+	IVMS101AddressTypeHome   = "HOME"
+	IVMS101IdentifierTypeLEI = "LEIX"
+)
@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+// WithdrawalThreshold is the withdrawal_threshold system_config value.
+type WithdrawalThreshold struct {
+	USDAmountCents int64 `json:"usd_amount_cents"`
+	AutoApprove    bool  `json:"auto_approve"`
+}
+
+// WithdrawalLimits is the auto_withdrawal_limit system_config value. Each
+// window is a rolling per-user total; zero disables the window.
+type WithdrawalLimits struct {
+	DailyUSDCents   int64 `json:"daily_usd_cents"`
+	WeeklyUSDCents  int64 `json:"weekly_usd_cents"`
+	MonthlyUSDCents int64 `json:"monthly_usd_cents"`
+}
+
+// LimitCheck records the outcome of evaluating a withdrawal against the
+// threshold, velocity limits, screening lists and account policy. It is
+// stored in withdrawals.metadata; Screening is set when the destination was
+// listed and Restriction when the account policy holds the withdrawal.
+type LimitCheck struct {
+	USDAmountCents int64               `json:"usd_amount_cents"`
+	Approved       bool                `json:"approved"`
+	Reasons        []string            `json:"reasons,omitempty"`
+	Screening      *ScreeningMatch     `json:"screening,omitempty"`
+	Restriction    *AccountRestriction `json:"restriction,omitempty"`
+	CheckedAt      time.Time           `json:"checked_at"`
+}
@@ -10,6 +10,12 @@ const (
 	PDMWebhookEventTypeTxVerify PDMWebhookEventType = "pdm.txverify"
 )
 
+// Payload fields of PDM webhooks. Address fields hold addresses on the chain
+// named by PDMPayloadChainID.
+const PDMPayloadChainID = "chain_id"
+
+var PDMPayloadAddressFields = []string{"address", "wallet_address", "from_address", "to_address"}
+
 type PDMWebhookRequest struct {
 	EventType PDMWebhookEventType `json:"event_type"`
 	Payload   map[string]string   `json:"payload"`
@@ -0,0 +1,79 @@
+package domain
+
+import "time"
+
+type DepositLimitPeriod string
+
+const (
+	DepositLimitDaily   DepositLimitPeriod = "daily"
+	DepositLimitWeekly  DepositLimitPeriod = "weekly"
+	DepositLimitMonthly DepositLimitPeriod = "monthly"
+)
+
+// DepositLimitPeriods lists the periods in the order limits are checked.
+var DepositLimitPeriods = []DepositLimitPeriod{DepositLimitDaily, DepositLimitWeekly, DepositLimitMonthly}
+
+// Window is the rolling window a limit of the period applies to.
+func (p DepositLimitPeriod) Window() time.Duration {
+	switch p {
+	case DepositLimitWeekly:
+		return 7 * 24 * time.Hour
+	case DepositLimitMonthly:
+		return 30 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// DepositLimit caps the USD value of deposits a user may have credited in a
+// rolling window. Lowering a limit takes effect at once; raising or removing
+// it is staged as PendingLimitUSDCents until PendingEffectiveAt, the end of
+// the cool-off. A pending limit of zero removes the limit.
+type DepositLimit struct {
+	UserID               string             `json:"user_id"`
+	Period               DepositLimitPeriod `json:"period"`
+	LimitUSDCents        int64              `json:"limit_usd_cents"`
+	PendingLimitUSDCents *int64             `json:"pending_limit_usd_cents,omitempty"`
+	PendingEffectiveAt   *time.Time         `json:"pending_effective_at,omitempty"`
+	SetBy                string             `json:"set_by,omitempty"`
+	UpdatedAt            time.Time          `json:"updated_at"`
+}
+
+// At returns the limit as it stands at now, with a pending change applied
+// once its cool-off has passed. A zero LimitUSDCents means no limit.
+func (l DepositLimit) At(now time.Time) DepositLimit {
+	if l.PendingLimitUSDCents != nil && l.PendingEffectiveAt != nil && !now.Before(*l.PendingEffectiveAt) {
+		l.LimitUSDCents = *l.PendingLimitUSDCents
+		l.PendingLimitUSDCents = nil
+		l.PendingEffectiveAt = nil
+	}
+	return l
+}
+
+// SelfExclusion bars a user from depositing until EndsAt. It cannot be
+// shortened or lifted early, only extended.
+type SelfExclusion struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	CreatedBy string    `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PlayerProtection is a user's deposit limits, as they stand now, and their
+// self-exclusion if one is in force.
+type PlayerProtection struct {
+	DepositLimits []DepositLimit `json:"deposit_limits"`
+	SelfExclusion *SelfExclusion `json:"self_exclusion,omitempty"`
+}
+
+// SetDepositLimitRequest sets the limit for a period. A limit of zero
+// removes it, which like any increase waits out the cool-off.
+type SetDepositLimitRequest struct {
+	Period        DepositLimitPeriod `json:"period" binding:"required,oneof=daily weekly monthly"`
+	LimitUSDCents int64              `json:"limit_usd_cents" binding:"gte=0"`
+}
+
+type SelfExclusionRequest struct {
+	Days int `json:"days" binding:"required,gt=0"`
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDepositLimitAt(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	raised := int64(50_000)
+	removed := int64(0)
+
+	tests := []struct {
+		name        string
+		pending     *int64
+		effectiveAt time.Time
+		want        int64
+		wantPending bool
+	}{
+		{name: "no pending change", want: 10_000},
+		{name: "raise in cool-off", pending: &raised, effectiveAt: now.Add(time.Second), want: 10_000, wantPending: true},
+		{name: "raise at the end of the cool-off", pending: &raised, effectiveAt: now, want: 50_000},
+		{name: "raise after the cool-off", pending: &raised, effectiveAt: now.Add(-time.Hour), want: 50_000},
+		{name: "removal in cool-off", pending: &removed, effectiveAt: now.Add(time.Hour), want: 10_000, wantPending: true},
+		{name: "removal after the cool-off", pending: &removed, effectiveAt: now.Add(-time.Hour), want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit := DepositLimit{Period: DepositLimitDaily, LimitUSDCents: 10_000, PendingLimitUSDCents: tt.pending}
+			if tt.pending != nil {
+				limit.PendingEffectiveAt = &tt.effectiveAt
+			}
+
+			got := limit.At(now)
+			if got.LimitUSDCents != tt.want {
+				t.Errorf("At().LimitUSDCents = %d, want %d", got.LimitUSDCents, tt.want)
+			}
+			if (got.PendingLimitUSDCents != nil) != tt.wantPending || (got.PendingEffectiveAt != nil) != tt.wantPending {
+				t.Errorf("At() pending = %v at %v, want pending %v", got.PendingLimitUSDCents, got.PendingEffectiveAt, tt.wantPending)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package domain
+
+import "time"
+
+type QuoteStatus string
+
+const (
+	QuoteStatusActive   QuoteStatus = "active"
+	QuoteStatusConsumed QuoteStatus = "consumed"
+	QuoteStatusRepriced QuoteStatus = "repriced"
+)
+
+// LatePaymentPolicy decides how a deposit is valued when it misses its quote,
+// either by arriving after expiry or short of the slippage tolerance.
+type LatePaymentPolicy string
+
+const (
+	LatePaymentPolicyMarket  LatePaymentPolicy = "market"
+	LatePaymentPolicyLowerOf LatePaymentPolicy = "lower_of"
+)
+
+// DepositQuote locks a fiat amount to a crypto amount for one deposit session.
+type DepositQuote struct {
+	ID              string      `json:"id"`
+	SessionID       string      `json:"session_id"`
+	UserID          string      `json:"user_id"`
+	CryptoCurrency  string      `json:"crypto_currency"`
+	FiatCurrency    string      `json:"fiat_currency"`
+	FiatAmountCents int64       `json:"fiat_amount_cents"`
+	CryptoAmount    float64     `json:"crypto_amount"`
+	ExchangeRate    float64     `json:"exchange_rate"`
+	PriceUSD        float64     `json:"price_usd"`
+	SlippageBps     int         `json:"slippage_bps"`
+	Status          QuoteStatus `json:"status"`
+	ExpiresAt       time.Time   `json:"expires_at"`
+	SettledAt       time.Time   `json:"settled_at,omitempty"`
+	CreatedAt       time.Time   `json:"created_at"`
+}
+
+type CreateQuoteRequest struct {
+	SessionID    string  `json:"session_id" binding:"required"`
+	FiatAmount   float64 `json:"fiat_amount" binding:"required,gt=0"`
+	FiatCurrency string  `json:"fiat_currency"`
+	SlippageBps  *int    `json:"slippage_bps"`
+}
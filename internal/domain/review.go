@@ -0,0 +1,64 @@
+package domain
+
+import "time"
+
+type ReviewDecision string
+
+const (
+	ReviewDecisionApproved ReviewDecision = "approved"
+	ReviewDecisionRejected ReviewDecision = "rejected"
+)
+
+// WithdrawalReview is the admin review state stored under "review" in
+// withdrawals.metadata. DecidedBy is empty when the system decided, e.g. on
+// a missed deadline.
+type WithdrawalReview struct {
+	Decision    ReviewDecision `json:"decision,omitempty"`
+	DecidedBy   string         `json:"decided_by,omitempty"`
+	DecidedAt   *time.Time     `json:"decided_at,omitempty"`
+	Reason      string         `json:"reason,omitempty"`
+	Escalations int            `json:"escalations,omitempty"`
+	Notes       []ReviewNote   `json:"notes,omitempty"`
+}
+
+type ReviewNote struct {
+	AdminID   string    `json:"admin_id"`
+	Note      string    `json:"note"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ApproveWithdrawalRequest struct {
+	Note string `json:"note"`
+}
+
+type RejectWithdrawalRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+type ReviewNoteRequest struct {
+	Note string `json:"note" binding:"required"`
+}
+
+type ReleaseDepositRequest struct {
+	Note string `json:"note"`
+}
+
+type RejectDepositRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// RequestDepositRefundRequest asks for a limit-held deposit to be refunded.
+// Deposits are only refunded to the address they came from; ToAddress may be
+// left empty or must name that address.
+type RequestDepositRefundRequest struct {
+	ToAddress string `json:"to_address"`
+}
+
+// MarkDepositRefundedRequest records the transaction an admin refunded a
+// limit-held deposit with. ToAddress, if given, must be the address the
+// deposit came from.
+type MarkDepositRefundedRequest struct {
+	TxHash    string `json:"tx_hash" binding:"required"`
+	ToAddress string `json:"to_address"`
+	Note      string `json:"note"`
+}
@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+// ScreeningMatch records a counterparty address found on a screening list.
+type ScreeningMatch struct {
+	Address   string    `json:"address"`
+	List      string    `json:"list"`
+	Reason    string    `json:"reason"`
+	MatchedAt time.Time `json:"matched_at"`
+}
@@ -14,6 +14,14 @@ const (
 	SessionStatusFailed     SessionStatus = "failed"
 	SessionStatusCancelled  SessionStatus = "cancelled"
 	SessionStatusExpired    SessionStatus = "expired"
+	// SessionStatusHeld is a paid session that is not credited until an
+	// admin releases it; see DepositHold.
+	SessionStatusHeld SessionStatus = "held"
+	// SessionStatusLimitHeld is a paid session that a deposit limit or
+	// self-exclusion kept from being credited. It is never credited, only
+	// refunded.
+	SessionStatusLimitHeld SessionStatus = "limit_held"
+	SessionStatusRefunded  SessionStatus = "refunded"
 )
 
 type DepositSession struct {
@@ -33,3 +41,56 @@ type DepositSession struct {
 	CreatedAt      time.Time       `json:"created_at" db:"created_at" binding:"required"`
 	UpdatedAt      time.Time       `json:"updated_at" db:"updated_at" binding:"required"`
 }
+
+// DepositSessionMetadata is what the verifier stores in
+// deposit_sessions.metadata before a session completes.
+type DepositSessionMetadata struct {
+	Hold *DepositHold `json:"hold,omitempty"`
+}
+
+// DepositHold records why a paid session was held and the transfer that
+// paid it, so a released session is credited from that transfer without
+// searching the chain again. Code is all the depositor is shown; Decision is
+// set once an admin resolves the hold.
+type DepositHold struct {
+	Code        RestrictionCode `json:"code"`
+	Reason      string          `json:"reason,omitempty"`
+	FromAddress string          `json:"from_address,omitempty"`
+	Screening   *ScreeningMatch `json:"screening,omitempty"`
+	Match       *DepositMatch   `json:"match,omitempty"`
+	HeldAt      time.Time       `json:"held_at"`
+	Decision    ReviewDecision  `json:"decision,omitempty"`
+	DecidedBy   string          `json:"decided_by,omitempty"`
+	DecidedAt   *time.Time      `json:"decided_at,omitempty"`
+	Note        string          `json:"note,omitempty"`
+	Refund      *DepositRefund  `json:"refund,omitempty"`
+}
+
+// DepositRefund tracks the return of a limit-held deposit: where the
+// depositor asked for it to go, and the payout once an admin has sent it.
+type DepositRefund struct {
+	ToAddress   string     `json:"to_address"`
+	RequestedAt *time.Time `json:"requested_at,omitempty"`
+	TxHash      string     `json:"tx_hash,omitempty"`
+	RefundedBy  string     `json:"refunded_by,omitempty"`
+	RefundedAt  *time.Time `json:"refunded_at,omitempty"`
+	Note        string     `json:"note,omitempty"`
+}
+
+// Hold returns the hold recorded in the session's metadata, if any.
+func (s DepositSession) Hold() *DepositHold {
+	var metadata DepositSessionMetadata
+	if len(s.Metadata) == 0 || json.Unmarshal(s.Metadata, &metadata) != nil {
+		return nil
+	}
+	return metadata.Hold
+}
+
+// ReleasedHold returns the session's hold if an admin released it.
+func (s DepositSession) ReleasedHold() *DepositHold {
+	hold := s.Hold()
+	if hold == nil || hold.Decision != ReviewDecisionApproved {
+		return nil
+	}
+	return hold
+}
@@ -38,6 +38,18 @@ type HeliusTransaction struct {
 	Events           interface{}      `json:"events"`
 }
 
+// DepositMatch is the transfer that satisfied a deposit, in base units.
+// Amount is what the deposit address received; for Token-2022 mints with a
+// transfer fee it is less than Sent, what the sender transferred. Mint and
+// TokenProgram are empty for native SOL.
+type DepositMatch struct {
+	Transaction  HeliusTransaction `json:"transaction"`
+	Amount       int64             `json:"amount"`
+	Sent         int64             `json:"sent"`
+	Mint         string            `json:"mint,omitempty"`
+	TokenProgram string            `json:"token_program,omitempty"`
+}
+
 type NativeTransfer struct {
 	FromUserAccount string `json:"fromUserAccount"`
 	ToUserAccount   string `json:"toUserAccount"`
@@ -81,3 +93,36 @@ type InnerInstruction struct {
 	Data      string   `json:"data"`
 	ProgramId string   `json:"programId"`
 }
+
+// SignatureStatus is a transaction's entry in getSignatureStatuses.
+type SignatureStatus struct {
+	Slot               uint64      `json:"slot"`
+	Confirmations      *uint64     `json:"confirmations"`
+	ConfirmationStatus string      `json:"confirmationStatus"`
+	Err                interface{} `json:"err"`
+}
+
+// AccountInfo is an account's entry in getAccountInfo. Data holds only as
+// much of the account's data as was requested; Space is its full size.
+type AccountInfo struct {
+	Lamports   uint64 `json:"lamports"`
+	Owner      string `json:"owner"`
+	Executable bool   `json:"executable"`
+	Space      uint64 `json:"space"`
+	Data       []byte `json:"-"`
+}
+
+// TokenDestination is where an SPL token payout to Address lands. Address is
+// either a wallet, whose associated token account receives the tokens, or a
+// token account of the mint itself. When the associated token account does
+// not exist yet, the payout creates it and the payer deposits
+// TokenAccountRent lamports for its rent.
+type TokenDestination struct {
+	Address              string `json:"address"`
+	Owner                string `json:"owner"`
+	TokenAccount         string `json:"token_account"`
+	TokenProgram         string `json:"token_program"`
+	IsTokenAccount       bool   `json:"is_token_account"`
+	RequiresTokenAccount bool   `json:"requires_token_account"`
+	TokenAccountRent     uint64 `json:"token_account_rent,omitempty"`
+}
@@ -52,3 +52,17 @@ type Transaction struct {
 	CreatedAt        time.Time          `json:"created_at" db:"created_at" binding:"required"`
 	UpdatedAt        time.Time          `json:"updated_at" db:"updated_at" binding:"required"`
 }
+
+// TransactionMetadata is the document stored in transactions.metadata.
+type TransactionMetadata struct {
+	Chain      json.RawMessage       `json:"chain"`
+	Pricing    *ExchangeRateResponse `json:"pricing,omitempty"`
+	Quote      *DepositQuote         `json:"quote,omitempty"`
+	NetworkFee *NetworkFee           `json:"network_fee,omitempty"`
+	// Mint and TokenProgram identify the token of an SPL transfer. A
+	// Token-2022 transfer fee withheld between sender and recipient is
+	// recorded in the token's base units.
+	Mint         string `json:"mint,omitempty"`
+	TokenProgram string `json:"token_program,omitempty"`
+	TransferFee  int64  `json:"transfer_fee,omitempty"`
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// TravelRuleThreshold is the travel_rule_threshold system config. Withdrawals
+// worth at least the threshold of the user's country, or DefaultUSDCents for
+// countries without one, must carry Travel Rule data. A threshold of zero
+// applies the rule to every withdrawal.
+type TravelRuleThreshold struct {
+	DefaultUSDCents int64            `json:"default_usd_cents"`
+	Jurisdictions   map[string]int64 `json:"jurisdictions"` // ISO 3166-1 alpha-2 country -> USD cents
+}
+
+// For returns the threshold that applies to users in country.
+func (t TravelRuleThreshold) For(country string) int64 {
+	if threshold, ok := t.Jurisdictions[strings.ToUpper(country)]; ok {
+		return threshold
+	}
+	return t.DefaultUSDCents
+}
+
+type TravelRuleWalletType string
+
+const (
+	TravelRuleWalletHosted     TravelRuleWalletType = "hosted" // held for the beneficiary by a VASP
+	TravelRuleWalletSelfHosted TravelRuleWalletType = "self_hosted"
+)
+
+// TravelRuleRequest is the beneficiary information sent with a withdrawal.
+// A hosted wallet names the VASP holding it. For a self-hosted wallet the
+// user must attest that the beneficiary owns or controls it.
+type TravelRuleRequest struct {
+	BeneficiaryName   string               `json:"beneficiary_name" binding:"required,max=140"`
+	WalletType        TravelRuleWalletType `json:"wallet_type" binding:"required,oneof=hosted self_hosted"`
+	VASPName          string               `json:"vasp_name" binding:"max=140"`
+	VASPLEI           string               `json:"vasp_lei" binding:"omitempty,len=20,alphanum"`
+	OwnershipAttested bool                 `json:"ownership_attested"`
+}
+
+// TravelRuleData is the Travel Rule record of a withdrawal, stored sealed in
+// its metadata. The originator is the user's profile when the withdrawal was
+// created.
+type TravelRuleData struct {
+	Originator        UserProfile       `json:"originator"`
+	Beneficiary       TravelRuleRequest `json:"beneficiary"`
+	ToAddress         string            `json:"to_address"`
+	USDAmountCents    int64             `json:"usd_amount_cents"`
+	AttestedAt        *time.Time        `json:"attested_at,omitempty"`
+	CapturedAt        time.Time         `json:"captured_at"`
+	Jurisdiction      string            `json:"jurisdiction"`
+	ThresholdUSDCents int64             `json:"threshold_usd_cents"`
+}
+
+// WithdrawalTravelRule is what a withdrawal's metadata holds of its Travel
+// Rule record: the threshold it was captured under and the record itself,
+// encrypted with security.encryption_key and bound to the withdrawal ID.
+type WithdrawalTravelRule struct {
+	Jurisdiction      string    `json:"jurisdiction"`
+	ThresholdUSDCents int64     `json:"threshold_usd_cents"`
+	Required          bool      `json:"required"`
+	Nonce             string    `json:"nonce"`
+	Ciphertext        string    `json:"ciphertext"`
+	CapturedAt        time.Time `json:"captured_at"`
+}
+
+// TravelRuleExport is a withdrawal's Travel Rule record for sharing with the
+// beneficiary VASP or a regulator, with the parties in IVMS101 form.
+type TravelRuleExport struct {
+	WithdrawalID      string               `json:"withdrawal_id"`
+	ChainID           string               `json:"chain_id"`
+	CryptoCurrency    string               `json:"crypto_currency"`
+	CryptoAmount      string               `json:"crypto_amount"`
+	USDAmountCents    int64                `json:"usd_amount_cents"`
+	TxHash            string               `json:"tx_hash,omitempty"`
+	WalletType        TravelRuleWalletType `json:"wallet_type"`
+	OwnershipAttested bool                 `json:"ownership_attested"`
+	AttestedAt        *time.Time           `json:"attested_at,omitempty"`
+	CapturedAt        time.Time            `json:"captured_at"`
+	IVMS101           IVMS101Payload       `json:"ivms101"`
+}
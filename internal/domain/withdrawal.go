@@ -42,3 +42,103 @@ type Withdrawal struct {
 	CreatedAt             time.Time        `json:"created_at" db:"created_at" binding:"required"`
 	UpdatedAt             time.Time        `json:"updated_at" db:"updated_at" binding:"required"`
 }
+
+// WithdrawalOriginAPI marks withdrawals created through the withdrawal API,
+// as opposed to rows inserted by another service.
+const WithdrawalOriginAPI = "api"
+
+// WithdrawalMetadata is the document stored in withdrawals.metadata.
+// FiatCurrency is the currency the reservation is denominated in and
+// FiatAmountCents the amount asked for in it, while the withdrawal's
+// USDAmountCents is always its USD value; withdrawals without a currency
+// predate multi-currency balances and are in USD. Withdrawals created through the API carry the client's
+// idempotency key and a fingerprint of the request it was first used with.
+// TravelRule holds the sealed Travel Rule record when one was captured.
+// LimitCheck is set once the withdrawal has been evaluated against the
+// velocity limits, Review once it has entered admin review, Broadcast once
+// the executor has signed a payout transaction for it, Verification when its
+// transaction did not match it on chain, and NetworkFee once it completed.
+type WithdrawalMetadata struct {
+	FiatCurrency    string                  `json:"fiat_currency,omitempty"`
+	FiatAmountCents int64                   `json:"fiat_amount_cents,omitempty"`
+	Origin          string                  `json:"origin,omitempty"`
+	IdempotencyKey  string                  `json:"idempotency_key,omitempty"`
+	RequestHash     string                  `json:"request_hash,omitempty"`
+	Destination     *TokenDestination       `json:"destination,omitempty"`
+	AddressBookID   string                  `json:"address_book_id,omitempty"`
+	TravelRule      *WithdrawalTravelRule   `json:"travel_rule,omitempty"`
+	LimitCheck      *LimitCheck             `json:"limit_check,omitempty"`
+	Review          *WithdrawalReview       `json:"review,omitempty"`
+	Broadcast       *WithdrawalBroadcast    `json:"broadcast,omitempty"`
+	Verification    *WithdrawalVerification `json:"verification,omitempty"`
+	NetworkFee      *NetworkFee             `json:"network_fee,omitempty"`
+}
+
+// PayableSince is when the withdrawal could first be paid out: its creation
+// or, once it has been through admin review, the review decision. Broadcast
+// deadlines count from here so time spent waiting on admins is not held
+// against the payout.
+func (m WithdrawalMetadata) PayableSince(createdAt time.Time) time.Time {
+	if m.Review != nil && m.Review.DecidedAt != nil && m.Review.DecidedAt.After(createdAt) {
+		return *m.Review.DecidedAt
+	}
+	return createdAt
+}
+
+// WithdrawalBroadcast is the latest payout transaction the executor signed
+// for a withdrawal. The signature is recorded before sending, so after a
+// restart the executor can tell whether the transaction landed or its
+// blockhash expired and it is safe to sign again.
+type WithdrawalBroadcast struct {
+	Signature            string    `json:"signature"`
+	Blockhash            string    `json:"blockhash"`
+	LastValidBlockHeight uint64    `json:"last_valid_block_height"`
+	Attempt              int       `json:"attempt"`
+	SignedAt             time.Time `json:"signed_at"`
+	Error                string    `json:"error,omitempty"`
+}
+
+// WithdrawalVerification records why a withdrawal's transaction failed
+// verification. The withdrawal is held for admin review, and an approval
+// decided after CheckedAt accepts that transaction as the payout.
+type WithdrawalVerification struct {
+	TxHash     string    `json:"tx_hash"`
+	Mismatches []string  `json:"mismatches"`
+	CheckedAt  time.Time `json:"checked_at"`
+}
+
+// CreateWithdrawalRequest asks for Amount, in FiatCurrency major units, to be
+// paid out as CryptoCurrency on ChainID. FiatCurrency defaults to the user's
+// balance currency. The idempotency key may also be sent as the
+// Idempotency-Key header. TravelRule is required from the Travel Rule
+// threshold of the user's country.
+type CreateWithdrawalRequest struct {
+	IdempotencyKey string             `json:"idempotency_key"`
+	ChainID        string             `json:"chain_id" binding:"required"`
+	CryptoCurrency string             `json:"crypto_currency" binding:"required"`
+	Amount         float64            `json:"amount" binding:"required,gt=0"`
+	FiatCurrency   string             `json:"fiat_currency"`
+	ToAddress      string             `json:"to_address" binding:"required"`
+	TravelRule     *TravelRuleRequest `json:"travel_rule"`
+}
+
+// WithdrawalFeeEstimateRequest asks what a withdrawal to ToAddress would
+// cost. Without an Amount the platform fee is estimated at its minimum.
+type WithdrawalFeeEstimateRequest struct {
+	ChainID        string  `form:"chain_id" binding:"required"`
+	CryptoCurrency string  `form:"crypto_currency" binding:"required"`
+	ToAddress      string  `form:"to_address" binding:"required"`
+	Amount         float64 `form:"amount" binding:"omitempty,gt=0"`
+	FiatCurrency   string  `form:"fiat_currency"`
+}
+
+// WithdrawalFeeEstimate is what a withdrawal is expected to cost the user,
+// in FiatCurrency minor units. TotalFeeCents is the platform fee plus the
+// network fee when the chain's policy makes the user pay it.
+type WithdrawalFeeEstimate struct {
+	FiatCurrency     string             `json:"fiat_currency"`
+	AmountCents      int64              `json:"amount_cents,omitempty"`
+	PlatformFeeCents int64              `json:"platform_fee_cents"`
+	NetworkFee       NetworkFeeEstimate `json:"network_fee"`
+	TotalFeeCents    int64              `json:"total_fee_cents"`
+}
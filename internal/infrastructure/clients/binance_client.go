@@ -0,0 +1,97 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/pkg/circuitbreaker"
+	"github.com/tuncanbit/tvs/pkg/config"
+	"github.com/tuncanbit/tvs/pkg/ratelimit"
+)
+
+type BinanceClient struct {
+	publicAPIClient
+}
+
+func NewBinanceClient(cfg config.PriceSourceConfig, limiters *ratelimit.Registry, breakers *circuitbreaker.Registry, logger zerolog.Logger) *BinanceClient {
+	return &BinanceClient{
+		publicAPIClient: newPublicAPIClient("binance", cfg, limiters, breakers, logger),
+	}
+}
+
+func (c *BinanceClient) Name() string {
+	return "binance"
+}
+
+// GetPrice quotes USD prices against USDT, which is the deepest USD-like
+// market on Binance. USDT itself therefore cannot be priced here.
+func (c *BinanceClient) GetPrice(ctx context.Context, cryptoCurrency, fiatCurrency string) (float64, error) {
+	symbol, err := binanceSymbol(cryptoCurrency, fiatCurrency)
+	if err != nil {
+		return 0, err
+	}
+
+	url := fmt.Sprintf("%s/api/v3/ticker/price?symbol=%s", c.baseURL, symbol)
+
+	var response struct {
+		Symbol string `json:"symbol"`
+		Price  string `json:"price"`
+	}
+	if err := c.getJSON(ctx, url, nil, &response); err != nil {
+		return 0, err
+	}
+
+	price, err := strconv.ParseFloat(response.Price, 64)
+	if err != nil || price <= 0 {
+		return 0, fmt.Errorf("invalid binance price %q for %s", response.Price, response.Symbol)
+	}
+	return price, nil
+}
+
+// GetHistoricalPrice uses the close of the one-minute kline containing at.
+func (c *BinanceClient) GetHistoricalPrice(ctx context.Context, cryptoCurrency, fiatCurrency string, at time.Time) (float64, error) {
+	symbol, err := binanceSymbol(cryptoCurrency, fiatCurrency)
+	if err != nil {
+		return 0, err
+	}
+
+	start := at.Truncate(time.Minute)
+	url := fmt.Sprintf("%s/api/v3/klines?symbol=%s&interval=1m&startTime=%d&limit=1", c.baseURL, symbol, start.UnixMilli())
+
+	var klines [][]json.RawMessage
+	if err := c.getJSON(ctx, url, nil, &klines); err != nil {
+		return 0, err
+	}
+	if len(klines) == 0 || len(klines[0]) < 5 {
+		return 0, fmt.Errorf("binance returned no kline for %s at %s", symbol, start.Format(time.RFC3339))
+	}
+
+	var openTime int64
+	var closePrice string
+	if err := json.Unmarshal(klines[0][0], &openTime); err != nil {
+		return 0, fmt.Errorf("invalid binance kline open time: %w", err)
+	}
+	if err := json.Unmarshal(klines[0][4], &closePrice); err != nil {
+		return 0, fmt.Errorf("invalid binance kline close price: %w", err)
+	}
+	price, err := strconv.ParseFloat(closePrice, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid binance kline close price %q: %w", closePrice, err)
+	}
+	return closestPrice([]pricePoint{{at: time.UnixMilli(openTime), price: price}}, at, cryptoCurrency)
+}
+
+func binanceSymbol(cryptoCurrency, fiatCurrency string) (string, error) {
+	quote := fiatCurrency
+	if fiatCurrency == "USD" {
+		quote = "USDT"
+	}
+	if cryptoCurrency == quote {
+		return "", fmt.Errorf("binance cannot price %s against itself", cryptoCurrency)
+	}
+	return cryptoCurrency + quote, nil
+}
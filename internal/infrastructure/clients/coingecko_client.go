@@ -0,0 +1,93 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/pkg/circuitbreaker"
+	"github.com/tuncanbit/tvs/pkg/config"
+	"github.com/tuncanbit/tvs/pkg/ratelimit"
+)
+
+type CoinGeckoClient struct {
+	publicAPIClient
+	apiKey string
+}
+
+func NewCoinGeckoClient(cfg config.PriceSourceConfig, limiters *ratelimit.Registry, breakers *circuitbreaker.Registry, logger zerolog.Logger) *CoinGeckoClient {
+	return &CoinGeckoClient{
+		publicAPIClient: newPublicAPIClient("coingecko", cfg, limiters, breakers, logger),
+		apiKey:          cfg.APIKey,
+	}
+}
+
+func (c *CoinGeckoClient) Name() string {
+	return "coingecko"
+}
+
+func (c *CoinGeckoClient) GetPrice(ctx context.Context, cryptoCurrency, fiatCurrency string) (float64, error) {
+	id, exists := coinGeckoIDs[cryptoCurrency]
+	if !exists {
+		return 0, fmt.Errorf("unsupported crypto currency for coingecko: %s", cryptoCurrency)
+	}
+	vs := strings.ToLower(fiatCurrency)
+
+	url := fmt.Sprintf("%s/api/v3/simple/price?ids=%s&vs_currencies=%s", c.baseURL, id, vs)
+	headers := map[string]string{}
+	if c.apiKey != "" {
+		headers["x-cg-demo-api-key"] = c.apiKey
+	}
+
+	var response map[string]map[string]float64
+	if err := c.getJSON(ctx, url, headers, &response); err != nil {
+		return 0, err
+	}
+
+	price, exists := response[id][vs]
+	if !exists || price <= 0 {
+		return 0, fmt.Errorf("coingecko returned no %s price for %s", fiatCurrency, cryptoCurrency)
+	}
+	return price, nil
+}
+
+func (c *CoinGeckoClient) GetHistoricalPrice(ctx context.Context, cryptoCurrency, fiatCurrency string, at time.Time) (float64, error) {
+	id, exists := coinGeckoIDs[cryptoCurrency]
+	if !exists {
+		return 0, fmt.Errorf("unsupported crypto currency for coingecko: %s", cryptoCurrency)
+	}
+
+	url := fmt.Sprintf("%s/api/v3/coins/%s/market_chart/range?vs_currency=%s&from=%d&to=%d",
+		c.baseURL, id, strings.ToLower(fiatCurrency), at.Add(-historyWindow).Unix(), at.Add(historyWindow).Unix())
+	headers := map[string]string{}
+	if c.apiKey != "" {
+		headers["x-cg-demo-api-key"] = c.apiKey
+	}
+
+	var response struct {
+		Prices [][2]float64 `json:"prices"`
+	}
+	if err := c.getJSON(ctx, url, headers, &response); err != nil {
+		return 0, err
+	}
+
+	points := make([]pricePoint, 0, len(response.Prices))
+	for _, point := range response.Prices {
+		points = append(points, pricePoint{at: time.UnixMilli(int64(point[0])), price: point[1]})
+	}
+	return closestPrice(points, at, cryptoCurrency)
+}
+
+var coinGeckoIDs = map[string]string{
+	"BTC":  "bitcoin",
+	"ETH":  "ethereum",
+	"LTC":  "litecoin",
+	"USDT": "tether",
+	"USDC": "usd-coin",
+	"SOL":  "solana",
+	"TRX":  "tron",
+	"BNB":  "binancecoin",
+	"DOGE": "dogecoin",
+}
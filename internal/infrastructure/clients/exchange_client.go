@@ -4,6 +4,7 @@ package clients
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -13,17 +14,21 @@ import (
 
 	"github.com/rs/zerolog"
 	"github.com/tuncanbit/tvs/internal/domain"
+	"github.com/tuncanbit/tvs/pkg/circuitbreaker"
 	"github.com/tuncanbit/tvs/pkg/config"
+	"github.com/tuncanbit/tvs/pkg/ratelimit"
 )
 
 type ExchangeAPIClient struct {
 	baseURL    string
 	httpClient *http.Client
 	config     *config.ExchangeAPIConfig
+	limiter    *ratelimit.Limiter
+	breaker    *circuitbreaker.Breaker
 	logger     zerolog.Logger
 }
 
-func NewExchangeAPIClient(cfg *config.ExchangeAPIConfig, logger zerolog.Logger) *ExchangeAPIClient {
+func NewExchangeAPIClient(cfg *config.ExchangeAPIConfig, limiters *ratelimit.Registry, breakers *circuitbreaker.Registry, logger zerolog.Logger) *ExchangeAPIClient {
 	return &ExchangeAPIClient{
 		baseURL: cfg.BaseURL,
 		httpClient: &http.Client{
@@ -36,15 +41,40 @@ func NewExchangeAPIClient(cfg *config.ExchangeAPIConfig, logger zerolog.Logger)
 				MaxIdleConnsPerHost: 10,
 			},
 		},
-		config: cfg,
-		logger: logger.With().Str("component", "coincap_api_client").Logger(),
+		config:  cfg,
+		limiter: limiters.For("coincap", cfg.APIKey),
+		breaker: breakers.For("coincap"),
+		logger:  logger.With().Str("component", "coincap_api_client").Logger(),
 	}
 }
 
+// GetExchangeRate returns the CoinCap spot price. CoinCap only quotes USD, so
+// other fiat currencies have to be converted by the caller.
 func (c *ExchangeAPIClient) GetExchangeRate(ctx context.Context, cryptoCurrency, fiatCurrency string) (*domain.ExchangeRateResponse, error) {
+	if fiatCurrency != "" && fiatCurrency != "USD" {
+		return nil, fmt.Errorf("coincap only quotes USD, got %s", fiatCurrency)
+	}
 	return c.getExchangeRateWithRetry(ctx, cryptoCurrency, 0)
 }
 
+// Available reports whether calls to CoinCap are currently allowed by the
+// circuit breaker.
+func (c *ExchangeAPIClient) Available() bool {
+	return c.breaker.Available()
+}
+
+func (c *ExchangeAPIClient) Name() string {
+	return "coincap"
+}
+
+func (c *ExchangeAPIClient) GetPrice(ctx context.Context, cryptoCurrency, fiatCurrency string) (float64, error) {
+	rate, err := c.GetExchangeRate(ctx, cryptoCurrency, fiatCurrency)
+	if err != nil {
+		return 0, err
+	}
+	return rate.Rate, nil
+}
+
 func (c *ExchangeAPIClient) GetMultipleExchangeRates(ctx context.Context, cryptoCurrencies []string, fiatCurrency string) (map[string]*domain.ExchangeRateResponse, error) {
 	rates := make(map[string]*domain.ExchangeRateResponse)
 
@@ -60,8 +90,103 @@ func (c *ExchangeAPIClient) GetMultipleExchangeRates(ctx context.Context, crypto
 	return rates, nil
 }
 
+// GetExchangeRateWithTimestamp returns the USD price in effect at timestamp,
+// taken from the closest point of CoinCap's minute-resolution history.
 func (c *ExchangeAPIClient) GetExchangeRateWithTimestamp(ctx context.Context, cryptoCurrency, fiatCurrency string, timestamp time.Time) (*domain.ExchangeRateResponse, error) {
-	return c.GetExchangeRate(ctx, cryptoCurrency, fiatCurrency)
+	price, err := c.GetHistoricalPrice(ctx, cryptoCurrency, fiatCurrency, timestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ExchangeRateResponse{
+		CryptoCurrency: cryptoCurrency,
+		FiatCurrency:   "USD",
+		Rate:           price,
+		PriceUSD:       price,
+		LastUpdated:    timestamp.Format(time.RFC3339),
+		PricedAt:       timestamp,
+	}, nil
+}
+
+func (c *ExchangeAPIClient) GetHistoricalPrice(ctx context.Context, cryptoCurrency, fiatCurrency string, at time.Time) (float64, error) {
+	if fiatCurrency != "USD" {
+		return 0, fmt.Errorf("coincap history only supports USD, got %s", fiatCurrency)
+	}
+
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return 0, fmt.Errorf("invalid base URL: %w", err)
+	}
+	u.Path = fmt.Sprintf("/v3/assets/%s/history", c.mapCryptoToCoinCapID(cryptoCurrency))
+	query := u.Query()
+	query.Set("interval", "m1")
+	query.Set("start", strconv.FormatInt(at.Add(-historyWindow).UnixMilli(), 10))
+	query.Set("end", strconv.FormatInt(at.Add(historyWindow).UnixMilli(), 10))
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return 0, fmt.Errorf("creating request failed: %w", err)
+	}
+	if c.config.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.config.APIKey)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	if err := c.limiter.Wait(ctx); err != nil {
+		return 0, fmt.Errorf("rate limiter wait failed: %w", err)
+	}
+
+	var body []byte
+	var clientErr error
+	err = c.breaker.Execute(func() error {
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			statusErr := c.handleErrorResponse(resp)
+			if shouldRetryStatusCode(resp.StatusCode) {
+				return statusErr
+			}
+			clientErr = statusErr
+			return nil
+		}
+
+		body, err = io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("reading response body failed: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	if clientErr != nil {
+		return 0, clientErr
+	}
+
+	var response struct {
+		Data []struct {
+			PriceUSD string `json:"priceUsd"`
+			Time     int64  `json:"time"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return 0, fmt.Errorf("parsing JSON response failed: %w", err)
+	}
+
+	points := make([]pricePoint, 0, len(response.Data))
+	for _, point := range response.Data {
+		price, err := strconv.ParseFloat(point.PriceUSD, 64)
+		if err != nil {
+			continue
+		}
+		points = append(points, pricePoint{at: time.UnixMilli(point.Time), price: price})
+	}
+	return closestPrice(points, at, cryptoCurrency)
 }
 
 func (c *ExchangeAPIClient) getExchangeRateWithRetry(ctx context.Context, cryptoCurrency string, attempt int) (*domain.ExchangeRateResponse, error) {
@@ -84,8 +209,26 @@ func (c *ExchangeAPIClient) getExchangeRateWithRetry(ctx context.Context, crypto
 
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("rate limiter wait failed: %w", err)
+	}
+
+	var resp *http.Response
+	err = c.breaker.Execute(func() error {
+		var doErr error
+		resp, doErr = c.httpClient.Do(req)
+		if doErr != nil {
+			return doErr
+		}
+		if shouldRetryStatusCode(resp.StatusCode) {
+			return fmt.Errorf("HTTP error %d", resp.StatusCode)
+		}
+		return nil
+	})
+	if errors.Is(err, circuitbreaker.ErrOpen) {
+		return nil, err
+	}
+	if resp == nil {
 		if shouldRetry(err) && attempt < c.config.MaxRetries {
 			backoff := calculateBackoff(attempt, c.config.RetryBackoffBase)
 			c.logger.Info().
@@ -0,0 +1,58 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/pkg/circuitbreaker"
+	"github.com/tuncanbit/tvs/pkg/config"
+	"github.com/tuncanbit/tvs/pkg/ratelimit"
+)
+
+// FXClient converts between fiat currencies using a Frankfurter-compatible
+// API, which publishes ECB reference rates once per business day.
+type FXClient struct {
+	publicAPIClient
+}
+
+func NewFXClient(cfg config.PriceSourceConfig, limiters *ratelimit.Registry, breakers *circuitbreaker.Registry, logger zerolog.Logger) *FXClient {
+	return &FXClient{
+		publicAPIClient: newPublicAPIClient("fx", cfg, limiters, breakers, logger),
+	}
+}
+
+// GetRate returns how many units of quote one unit of base buys today.
+func (c *FXClient) GetRate(ctx context.Context, base, quote string) (float64, error) {
+	return c.getRate(ctx, "latest", base, quote)
+}
+
+// GetHistoricalRate returns the reference rate published for the day of at.
+// Weekends and holidays resolve to the previous business day.
+func (c *FXClient) GetHistoricalRate(ctx context.Context, base, quote string, at time.Time) (float64, error) {
+	return c.getRate(ctx, at.UTC().Format("2006-01-02"), base, quote)
+}
+
+func (c *FXClient) getRate(ctx context.Context, date, base, quote string) (float64, error) {
+	if base == quote {
+		return 1, nil
+	}
+
+	url := fmt.Sprintf("%s/%s?from=%s&to=%s", c.baseURL, date, base, quote)
+
+	var response struct {
+		Base  string             `json:"base"`
+		Date  string             `json:"date"`
+		Rates map[string]float64 `json:"rates"`
+	}
+	if err := c.getJSON(ctx, url, nil, &response); err != nil {
+		return 0, err
+	}
+
+	rate, exists := response.Rates[quote]
+	if !exists || rate <= 0 {
+		return 0, fmt.Errorf("fx source returned no %s/%s rate for %s", base, quote, date)
+	}
+	return rate, nil
+}
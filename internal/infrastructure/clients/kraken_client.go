@@ -0,0 +1,115 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/pkg/circuitbreaker"
+	"github.com/tuncanbit/tvs/pkg/config"
+	"github.com/tuncanbit/tvs/pkg/ratelimit"
+)
+
+type KrakenClient struct {
+	publicAPIClient
+}
+
+func NewKrakenClient(cfg config.PriceSourceConfig, limiters *ratelimit.Registry, breakers *circuitbreaker.Registry, logger zerolog.Logger) *KrakenClient {
+	return &KrakenClient{
+		publicAPIClient: newPublicAPIClient("kraken", cfg, limiters, breakers, logger),
+	}
+}
+
+func (c *KrakenClient) Name() string {
+	return "kraken"
+}
+
+func (c *KrakenClient) GetPrice(ctx context.Context, cryptoCurrency, fiatCurrency string) (float64, error) {
+	url := fmt.Sprintf("%s/0/public/Ticker?pair=%s%s", c.baseURL, krakenAsset(cryptoCurrency), fiatCurrency)
+
+	var response struct {
+		Error  []string `json:"error"`
+		Result map[string]struct {
+			LastTrade []string `json:"c"`
+		} `json:"result"`
+	}
+	if err := c.getJSON(ctx, url, nil, &response); err != nil {
+		return 0, err
+	}
+	if len(response.Error) > 0 {
+		return 0, fmt.Errorf("kraken error: %s", strings.Join(response.Error, ", "))
+	}
+
+	// Kraken answers with its own pair name (e.g. XXBTZUSD), so take the
+	// single entry rather than looking it up by the requested name.
+	for pair, ticker := range response.Result {
+		if len(ticker.LastTrade) == 0 {
+			return 0, fmt.Errorf("kraken returned no last trade for %s", pair)
+		}
+		price, err := strconv.ParseFloat(ticker.LastTrade[0], 64)
+		if err != nil || price <= 0 {
+			return 0, fmt.Errorf("invalid kraken price %q for %s", ticker.LastTrade[0], pair)
+		}
+		return price, nil
+	}
+	return 0, fmt.Errorf("kraken returned no ticker for %s/%s", cryptoCurrency, fiatCurrency)
+}
+
+// GetHistoricalPrice uses the close of the one-minute OHLC candle nearest to
+// at. Kraken only serves the most recent 720 candles, so older moments fail.
+func (c *KrakenClient) GetHistoricalPrice(ctx context.Context, cryptoCurrency, fiatCurrency string, at time.Time) (float64, error) {
+	url := fmt.Sprintf("%s/0/public/OHLC?pair=%s%s&interval=1&since=%d",
+		c.baseURL, krakenAsset(cryptoCurrency), fiatCurrency, at.Add(-historyWindow).Unix())
+
+	var response struct {
+		Error  []string                   `json:"error"`
+		Result map[string]json.RawMessage `json:"result"`
+	}
+	if err := c.getJSON(ctx, url, nil, &response); err != nil {
+		return 0, err
+	}
+	if len(response.Error) > 0 {
+		return 0, fmt.Errorf("kraken error: %s", strings.Join(response.Error, ", "))
+	}
+
+	var points []pricePoint
+	for pair, raw := range response.Result {
+		if pair == "last" {
+			continue
+		}
+		var candles [][]interface{}
+		if err := json.Unmarshal(raw, &candles); err != nil {
+			return 0, fmt.Errorf("invalid kraken OHLC data for %s: %w", pair, err)
+		}
+		for _, candle := range candles {
+			if len(candle) < 5 {
+				continue
+			}
+			openTime, ok := candle[0].(float64)
+			if !ok {
+				continue
+			}
+			closeStr, ok := candle[4].(string)
+			if !ok {
+				continue
+			}
+			price, err := strconv.ParseFloat(closeStr, 64)
+			if err != nil {
+				continue
+			}
+			points = append(points, pricePoint{at: time.Unix(int64(openTime), 0), price: price})
+		}
+	}
+	return closestPrice(points, at, cryptoCurrency)
+}
+
+func krakenAsset(cryptoCurrency string) string {
+	if cryptoCurrency == "BTC" {
+		return "XBT"
+	}
+	return cryptoCurrency
+}
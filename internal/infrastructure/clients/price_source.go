@@ -0,0 +1,156 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/pkg/circuitbreaker"
+	"github.com/tuncanbit/tvs/pkg/config"
+	"github.com/tuncanbit/tvs/pkg/ratelimit"
+)
+
+// IPriceSource is a single market data provider used by the price oracle.
+type IPriceSource interface {
+	Name() string
+	GetPrice(ctx context.Context, cryptoCurrency, fiatCurrency string) (float64, error)
+}
+
+// IHistoricalPriceSource is implemented by price sources that can also quote
+// the price in effect at a past moment.
+type IHistoricalPriceSource interface {
+	IPriceSource
+	GetHistoricalPrice(ctx context.Context, cryptoCurrency, fiatCurrency string, at time.Time) (float64, error)
+}
+
+// historyWindow bounds how far from the requested moment a historical price
+// point may be and still be used.
+const historyWindow = 5 * time.Minute
+
+type pricePoint struct {
+	at    time.Time
+	price float64
+}
+
+// closestPrice returns the price of the point nearest to at, rejecting points
+// further away than historyWindow.
+func closestPrice(points []pricePoint, at time.Time, cryptoCurrency string) (float64, error) {
+	if len(points) == 0 {
+		return 0, fmt.Errorf("no historical price points for %s around %s", cryptoCurrency, at.Format(time.RFC3339))
+	}
+
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].at.Sub(at).Abs() < points[j].at.Sub(at).Abs()
+	})
+	if points[0].at.Sub(at).Abs() > historyWindow || points[0].price <= 0 {
+		return 0, fmt.Errorf("no historical price for %s within %s of %s", cryptoCurrency, historyWindow, at.Format(time.RFC3339))
+	}
+	return points[0].price, nil
+}
+
+// NewPriceSources builds every enabled price source. CoinCap is always
+// included because it is configured through exchange_api_config.
+func NewPriceSources(cfg *config.Config, coinCap *ExchangeAPIClient, limiters *ratelimit.Registry, breakers *circuitbreaker.Registry, logger zerolog.Logger) []IPriceSource {
+	sources := []IPriceSource{coinCap}
+
+	for name, sourceCfg := range cfg.Pricing.Sources {
+		if !sourceCfg.Enabled {
+			continue
+		}
+		switch name {
+		case "coingecko":
+			sources = append(sources, NewCoinGeckoClient(sourceCfg, limiters, breakers, logger))
+		case "binance":
+			sources = append(sources, NewBinanceClient(sourceCfg, limiters, breakers, logger))
+		case "kraken":
+			sources = append(sources, NewKrakenClient(sourceCfg, limiters, breakers, logger))
+		default:
+			logger.Warn().Str("source", name).Msg("Unknown price source configured, ignoring")
+		}
+	}
+
+	return sources
+}
+
+// publicAPIClient holds what every public market data endpoint needs: an
+// HTTP client plus the shared rate limiter and circuit breaker for it.
+type publicAPIClient struct {
+	name       string
+	baseURL    string
+	httpClient *http.Client
+	limiter    *ratelimit.Limiter
+	breaker    *circuitbreaker.Breaker
+	logger     zerolog.Logger
+}
+
+func newPublicAPIClient(name string, cfg config.PriceSourceConfig, limiters *ratelimit.Registry, breakers *circuitbreaker.Registry, logger zerolog.Logger) publicAPIClient {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	return publicAPIClient{
+		name:    name,
+		baseURL: cfg.BaseURL,
+		httpClient: &http.Client{
+			Timeout: timeout,
+		},
+		limiter: limiters.For(name, cfg.APIKey),
+		breaker: breakers.For(name),
+		logger:  logger.With().Str("component", name+"_api_client").Logger(),
+	}
+}
+
+func (c *publicAPIClient) getJSON(ctx context.Context, url string, headers map[string]string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return fmt.Errorf("creating request failed: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	if err := c.limiter.Wait(ctx); err != nil {
+		return fmt.Errorf("rate limiter wait failed: %w", err)
+	}
+
+	var body []byte
+	var clientErr error
+	err = c.breaker.Execute(func() error {
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		body, err = io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("reading response body failed: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			statusErr := fmt.Errorf("HTTP error %d: %s", resp.StatusCode, string(body))
+			if shouldRetryStatusCode(resp.StatusCode) {
+				return statusErr
+			}
+			clientErr = statusErr
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if clientErr != nil {
+		return clientErr
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("parsing JSON response failed: %w", err)
+	}
+	return nil
+}
@@ -1,27 +1,184 @@
 package rpc
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/internal/domain"
+	"github.com/tuncanbit/tvs/pkg/circuitbreaker"
+	"github.com/tuncanbit/tvs/pkg/config"
+	"github.com/tuncanbit/tvs/pkg/ratelimit"
 )
 
+// AlchemyClient talks JSON-RPC to EVM chains through Alchemy.
 type AlchemyClient struct {
-	baseURL    string
 	apiKey     string
+	rpcURLs    map[string]string
 	httpClient *http.Client
+	limiter    *ratelimit.Limiter
+	breaker    *circuitbreaker.Breaker
+	logger     zerolog.Logger
 }
 
-func NewAlchemyClient(baseURL, apiKey string) *AlchemyClient {
+func NewAlchemyClient(cfg *config.Config, limiters *ratelimit.Registry, breakers *circuitbreaker.Registry, logger zerolog.Logger) *AlchemyClient {
 	return &AlchemyClient{
-		baseURL: baseURL,
-		apiKey:  apiKey,
+		apiKey:  cfg.Alchemy.APIKey,
+		rpcURLs: cfg.Alchemy.RPCURLs,
 		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout: cfg.Alchemy.Timeout,
 			Transport: &http.Transport{
 				MaxIdleConns:        100,
 				MaxIdleConnsPerHost: 10,
 				IdleConnTimeout:     90 * time.Second,
 			},
 		},
+		limiter: limiters.For("alchemy", cfg.Alchemy.APIKey),
+		breaker: breakers.For("alchemy"),
+		logger:  logger,
+	}
+}
+
+// GetFeeHistory returns the base fee and the given priority fee percentiles
+// of the last blockCount blocks, in wei per gas. BaseFeePerGas has one more
+// entry than the blocks: the base fee of the next block.
+func (c *AlchemyClient) GetFeeHistory(ctx context.Context, chainID string, blockCount int, rewardPercentiles []float64) (*domain.FeeHistory, error) {
+	var result struct {
+		OldestBlock   string     `json:"oldestBlock"`
+		BaseFeePerGas []string   `json:"baseFeePerGas"`
+		Reward        [][]string `json:"reward"`
+	}
+	params := []interface{}{fmt.Sprintf("0x%x", blockCount), "latest", rewardPercentiles}
+	if err := c.call(ctx, chainID, "eth_feeHistory", params, &result); err != nil {
+		return nil, err
+	}
+
+	history := &domain.FeeHistory{}
+	var err error
+	if history.OldestBlock, err = parseQuantity(result.OldestBlock); err != nil {
+		return nil, fmt.Errorf("invalid oldest block: %w", err)
+	}
+	for _, fee := range result.BaseFeePerGas {
+		wei, err := parseQuantity(fee)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base fee: %w", err)
+		}
+		history.BaseFeePerGas = append(history.BaseFeePerGas, wei)
+	}
+	for _, block := range result.Reward {
+		rewards := make([]uint64, 0, len(block))
+		for _, reward := range block {
+			wei, err := parseQuantity(reward)
+			if err != nil {
+				return nil, fmt.Errorf("invalid priority fee: %w", err)
+			}
+			rewards = append(rewards, wei)
+		}
+		history.Reward = append(history.Reward, rewards)
+	}
+	return history, nil
+}
+
+func (c *AlchemyClient) getRPCURL(chainID string) (string, error) {
+	rpcURL, exists := c.rpcURLs[chainID]
+	if !exists {
+		return "", fmt.Errorf("no RPC URL configured for chain: %s", chainID)
+	}
+	if c.apiKey != "" {
+		rpcURL = strings.TrimSuffix(rpcURL, "/") + "/" + c.apiKey
+	}
+	return rpcURL, nil
+}
+
+// call invokes an EVM JSON-RPC method and decodes its result into result.
+func (c *AlchemyClient) call(ctx context.Context, chainID, method string, params []interface{}, result interface{}) error {
+	rpcURL, err := c.getRPCURL(chainID)
+	if err != nil {
+		return err
+	}
+
+	bodyBytes, err := json.Marshal(rpcRequest{JSONRPC: "2.0", ID: 1, Method: method, Params: params})
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s request: %v", method, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", rpcURL, bytes.NewBuffer(bodyBytes))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	body, err := c.doRequest(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to call %s: %w", method, err)
+	}
+
+	var resp rpcResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return fmt.Errorf("failed to parse %s response: %v", method, err)
+	}
+	if resp.Error != nil {
+		return resp.Error
+	}
+	if err := json.Unmarshal(resp.Result, result); err != nil {
+		return fmt.Errorf("failed to parse %s result: %v", method, err)
+	}
+	return nil
+}
+
+// doRequest sends req through the rate limiter and circuit breaker and
+// returns the response body of a successful call.
+func (c *AlchemyClient) doRequest(ctx context.Context, req *http.Request) ([]byte, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("rate limiter wait failed: %w", err)
+	}
+
+	var body []byte
+	var clientErr error
+	err := c.breaker.Execute(func() error {
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		body, err = io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("failed to read response body: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			c.logger.Error().
+				Int("status_code", resp.StatusCode).
+				Str("response_body", string(body)).
+				Msg("Alchemy API request failed")
+			statusErr := fmt.Errorf("API request failed with status: %s", resp.Status)
+			if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError {
+				return statusErr
+			}
+			clientErr = statusErr
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if clientErr != nil {
+		return nil, clientErr
+	}
+	return body, nil
+}
+
+// parseQuantity decodes a hex-encoded JSON-RPC quantity such as "0x3b9aca00".
+func parseQuantity(quantity string) (uint64, error) {
+	if !strings.HasPrefix(quantity, "0x") {
+		return 0, fmt.Errorf("quantity %q is not hex encoded", quantity)
 	}
+	return strconv.ParseUint(quantity[2:], 16, 64)
 }
@@ -0,0 +1,29 @@
+package rpc
+
+import "testing"
+
+func TestParseQuantity(t *testing.T) {
+	tests := []struct {
+		quantity string
+		want     uint64
+		wantErr  bool
+	}{
+		{"0x0", 0, false},
+		{"0x3b9aca00", 1_000_000_000, false},
+		{"0xffffffffffffffff", 1<<64 - 1, false},
+		{"0x", 0, true},
+		{"1000", 0, true},
+		{"0xzz", 0, true},
+		{"0x10000000000000000", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseQuantity(tt.quantity)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseQuantity(%q) error = %v, wantErr %v", tt.quantity, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("parseQuantity(%q) = %d, want %d", tt.quantity, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package rpc
+
+import (
+	"testing"
+
+	"github.com/tuncanbit/tvs/internal/domain"
+)
+
+const (
+	receivedMint  = "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"
+	receivedOwner = "586Z7H2vpX9qNhN2T4e9Utugie3ogjbxzGaMtM3E6HR5"
+	ownerAccount  = "HKpJMFu3s2nEZ6WofQc3Xbb4RwGFb9AzTKdNwuZSvGGq"
+	senderOwner   = "FVen3X669xLzsi6N2V91DoiyzHzg1uAgqiT8jZ9nS96Z"
+	senderAccount = "HU2S9ByyqbnCD2SVfvr9qoLtDTtyTnMZoMaw1xpr6cTb"
+	otherMint     = "Es9vMFrzaCERmJfrF4H2FYD4KCoNkY11McCe8BenwNYB"
+)
+
+func balanceChange(userAccount, tokenAccount, mint, amount string) domain.TokenBalanceChange {
+	change := domain.TokenBalanceChange{UserAccount: userAccount, TokenAccount: tokenAccount, Mint: mint}
+	change.RawTokenAmount.TokenAmount = amount
+	change.RawTokenAmount.Decimals = 6
+	return change
+}
+
+func transactionWith(changes ...domain.TokenBalanceChange) domain.HeliusTransaction {
+	var tx domain.HeliusTransaction
+	for _, change := range changes {
+		tx.AccountData = append(tx.AccountData, domain.AccountData{
+			Account:             change.TokenAccount,
+			TokenBalanceChanges: []domain.TokenBalanceChange{change},
+		})
+	}
+	return tx
+}
+
+func TestReceivedTokenAmount(t *testing.T) {
+	tests := []struct {
+		name      string
+		tx        domain.HeliusTransaction
+		owner     string
+		want      int64
+		wantFound bool
+	}{
+		{
+			name: "credited to the owner's account",
+			tx: transactionWith(
+				balanceChange(senderOwner, senderAccount, receivedMint, "-1000000"),
+				balanceChange(receivedOwner, ownerAccount, receivedMint, "1000000"),
+			),
+			owner:     receivedOwner,
+			want:      1_000_000,
+			wantFound: true,
+		},
+		{
+			name: "looked up by token account",
+			tx: transactionWith(
+				balanceChange(receivedOwner, ownerAccount, receivedMint, "1000000"),
+			),
+			owner:     ownerAccount,
+			want:      1_000_000,
+			wantFound: true,
+		},
+		{
+			name: "transfer fee withheld",
+			tx: transactionWith(
+				balanceChange(senderOwner, senderAccount, receivedMint, "-1000000"),
+				balanceChange(receivedOwner, ownerAccount, receivedMint, "990000"),
+			),
+			owner:     receivedOwner,
+			want:      990_000,
+			wantFound: true,
+		},
+		{
+			name: "summed over the owner's accounts",
+			tx: transactionWith(
+				balanceChange(receivedOwner, ownerAccount, receivedMint, "400000"),
+				balanceChange(receivedOwner, senderAccount, receivedMint, "600000"),
+			),
+			owner:     receivedOwner,
+			want:      1_000_000,
+			wantFound: true,
+		},
+		{
+			name: "other mints ignored",
+			tx: transactionWith(
+				balanceChange(receivedOwner, ownerAccount, otherMint, "1000000"),
+			),
+			owner: receivedOwner,
+		},
+		{
+			name: "unparseable amounts skipped",
+			tx: transactionWith(
+				balanceChange(receivedOwner, ownerAccount, receivedMint, "1.5"),
+				balanceChange(receivedOwner, ownerAccount, receivedMint, "250000"),
+			),
+			owner:     receivedOwner,
+			want:      250_000,
+			wantFound: true,
+		},
+		{
+			name:  "no balance changes",
+			tx:    domain.HeliusTransaction{},
+			owner: receivedOwner,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := ReceivedTokenAmount(tt.tx, tt.owner, receivedMint)
+			if got != tt.want || found != tt.wantFound {
+				t.Errorf("ReceivedTokenAmount() = %d, %v, want %d, %v", got, found, tt.want, tt.wantFound)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package rpc
+
+import (
+	"fmt"
+
+	"github.com/tuncanbit/tvs/internal/domain"
+)
+
+// TokenTypeForCrypto maps a crypto currency code to the Solana token that
+// carries it.
+func TokenTypeForCrypto(crypto string) (domain.SPLTokenType, error) {
+	switch crypto {
+	case "SOL":
+		return domain.SPLTokenTypeSOL, nil
+	case "USDC":
+		return domain.SPLTokenTypeUSDC, nil
+	case "USDT":
+		return domain.SPLTokenTypeUSDT, nil
+	default:
+		return "", fmt.Errorf("unsupported crypto currency: %s", crypto)
+	}
+}
+
+// ClusterTypeForChain maps a chain ID to its Solana cluster.
+func ClusterTypeForChain(chainID string) (domain.SolanaClusterType, error) {
+	switch chainID {
+	case "sol-mainnet":
+		return domain.SolanaClusterTypeMainnet, nil
+	case "sol-testnet":
+		return domain.SolanaClusterTypeTestnet, nil
+	default:
+		return "", fmt.Errorf("unsupported Solana chain: %s", chainID)
+	}
+}
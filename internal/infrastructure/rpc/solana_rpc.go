@@ -0,0 +1,234 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/tuncanbit/tvs/internal/domain"
+)
+
+// SolanaRPC is what the withdrawal executor needs from a Solana node.
+// HeliusClient implements it; a mock or a client pointed at
+// solana-test-validator can stand in for tests.
+type SolanaRPC interface {
+	GetMintAddress(clusterType domain.SolanaClusterType, tokenType domain.SPLTokenType) (string, error)
+	GetDecimals(clusterType domain.SolanaClusterType, tokenType domain.SPLTokenType) (int, error)
+	GetLatestBlockhash(ctx context.Context, clusterType domain.SolanaClusterType) (string, uint64, error)
+	GetBlockHeight(ctx context.Context, clusterType domain.SolanaClusterType) (uint64, error)
+	SendTransaction(ctx context.Context, clusterType domain.SolanaClusterType, transaction []byte) (string, error)
+	GetSignatureStatus(ctx context.Context, clusterType domain.SolanaClusterType, signature string) (*domain.SignatureStatus, error)
+	InspectTokenDestination(ctx context.Context, clusterType domain.SolanaClusterType, tokenType domain.SPLTokenType, address string) (*domain.TokenDestination, error)
+}
+
+// accountDataPrefix is how much account data GetAccountInfo fetches: enough
+// to tell token accounts from mints and read their mint and owner.
+const accountDataPrefix = 166
+
+type rpcRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      int           `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *RPCError       `json:"error"`
+}
+
+// RPCError is an error returned by a Solana JSON-RPC node, such as a failed
+// preflight simulation.
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("rpc error %d: %s", e.Code, e.Message)
+}
+
+func (c *HeliusClient) getRPCURL(clusterType domain.SolanaClusterType) (string, error) {
+	rpcURL, exists := c.rpcURLs[string(clusterType)]
+	if !exists {
+		return "", fmt.Errorf("no RPC URL configured for cluster type: %s", clusterType)
+	}
+	parsed, err := url.Parse(rpcURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid RPC URL for cluster type %s: %w", clusterType, err)
+	}
+	if c.apiKey != "" {
+		query := parsed.Query()
+		query.Set("api-key", c.apiKey)
+		parsed.RawQuery = query.Encode()
+	}
+	return parsed.String(), nil
+}
+
+// call invokes a Solana JSON-RPC method and decodes its result into result.
+func (c *HeliusClient) call(ctx context.Context, clusterType domain.SolanaClusterType, method string, params []interface{}, result interface{}) error {
+	rpcURL, err := c.getRPCURL(clusterType)
+	if err != nil {
+		return err
+	}
+
+	bodyBytes, err := json.Marshal(rpcRequest{JSONRPC: "2.0", ID: 1, Method: method, Params: params})
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s request: %v", method, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", rpcURL, bytes.NewBuffer(bodyBytes))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	body, err := c.doRequest(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to call %s: %w", method, err)
+	}
+
+	var resp rpcResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return fmt.Errorf("failed to parse %s response: %v", method, err)
+	}
+	if resp.Error != nil {
+		return resp.Error
+	}
+	if err := json.Unmarshal(resp.Result, result); err != nil {
+		return fmt.Errorf("failed to parse %s result: %v", method, err)
+	}
+	return nil
+}
+
+// GetLatestBlockhash returns a recent blockhash and the last block height at
+// which a transaction using it can still land.
+func (c *HeliusClient) GetLatestBlockhash(ctx context.Context, clusterType domain.SolanaClusterType) (string, uint64, error) {
+	var result struct {
+		Value struct {
+			Blockhash            string `json:"blockhash"`
+			LastValidBlockHeight uint64 `json:"lastValidBlockHeight"`
+		} `json:"value"`
+	}
+	params := []interface{}{map[string]string{"commitment": "finalized"}}
+	if err := c.call(ctx, clusterType, "getLatestBlockhash", params, &result); err != nil {
+		return "", 0, err
+	}
+	return result.Value.Blockhash, result.Value.LastValidBlockHeight, nil
+}
+
+func (c *HeliusClient) GetBlockHeight(ctx context.Context, clusterType domain.SolanaClusterType) (uint64, error) {
+	var height uint64
+	params := []interface{}{map[string]string{"commitment": "confirmed"}}
+	if err := c.call(ctx, clusterType, "getBlockHeight", params, &height); err != nil {
+		return 0, err
+	}
+	return height, nil
+}
+
+// SendTransaction submits a signed, serialized transaction and returns its
+// signature. The node simulates it first, so transactions that would fail
+// are rejected with an *RPCError.
+func (c *HeliusClient) SendTransaction(ctx context.Context, clusterType domain.SolanaClusterType, transaction []byte) (string, error) {
+	var signature string
+	params := []interface{}{
+		base64.StdEncoding.EncodeToString(transaction),
+		map[string]interface{}{
+			"encoding":            "base64",
+			"preflightCommitment": "confirmed",
+			"maxRetries":          5,
+		},
+	}
+	if err := c.call(ctx, clusterType, "sendTransaction", params, &signature); err != nil {
+		return "", err
+	}
+	return signature, nil
+}
+
+// GetSignatureStatus returns the status of a transaction, or nil if the
+// cluster has not seen it.
+func (c *HeliusClient) GetSignatureStatus(ctx context.Context, clusterType domain.SolanaClusterType, signature string) (*domain.SignatureStatus, error) {
+	var result struct {
+		Value []*domain.SignatureStatus `json:"value"`
+	}
+	params := []interface{}{
+		[]string{signature},
+		map[string]bool{"searchTransactionHistory": true},
+	}
+	if err := c.call(ctx, clusterType, "getSignatureStatuses", params, &result); err != nil {
+		return nil, err
+	}
+	if len(result.Value) == 0 {
+		return nil, nil
+	}
+	return result.Value[0], nil
+}
+
+// GetRecentPrioritizationFees returns the prioritization fees, in
+// micro-lamports per compute unit, paid in recent slots by transactions that
+// write-lock any of accounts.
+func (c *HeliusClient) GetRecentPrioritizationFees(ctx context.Context, clusterType domain.SolanaClusterType, accounts []string) ([]uint64, error) {
+	var result []struct {
+		Slot              uint64 `json:"slot"`
+		PrioritizationFee uint64 `json:"prioritizationFee"`
+	}
+	params := []interface{}{}
+	if len(accounts) > 0 {
+		params = append(params, accounts)
+	}
+	if err := c.call(ctx, clusterType, "getRecentPrioritizationFees", params, &result); err != nil {
+		return nil, err
+	}
+	fees := make([]uint64, 0, len(result))
+	for _, entry := range result {
+		fees = append(fees, entry.PrioritizationFee)
+	}
+	return fees, nil
+}
+
+// GetAccountInfo returns an account's balance, owning program and the first
+// accountDataPrefix bytes of its data, or nil if the account does not exist.
+func (c *HeliusClient) GetAccountInfo(ctx context.Context, clusterType domain.SolanaClusterType, address string) (*domain.AccountInfo, error) {
+	var result struct {
+		Value *struct {
+			domain.AccountInfo
+			Data []string `json:"data"`
+		} `json:"value"`
+	}
+	params := []interface{}{
+		address,
+		map[string]interface{}{
+			"encoding":   "base64",
+			"commitment": "confirmed",
+			"dataSlice":  map[string]int{"offset": 0, "length": accountDataPrefix},
+		},
+	}
+	if err := c.call(ctx, clusterType, "getAccountInfo", params, &result); err != nil {
+		return nil, err
+	}
+	if result.Value == nil {
+		return nil, nil
+	}
+	info := result.Value.AccountInfo
+	if len(result.Value.Data) > 0 {
+		data, err := base64.StdEncoding.DecodeString(result.Value.Data[0])
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode account data: %v", err)
+		}
+		info.Data = data
+	}
+	return &info, nil
+}
+
+// GetMinimumBalanceForRentExemption returns the lamports an account of size
+// bytes must hold to be rent exempt.
+func (c *HeliusClient) GetMinimumBalanceForRentExemption(ctx context.Context, clusterType domain.SolanaClusterType, size uint64) (uint64, error) {
+	var lamports uint64
+	if err := c.call(ctx, clusterType, "getMinimumBalanceForRentExemption", []interface{}{size}, &lamports); err != nil {
+		return 0, err
+	}
+	return lamports, nil
+}
@@ -0,0 +1,129 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/tuncanbit/tvs/internal/domain"
+	"github.com/tuncanbit/tvs/pkg/solana"
+)
+
+var (
+	ErrInvalidDestination      = errors.New("destination is not a Solana address")
+	ErrDestinationNotWallet    = errors.New("destination is neither a wallet nor a token account")
+	ErrDestinationMintMismatch = errors.New("destination token account holds a different token")
+	ErrDestinationFrozen       = errors.New("destination token account is frozen")
+	ErrUnsupportedTokenProgram = errors.New("mint is not owned by the Token or Token-2022 program")
+)
+
+// InspectTokenDestination works out where a payout of tokenType to address
+// lands. A wallet, existing or not, receives into its associated token
+// account for the mint's program; a token account of the mint receives
+// directly. Programs, mints, token accounts of other mints, frozen token
+// accounts and accounts owned by any other program are rejected.
+func (c *HeliusClient) InspectTokenDestination(ctx context.Context, clusterType domain.SolanaClusterType, tokenType domain.SPLTokenType, address string) (*domain.TokenDestination, error) {
+	wallet, err := solana.ParsePublicKey(address)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDestination, err)
+	}
+	mintAddress, err := c.GetMintAddress(clusterType, tokenType)
+	if err != nil {
+		return nil, err
+	}
+	mint, err := solana.ParsePublicKey(mintAddress)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mint address for %s: %w", tokenType, err)
+	}
+	program, err := c.MintProgram(ctx, clusterType, mint)
+	if err != nil {
+		return nil, err
+	}
+	destination := &domain.TokenDestination{Address: address, TokenProgram: program.String()}
+
+	account, err := c.GetAccountInfo(ctx, clusterType, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up destination: %w", err)
+	}
+	if account != nil {
+		owner, err := solana.ParsePublicKey(account.Owner)
+		if err != nil {
+			return nil, fmt.Errorf("invalid destination owner: %w", err)
+		}
+		switch {
+		case solana.IsTokenProgram(owner):
+			tokenAccount, err := solana.DecodeTokenAccount(owner, account.Data, account.Space)
+			if errors.Is(err, solana.ErrTokenAccountFrozen) {
+				return nil, fmt.Errorf("%w: %s", ErrDestinationFrozen, address)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrDestinationNotWallet, err)
+			}
+			if tokenAccount.Mint != mint {
+				return nil, fmt.Errorf("%w: %s, not %s", ErrDestinationMintMismatch, tokenAccount.Mint, mint)
+			}
+			destination.Owner = tokenAccount.Owner.String()
+			destination.TokenAccount = address
+			destination.IsTokenAccount = true
+			return destination, nil
+		case account.Executable:
+			return nil, fmt.Errorf("%w: %s is a program", ErrDestinationNotWallet, address)
+		case owner != solana.SystemProgramID:
+			return nil, fmt.Errorf("%w: owned by %s", ErrDestinationNotWallet, owner)
+		}
+	}
+
+	tokenAccount, err := solana.AssociatedTokenAddress(wallet, mint, program)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive token account: %w", err)
+	}
+	destination.Owner = address
+	destination.TokenAccount = tokenAccount.String()
+
+	existing, err := c.GetAccountInfo(ctx, clusterType, tokenAccount.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up token account: %w", err)
+	}
+	if existing != nil {
+		if _, err := solana.DecodeTokenAccount(program, existing.Data, existing.Space); errors.Is(err, solana.ErrTokenAccountFrozen) {
+			return nil, fmt.Errorf("%w: %s", ErrDestinationFrozen, tokenAccount)
+		}
+	} else {
+		size := uint64(solana.TokenAccountSize)
+		if program == solana.Token2022ProgramID {
+			// Mints with extensions that accounts must carry, such as
+			// transfer fees, make the account and its rent slightly larger.
+			size = solana.Token2022AssociatedAccountSize
+		}
+		rent, err := c.GetMinimumBalanceForRentExemption(ctx, clusterType, size)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get token account rent: %w", err)
+		}
+		destination.RequiresTokenAccount = true
+		destination.TokenAccountRent = rent
+	}
+	return destination, nil
+}
+
+// MintProgram returns the token program that owns mint. A mint never changes
+// program, so the answer is cached for the life of the client.
+func (c *HeliusClient) MintProgram(ctx context.Context, clusterType domain.SolanaClusterType, mint solana.PublicKey) (solana.PublicKey, error) {
+	key := string(clusterType) + ":" + mint.String()
+	if program, ok := c.mintPrograms.Load(key); ok {
+		return program.(solana.PublicKey), nil
+	}
+
+	account, err := c.GetAccountInfo(ctx, clusterType, mint.String())
+	if err != nil {
+		return solana.PublicKey{}, fmt.Errorf("failed to look up mint: %w", err)
+	}
+	if account == nil {
+		return solana.PublicKey{}, fmt.Errorf("mint %s does not exist on %s", mint, clusterType)
+	}
+	program, err := solana.ParsePublicKey(account.Owner)
+	if err != nil || !solana.IsTokenProgram(program) {
+		return solana.PublicKey{}, fmt.Errorf("%w: %s is owned by %s", ErrUnsupportedTokenProgram, mint, account.Owner)
+	}
+	c.mintPrograms.Store(key, program)
+	return program, nil
+}
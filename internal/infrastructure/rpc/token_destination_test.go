@@ -0,0 +1,194 @@
+package rpc
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/internal/domain"
+	"github.com/tuncanbit/tvs/pkg/circuitbreaker"
+	"github.com/tuncanbit/tvs/pkg/config"
+	"github.com/tuncanbit/tvs/pkg/ratelimit"
+	"github.com/tuncanbit/tvs/pkg/solana"
+)
+
+const tokenAccountRent = 2_039_280
+
+var (
+	testMint   = solana.MustParsePublicKey("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v")
+	testWallet = solana.MustParsePublicKey("FVen3X669xLzsi6N2V91DoiyzHzg1uAgqiT8jZ9nS96Z")
+)
+
+// fakeAccount is an account served by the fake node.
+type fakeAccount struct {
+	owner solana.PublicKey
+	space uint64
+	data  []byte
+}
+
+// newFakeNode serves getAccountInfo from accounts, reporting any other
+// address as missing, and a fixed token account rent.
+func newFakeNode(t *testing.T, accounts map[string]fakeAccount) *HeliusClient {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("invalid request: %v", err)
+			return
+		}
+		var result interface{}
+		switch req.Method {
+		case "getAccountInfo":
+			var address string
+			json.Unmarshal(req.Params[0], &address)
+			value := map[string]interface{}(nil)
+			if account, ok := accounts[address]; ok {
+				value = map[string]interface{}{
+					"lamports":   tokenAccountRent,
+					"owner":      account.owner.String(),
+					"executable": false,
+					"space":      account.space,
+					"data":       []string{base64.StdEncoding.EncodeToString(account.data), "base64"},
+				}
+			}
+			result = map[string]interface{}{"value": value}
+		case "getMinimumBalanceForRentExemption":
+			result = tokenAccountRent
+		default:
+			t.Errorf("unexpected method %s", req.Method)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": 1, "result": result})
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := &config.Config{
+		Helius: config.HeliusConfig{
+			RPCURLs: map[string]string{string(domain.SolanaClusterTypeDevnet): server.URL},
+			Timeout: 5 * time.Second,
+		},
+		MintAddresses: map[string]map[string]string{
+			string(domain.SolanaClusterTypeDevnet): {string(domain.SPLTokenTypeUSDC): testMint.String()},
+		},
+	}
+	logger := zerolog.Nop()
+	return NewHeliusClient(cfg, ratelimit.NewRegistry(nil, logger), circuitbreaker.NewRegistry(nil, logger), logger)
+}
+
+// tokenAccount lays out the data of a token account of testMint owned by
+// owner in state: 1 initialized, 2 frozen.
+func tokenAccount(owner solana.PublicKey, state byte) fakeAccount {
+	data := make([]byte, solana.TokenAccountSize)
+	copy(data, testMint[:])
+	copy(data[solana.PublicKeyLength:], owner[:])
+	data[108] = state
+	return fakeAccount{owner: solana.TokenProgramID, space: solana.TokenAccountSize, data: data}
+}
+
+func TestInspectTokenDestination(t *testing.T) {
+	ata, err := solana.AssociatedTokenAddress(testWallet, testMint, solana.TokenProgramID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mint := fakeAccount{owner: solana.TokenProgramID, space: solana.MintSize, data: make([]byte, solana.MintSize)}
+
+	tests := []struct {
+		name     string
+		accounts map[string]fakeAccount
+		address  string
+		want     domain.TokenDestination
+		wantErr  error
+	}{
+		{
+			name:     "wallet without token account",
+			accounts: map[string]fakeAccount{testMint.String(): mint},
+			address:  testWallet.String(),
+			want: domain.TokenDestination{
+				Owner:                testWallet.String(),
+				TokenAccount:         ata.String(),
+				RequiresTokenAccount: true,
+				TokenAccountRent:     tokenAccountRent,
+			},
+		},
+		{
+			name: "wallet with token account",
+			accounts: map[string]fakeAccount{
+				testMint.String(): mint,
+				ata.String():      tokenAccount(testWallet, 1),
+			},
+			address: testWallet.String(),
+			want:    domain.TokenDestination{Owner: testWallet.String(), TokenAccount: ata.String()},
+		},
+		{
+			name: "wallet with frozen token account",
+			accounts: map[string]fakeAccount{
+				testMint.String(): mint,
+				ata.String():      tokenAccount(testWallet, 2),
+			},
+			address: testWallet.String(),
+			wantErr: ErrDestinationFrozen,
+		},
+		{
+			name: "token account",
+			accounts: map[string]fakeAccount{
+				testMint.String(): mint,
+				ata.String():      tokenAccount(testWallet, 1),
+			},
+			address: ata.String(),
+			want: domain.TokenDestination{
+				Owner:          testWallet.String(),
+				TokenAccount:   ata.String(),
+				IsTokenAccount: true,
+			},
+		},
+		{
+			name: "frozen token account",
+			accounts: map[string]fakeAccount{
+				testMint.String(): mint,
+				ata.String():      tokenAccount(testWallet, 2),
+			},
+			address: ata.String(),
+			wantErr: ErrDestinationFrozen,
+		},
+		{
+			name: "uninitialized token account",
+			accounts: map[string]fakeAccount{
+				testMint.String(): mint,
+				ata.String():      tokenAccount(testWallet, 0),
+			},
+			address: ata.String(),
+			wantErr: ErrDestinationNotWallet,
+		},
+		{
+			name:     "mint",
+			accounts: map[string]fakeAccount{testMint.String(): mint},
+			address:  testMint.String(),
+			wantErr:  ErrDestinationNotWallet,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newFakeNode(t, tt.accounts)
+			got, err := client.InspectTokenDestination(context.Background(), domain.SolanaClusterTypeDevnet, domain.SPLTokenTypeUSDC, tt.address)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("InspectTokenDestination() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			tt.want.Address = tt.address
+			tt.want.TokenProgram = solana.TokenProgramID.String()
+			if *got != tt.want {
+				t.Errorf("InspectTokenDestination() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
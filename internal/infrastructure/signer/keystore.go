@@ -0,0 +1,136 @@
+package signer
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/tuncanbit/tvs/pkg/base58"
+	"github.com/tuncanbit/tvs/pkg/kdf"
+)
+
+// KeystoreEntry is one key in a keystore file: a 64-byte ed25519 private key
+// (the Solana keypair format) sealed with AES-256-GCM under a key stretched
+// from the passphrase with scrypt and the entry's salt.
+type KeystoreEntry struct {
+	Address    string `json:"address"`
+	Salt       string `json:"salt"`
+	Nonce      string `json:"nonce"`
+	Ciphertext string `json:"ciphertext"`
+}
+
+type keystoreFile struct {
+	Keys []KeystoreEntry `json:"keys"`
+}
+
+// LocalKeystore signs with keys decrypted from a keystore file at startup.
+type LocalKeystore struct {
+	keys map[string]ed25519.PrivateKey
+}
+
+// LoadKeystore reads and decrypts the keystore at path with passphrase. Each
+// key must match the address it is stored under.
+func LoadKeystore(path, passphrase string) (*LocalKeystore, error) {
+	if passphrase == "" {
+		return nil, fmt.Errorf("keystore passphrase is empty")
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore %s: %w", path, err)
+	}
+	var file keystoreFile
+	if err := json.Unmarshal(raw, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse keystore %s: %w", path, err)
+	}
+
+	keystore := &LocalKeystore{keys: make(map[string]ed25519.PrivateKey, len(file.Keys))}
+	for _, entry := range file.Keys {
+		salt, err := base64.StdEncoding.DecodeString(entry.Salt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid salt for %s: %w", entry.Address, err)
+		}
+		aead, err := keystoreCipher(passphrase, salt)
+		if err != nil {
+			return nil, fmt.Errorf("key for %s: %w", entry.Address, err)
+		}
+		nonce, err := base64.StdEncoding.DecodeString(entry.Nonce)
+		if err != nil {
+			return nil, fmt.Errorf("invalid nonce for %s: %w", entry.Address, err)
+		}
+		ciphertext, err := base64.StdEncoding.DecodeString(entry.Ciphertext)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ciphertext for %s: %w", entry.Address, err)
+		}
+		plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(entry.Address))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt key for %s: %w", entry.Address, err)
+		}
+		if len(plaintext) != ed25519.PrivateKeySize {
+			return nil, fmt.Errorf("key for %s is %d bytes, want %d", entry.Address, len(plaintext), ed25519.PrivateKeySize)
+		}
+		key := ed25519.PrivateKey(plaintext)
+		if base58.Encode(key.Public().(ed25519.PublicKey)) != entry.Address {
+			return nil, fmt.Errorf("key stored under %s belongs to a different address", entry.Address)
+		}
+		keystore.keys[entry.Address] = key
+	}
+	return keystore, nil
+}
+
+// SealKey encrypts a 64-byte ed25519 private key into a keystore entry, for
+// tooling that provisions keystore files.
+func SealKey(privateKey ed25519.PrivateKey, passphrase string) (KeystoreEntry, error) {
+	if len(privateKey) != ed25519.PrivateKeySize {
+		return KeystoreEntry{}, fmt.Errorf("private key is %d bytes, want %d", len(privateKey), ed25519.PrivateKeySize)
+	}
+	salt, err := kdf.NewSalt()
+	if err != nil {
+		return KeystoreEntry{}, err
+	}
+	aead, err := keystoreCipher(passphrase, salt)
+	if err != nil {
+		return KeystoreEntry{}, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return KeystoreEntry{}, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	address := base58.Encode(privateKey.Public().(ed25519.PublicKey))
+	return KeystoreEntry{
+		Address:    address,
+		Salt:       base64.StdEncoding.EncodeToString(salt),
+		Nonce:      base64.StdEncoding.EncodeToString(nonce),
+		Ciphertext: base64.StdEncoding.EncodeToString(aead.Seal(nil, nonce, privateKey, []byte(address))),
+	}, nil
+}
+
+func (k *LocalKeystore) Sign(_ context.Context, address string, message []byte) ([]byte, error) {
+	key, ok := k.keys[address]
+	if !ok {
+		return nil, fmt.Errorf("%w %s", ErrUnknownKey, address)
+	}
+	return ed25519.Sign(key, message), nil
+}
+
+func (k *LocalKeystore) HasKey(address string) bool {
+	_, ok := k.keys[address]
+	return ok
+}
+
+func keystoreCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := kdf.Passphrase(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create keystore cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,96 @@
+package signer
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tuncanbit/tvs/pkg/base58"
+)
+
+func writeKeystore(t *testing.T, entries ...KeystoreEntry) string {
+	t.Helper()
+	raw, err := json.Marshal(keystoreFile{Keys: entries})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "keystore.json")
+	if err := os.WriteFile(path, raw, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestKeystoreRoundTrip(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry, err := SealKey(key, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := base58.Encode(key.Public().(ed25519.PublicKey))
+	if entry.Address != address || entry.Salt == "" {
+		t.Fatalf("SealKey() = %+v, want address %s and a salt", entry, address)
+	}
+
+	keystore, err := LoadKeystore(writeKeystore(t, entry), "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := []byte("payout")
+	signature, err := keystore.Sign(context.Background(), address, message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ed25519.Verify(key.Public().(ed25519.PublicKey), message, signature) {
+		t.Error("signature does not verify")
+	}
+}
+
+func TestSealKeyUsesFreshSalt(t *testing.T) {
+	_, key, _ := ed25519.GenerateKey(nil)
+	first, err := SealKey(key, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := SealKey(key, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Salt == second.Salt {
+		t.Error("two seals of the same key share a salt")
+	}
+}
+
+func TestLoadKeystoreRejects(t *testing.T) {
+	_, key, _ := ed25519.GenerateKey(nil)
+	entry, err := SealKey(key, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	unsalted := entry
+	unsalted.Salt = ""
+	_, otherKey, _ := ed25519.GenerateKey(nil)
+	misfiled := entry
+	misfiled.Address = base58.Encode(otherKey.Public().(ed25519.PublicKey))
+
+	tests := []struct {
+		name       string
+		entry      KeystoreEntry
+		passphrase string
+	}{
+		{"wrong passphrase", entry, "battery staple"},
+		{"missing salt", unsalted, "correct horse"},
+		{"entry under another address", misfiled, "correct horse"},
+	}
+	for _, tt := range tests {
+		if _, err := LoadKeystore(writeKeystore(t, tt.entry), tt.passphrase); err == nil {
+			t.Errorf("%s: LoadKeystore succeeded", tt.name)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package signer
+
+import (
+	"context"
+	"fmt"
+)
+
+// KMSClient is the part of a key management service the KMS signer needs:
+// signing a message with an ed25519 key that never leaves the service.
+type KMSClient interface {
+	Sign(ctx context.Context, keyID string, message []byte) ([]byte, error)
+}
+
+// KMSSigner signs through a KMSClient, mapping each address to the ID of
+// the KMS key behind it.
+type KMSSigner struct {
+	client KMSClient
+	keyIDs map[string]string // address -> KMS key ID
+}
+
+func NewKMSSigner(client KMSClient, keyIDs map[string]string) *KMSSigner {
+	return &KMSSigner{
+		client: client,
+		keyIDs: keyIDs,
+	}
+}
+
+func (s *KMSSigner) Sign(ctx context.Context, address string, message []byte) ([]byte, error) {
+	keyID, ok := s.keyIDs[address]
+	if !ok {
+		return nil, fmt.Errorf("%w %s", ErrUnknownKey, address)
+	}
+	signature, err := s.client.Sign(ctx, keyID, message)
+	if err != nil {
+		return nil, fmt.Errorf("kms failed to sign for %s: %w", address, err)
+	}
+	return signature, nil
+}
+
+func (s *KMSSigner) HasKey(address string) bool {
+	_, ok := s.keyIDs[address]
+	return ok
+}
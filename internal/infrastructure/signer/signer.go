@@ -0,0 +1,18 @@
+package signer
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrUnknownKey = errors.New("signer holds no key for address")
+
+// Signer produces ed25519 signatures with keys it holds, identified by their
+// base58 Solana address. Implementations never hand out private keys, so a
+// KMS or HSM backend can replace the local keystore.
+type Signer interface {
+	// Sign returns the 64-byte signature of message by the key for address.
+	Sign(ctx context.Context, address string, message []byte) ([]byte, error)
+	// HasKey reports whether the signer can sign for address.
+	HasKey(address string) bool
+}
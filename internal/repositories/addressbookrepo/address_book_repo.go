@@ -0,0 +1,31 @@
+package addressbookrepo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/tuncanbit/tvs/internal/domain"
+)
+
+var (
+	ErrAddressExists   = errors.New("address is already in the address book")
+	ErrAddressNotFound = errors.New("address book entry not found")
+)
+
+type IAddressBookRepository interface {
+	// CreateAddress adds an entry, returning ErrAddressExists if the user has
+	// already saved the address on the chain.
+	CreateAddress(ctx context.Context, entry domain.WithdrawalAddress) (*domain.WithdrawalAddress, error)
+	ListAddresses(ctx context.Context, userID string) ([]domain.WithdrawalAddress, error)
+	// GetAddress returns the user's entry for the address on the chain, or
+	// ErrAddressNotFound.
+	GetAddress(ctx context.Context, userID, chainID, address string) (*domain.WithdrawalAddress, error)
+	UpdateLabel(ctx context.Context, userID, id, label string) (*domain.WithdrawalAddress, error)
+	// DeleteAddress removes the entry and returns it, or ErrAddressNotFound.
+	DeleteAddress(ctx context.Context, userID, id string) (*domain.WithdrawalAddress, error)
+	// GetSettings returns the user's settings, which default to strict mode
+	// off for users who never changed them.
+	GetSettings(ctx context.Context, userID string) (*domain.AddressBookSettings, error)
+	SaveSettings(ctx context.Context, userID string, strictMode bool, strictModeEndsAt *time.Time) (*domain.AddressBookSettings, error)
+}
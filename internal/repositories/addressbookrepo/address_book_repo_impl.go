@@ -0,0 +1,207 @@
+package addressbookrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/internal/domain"
+	"github.com/tuncanbit/tvs/internal/repositories/addressbookrepo/gen"
+)
+
+const uniqueViolation = "23505"
+
+type AddressBookRepository struct {
+	db      *sql.DB
+	queries *gen.Queries
+	logger  zerolog.Logger
+}
+
+func New(db *sql.DB, logger zerolog.Logger) IAddressBookRepository {
+	return &AddressBookRepository{
+		db:      db,
+		queries: gen.New(db),
+		logger:  logger,
+	}
+}
+
+func (r *AddressBookRepository) CreateAddress(ctx context.Context, entry domain.WithdrawalAddress) (*domain.WithdrawalAddress, error) {
+	userUUID, err := uuid.Parse(entry.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user_id format: %w", err)
+	}
+
+	row, err := r.queries.CreateWithdrawalAddress(ctx, gen.CreateWithdrawalAddressParams{
+		UserID:      userUUID,
+		ChainID:     entry.ChainID,
+		Address:     entry.Address,
+		Label:       entry.Label,
+		AvailableAt: entry.AvailableAt,
+	})
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return nil, ErrAddressExists
+		}
+		return nil, fmt.Errorf("failed to add address %s on %s: %w", entry.Address, entry.ChainID, err)
+	}
+	return mapDBAddress(row), nil
+}
+
+func (r *AddressBookRepository) ListAddresses(ctx context.Context, userID string) ([]domain.WithdrawalAddress, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user_id format: %w", err)
+	}
+
+	rows, err := r.queries.ListWithdrawalAddresses(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list addresses of user %s: %w", userID, err)
+	}
+	addresses := make([]domain.WithdrawalAddress, len(rows))
+	for i, row := range rows {
+		addresses[i] = *mapDBAddress(row)
+	}
+	return addresses, nil
+}
+
+func (r *AddressBookRepository) GetAddress(ctx context.Context, userID, chainID, address string) (*domain.WithdrawalAddress, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user_id format: %w", err)
+	}
+
+	row, err := r.queries.GetWithdrawalAddress(ctx, gen.GetWithdrawalAddressParams{
+		UserID:  userUUID,
+		ChainID: chainID,
+		Address: address,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrAddressNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get address %s on %s: %w", address, chainID, err)
+	}
+	return mapDBAddress(row), nil
+}
+
+func (r *AddressBookRepository) UpdateLabel(ctx context.Context, userID, id, label string) (*domain.WithdrawalAddress, error) {
+	userUUID, idUUID, err := parseEntryIDs(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := r.queries.UpdateWithdrawalAddressLabel(ctx, gen.UpdateWithdrawalAddressLabelParams{
+		ID:     idUUID,
+		UserID: userUUID,
+		Label:  label,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrAddressNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update label of address %s: %w", id, err)
+	}
+	return mapDBAddress(row), nil
+}
+
+func (r *AddressBookRepository) DeleteAddress(ctx context.Context, userID, id string) (*domain.WithdrawalAddress, error) {
+	userUUID, idUUID, err := parseEntryIDs(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := r.queries.DeleteWithdrawalAddress(ctx, gen.DeleteWithdrawalAddressParams{
+		ID:     idUUID,
+		UserID: userUUID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrAddressNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete address %s: %w", id, err)
+	}
+	return mapDBAddress(row), nil
+}
+
+func (r *AddressBookRepository) GetSettings(ctx context.Context, userID string) (*domain.AddressBookSettings, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user_id format: %w", err)
+	}
+
+	row, err := r.queries.GetWithdrawalAddressSettings(ctx, userUUID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &domain.AddressBookSettings{UserID: userID}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get address book settings of user %s: %w", userID, err)
+	}
+	return mapDBSettings(row), nil
+}
+
+func (r *AddressBookRepository) SaveSettings(ctx context.Context, userID string, strictMode bool, strictModeEndsAt *time.Time) (*domain.AddressBookSettings, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user_id format: %w", err)
+	}
+
+	var endsAt sql.NullTime
+	if strictModeEndsAt != nil {
+		endsAt = sql.NullTime{Time: *strictModeEndsAt, Valid: true}
+	}
+	row, err := r.queries.UpsertWithdrawalAddressSettings(ctx, gen.UpsertWithdrawalAddressSettingsParams{
+		UserID:           userUUID,
+		StrictMode:       strictMode,
+		StrictModeEndsAt: endsAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save address book settings of user %s: %w", userID, err)
+	}
+	return mapDBSettings(row), nil
+}
+
+// parseEntryIDs parses the owner and ID of an entry. A malformed entry ID
+// cannot match any entry, so it is reported as not found.
+func parseEntryIDs(userID, id string) (uuid.UUID, uuid.UUID, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return uuid.UUID{}, uuid.UUID{}, fmt.Errorf("invalid user_id format: %w", err)
+	}
+	idUUID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.UUID{}, uuid.UUID{}, ErrAddressNotFound
+	}
+	return userUUID, idUUID, nil
+}
+
+func mapDBAddress(row gen.WithdrawalAddress) *domain.WithdrawalAddress {
+	return &domain.WithdrawalAddress{
+		ID:          row.ID.String(),
+		UserID:      row.UserID.String(),
+		ChainID:     row.ChainID,
+		Address:     row.Address,
+		Label:       row.Label,
+		AvailableAt: row.AvailableAt,
+		CreatedAt:   row.CreatedAt.Time,
+		UpdatedAt:   row.UpdatedAt.Time,
+	}
+}
+
+func mapDBSettings(row gen.WithdrawalAddressSetting) *domain.AddressBookSettings {
+	settings := &domain.AddressBookSettings{
+		UserID:     row.UserID.String(),
+		StrictMode: row.StrictMode,
+		UpdatedAt:  row.UpdatedAt.Time,
+	}
+	if row.StrictModeEndsAt.Valid {
+		endsAt := row.StrictModeEndsAt.Time
+		settings.StrictModeEndsAt = &endsAt
+	}
+	return settings
+}
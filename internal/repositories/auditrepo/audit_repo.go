@@ -0,0 +1,12 @@
+package auditrepo
+
+import (
+	"context"
+
+	"github.com/tuncanbit/tvs/internal/domain"
+)
+
+type IAuditRepository interface {
+	CreateAuditLog(ctx context.Context, entry domain.AuditLog) error
+	ListForEntity(ctx context.Context, entityType, entityID string) ([]domain.AuditLog, error)
+}
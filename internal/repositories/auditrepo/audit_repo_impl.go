@@ -0,0 +1,85 @@
+package auditrepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/sqlc-dev/pqtype"
+	"github.com/tuncanbit/tvs/internal/domain"
+	"github.com/tuncanbit/tvs/internal/repositories/auditrepo/gen"
+	"github.com/tuncanbit/tvs/pkg/db"
+)
+
+type AuditRepository struct {
+	db      *sql.DB
+	queries *gen.Queries
+	logger  zerolog.Logger
+}
+
+func New(db *sql.DB, logger zerolog.Logger) IAuditRepository {
+	return &AuditRepository{
+		db:      db,
+		queries: gen.New(db),
+		logger:  logger,
+	}
+}
+
+func (r *AuditRepository) CreateAuditLog(ctx context.Context, entry domain.AuditLog) error {
+	var adminID uuid.NullUUID
+	if entry.AdminID != "" {
+		parsed, err := uuid.Parse(entry.AdminID)
+		if err != nil {
+			return fmt.Errorf("invalid admin ID %s: %w", entry.AdminID, err)
+		}
+		adminID = uuid.NullUUID{UUID: parsed, Valid: true}
+	}
+
+	err := r.queries.CreateAuditLog(ctx, gen.CreateAuditLogParams{
+		Action:     entry.Action,
+		EntityType: entry.EntityType,
+		EntityID:   entry.EntityID,
+		AdminID:    adminID,
+		OldValues:  pqtype.NullRawMessage{RawMessage: entry.OldValues, Valid: len(entry.OldValues) > 0},
+		NewValues:  pqtype.NullRawMessage{RawMessage: entry.NewValues, Valid: len(entry.NewValues) > 0},
+		IpAddress:  db.Inet(entry.IPAddress),
+		UserAgent:  sql.NullString{String: entry.UserAgent, Valid: entry.UserAgent != ""},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write audit log %s for %s %s: %w", entry.Action, entry.EntityType, entry.EntityID, err)
+	}
+	return nil
+}
+
+func (r *AuditRepository) ListForEntity(ctx context.Context, entityType, entityID string) ([]domain.AuditLog, error) {
+	rows, err := r.queries.ListAuditLogsForEntity(ctx, gen.ListAuditLogsForEntityParams{
+		EntityType: entityType,
+		EntityID:   entityID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit logs for %s %s: %w", entityType, entityID, err)
+	}
+
+	entries := make([]domain.AuditLog, len(rows))
+	for i, row := range rows {
+		entries[i] = domain.AuditLog{
+			ID:         row.ID.String(),
+			Action:     row.Action,
+			EntityType: row.EntityType,
+			EntityID:   row.EntityID,
+			OldValues:  row.OldValues.RawMessage,
+			NewValues:  row.NewValues.RawMessage,
+			UserAgent:  row.UserAgent.String,
+			CreatedAt:  row.CreatedAt.Time,
+		}
+		if row.AdminID.Valid {
+			entries[i].AdminID = row.AdminID.UUID.String()
+		}
+		if row.IpAddress.Valid {
+			entries[i].IPAddress = row.IpAddress.IPNet.IP.String()
+		}
+	}
+	return entries, nil
+}
@@ -152,6 +152,9 @@ const (
 	DepositSessionStatusFailed     DepositSessionStatus = "failed"
 	DepositSessionStatusCancelled  DepositSessionStatus = "cancelled"
 	DepositSessionStatusExpired    DepositSessionStatus = "expired"
+	DepositSessionStatusHeld       DepositSessionStatus = "held"
+	DepositSessionStatusLimitHeld  DepositSessionStatus = "limit_held"
+	DepositSessionStatusRefunded   DepositSessionStatus = "refunded"
 )
 
 func (e *DepositSessionStatus) Scan(src interface{}) error {
@@ -356,6 +359,35 @@ type CurrencyConfig struct {
 	CreatedAt        sql.NullTime   `json:"created_at"`
 }
 
+type DepositLimit struct {
+	UserID               uuid.UUID     `json:"user_id"`
+	Period               string        `json:"period"`
+	LimitUsdCents        int64         `json:"limit_usd_cents"`
+	PendingLimitUsdCents sql.NullInt64 `json:"pending_limit_usd_cents"`
+	PendingEffectiveAt   sql.NullTime  `json:"pending_effective_at"`
+	SetBy                uuid.NullUUID `json:"set_by"`
+	CreatedAt            sql.NullTime  `json:"created_at"`
+	UpdatedAt            sql.NullTime  `json:"updated_at"`
+}
+
+type DepositQuote struct {
+	ID              uuid.UUID    `json:"id"`
+	SessionID       string       `json:"session_id"`
+	UserID          uuid.UUID    `json:"user_id"`
+	CryptoCurrency  string       `json:"crypto_currency"`
+	FiatCurrency    string       `json:"fiat_currency"`
+	FiatAmountCents int64        `json:"fiat_amount_cents"`
+	CryptoAmount    string       `json:"crypto_amount"`
+	ExchangeRate    string       `json:"exchange_rate"`
+	PriceUsd        string       `json:"price_usd"`
+	SlippageBps     int32        `json:"slippage_bps"`
+	Status          string       `json:"status"`
+	ExpiresAt       time.Time    `json:"expires_at"`
+	SettledAt       sql.NullTime `json:"settled_at"`
+	CreatedAt       sql.NullTime `json:"created_at"`
+	UpdatedAt       sql.NullTime `json:"updated_at"`
+}
+
 type DepositSession struct {
 	ID             uuid.UUID             `json:"id"`
 	SessionID      string                `json:"session_id"`
@@ -404,6 +436,24 @@ type ManualFund struct {
 	CreatedAt     sql.NullTime `json:"created_at"`
 }
 
+type NetworkFee struct {
+	ID               uuid.UUID      `json:"id"`
+	ChainID          string         `json:"chain_id"`
+	TxHash           string         `json:"tx_hash"`
+	TransactionType  string         `json:"transaction_type"`
+	WithdrawalID     sql.NullString `json:"withdrawal_id"`
+	DepositSessionID sql.NullString `json:"deposit_session_id"`
+	FeeCurrency      string         `json:"fee_currency"`
+	FeeBaseUnits     int64          `json:"fee_base_units"`
+	FeePayer         string         `json:"fee_payer"`
+	PaidBy           string         `json:"paid_by"`
+	UsdCents         int64          `json:"usd_cents"`
+	PriceUsd         sql.NullString `json:"price_usd"`
+	ChargedCurrency  sql.NullString `json:"charged_currency"`
+	ChargedCents     int64          `json:"charged_cents"`
+	CreatedAt        sql.NullTime   `json:"created_at"`
+}
+
 type OperationalGroup struct {
 	ID          uuid.UUID      `json:"id"`
 	Name        string         `json:"name"`
@@ -419,6 +469,15 @@ type OperationalType struct {
 	CreatedAt   sql.NullTime   `json:"created_at"`
 }
 
+type SelfExclusion struct {
+	ID        uuid.UUID     `json:"id"`
+	UserID    uuid.UUID     `json:"user_id"`
+	StartsAt  time.Time     `json:"starts_at"`
+	EndsAt    time.Time     `json:"ends_at"`
+	CreatedBy uuid.NullUUID `json:"created_by"`
+	CreatedAt sql.NullTime  `json:"created_at"`
+}
+
 type ServiceApiKey struct {
 	ID              uuid.UUID    `json:"id"`
 	IssuerService   string       `json:"issuer_service"`
@@ -585,3 +644,32 @@ type Withdrawal struct {
 	CreatedAt             sql.NullTime          `json:"created_at"`
 	UpdatedAt             sql.NullTime          `json:"updated_at"`
 }
+
+type WithdrawalAddress struct {
+	ID          uuid.UUID    `json:"id"`
+	UserID      uuid.UUID    `json:"user_id"`
+	ChainID     string       `json:"chain_id"`
+	Address     string       `json:"address"`
+	Label       string       `json:"label"`
+	AvailableAt time.Time    `json:"available_at"`
+	CreatedAt   sql.NullTime `json:"created_at"`
+	UpdatedAt   sql.NullTime `json:"updated_at"`
+}
+
+type WithdrawalAddressSetting struct {
+	UserID           uuid.UUID    `json:"user_id"`
+	StrictMode       bool         `json:"strict_mode"`
+	StrictModeEndsAt sql.NullTime `json:"strict_mode_ends_at"`
+	UpdatedAt        sql.NullTime `json:"updated_at"`
+}
+
+type WithdrawalApproval struct {
+	ID           uuid.UUID      `json:"id"`
+	WithdrawalID string         `json:"withdrawal_id"`
+	AdminID      uuid.UUID      `json:"admin_id"`
+	Note         sql.NullString `json:"note"`
+	IpAddress    pqtype.Inet    `json:"ip_address"`
+	UserAgent    sql.NullString `json:"user_agent"`
+	ApprovedAt   sql.NullTime   `json:"approved_at"`
+	RevokedAt    sql.NullTime   `json:"revoked_at"`
+}
@@ -2,15 +2,26 @@ package balancerepo
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 
 	"github.com/tuncanbit/tvs/internal/domain"
 )
 
+var ErrInsufficientBalance = errors.New("insufficient balance")
+
 type IBalanceRepository interface {
 	GetUserBalances(ctx context.Context, userID string) ([]*domain.Balance, error)
-	GetBalance(ctx context.Context, userID string) (*domain.Balance, error)
+	GetBalance(ctx context.Context, userID, currencyCode string) (*domain.Balance, error)
+	GetBalanceForUpdateTx(ctx context.Context, tx *sql.Tx, userID, currencyCode string) (*domain.Balance, error)
+	EnsureBalance(ctx context.Context, userID, currencyCode string) error
 	ReserveBalance(ctx context.Context, userID, currencyCode string, amountCents int64) error
 	ReleaseReservedBalance(ctx context.Context, userID, currencyCode string, amountCents int64) error
 	LogBalanceChange(ctx context.Context, balanceLog *domain.BalanceLog) error
 	UpdateBalance(ctx context.Context, userID, currency string, newAmountCents int64, newAmountUnits string) error
+	// SettleReservedBalanceTx completes a withdrawal: it consumes
+	// reservedCents from the reserved balance, debits chargedCents from the
+	// available balance and takes debitedUnits off amount_units. It fails
+	// with ErrInsufficientBalance if either balance falls short.
+	SettleReservedBalanceTx(ctx context.Context, tx *sql.Tx, userID, currencyCode string, reservedCents, chargedCents int64, debitedUnits string) (*domain.Balance, error)
 }
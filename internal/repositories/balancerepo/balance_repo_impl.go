@@ -3,6 +3,7 @@ package balancerepo
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
@@ -63,7 +64,7 @@ func (r *BalanceRepository) GetUserBalances(ctx context.Context, userID string)
 	return result, nil
 }
 
-func (r *BalanceRepository) GetBalance(ctx context.Context, userID string) (*domain.Balance, error) {
+func (r *BalanceRepository) GetBalance(ctx context.Context, userID, currencyCode string) (*domain.Balance, error) {
 	userUUID, err := uuid.Parse(userID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid user_id format: %v", err)
@@ -71,12 +72,57 @@ func (r *BalanceRepository) GetBalance(ctx context.Context, userID string) (*dom
 
 	balance, err := r.store.GetBalance(ctx, gen.GetBalanceParams{
 		UserID:       userUUID,
-		CurrencyCode: "USD",
+		CurrencyCode: currencyCode,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get balance: %v", err)
+	}
+
+	return mapDBBalance(balance), nil
+}
+
+// GetBalanceForUpdateTx reads the balance inside tx and locks its row until
+// tx ends, so the writes that follow are based on a value no one else can
+// change in the meantime.
+func (r *BalanceRepository) GetBalanceForUpdateTx(ctx context.Context, tx *sql.Tx, userID, currencyCode string) (*domain.Balance, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user_id format: %v", err)
+	}
+
+	balance, err := r.store.WithTx(tx).GetBalanceForUpdate(ctx, gen.GetBalanceForUpdateParams{
+		UserID:       userUUID,
+		CurrencyCode: currencyCode,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get balance: %v", err)
 	}
+	return mapDBBalance(balance), nil
+}
 
+func (r *BalanceRepository) SettleReservedBalanceTx(ctx context.Context, tx *sql.Tx, userID, currencyCode string, reservedCents, chargedCents int64, debitedUnits string) (*domain.Balance, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user_id format: %v", err)
+	}
+
+	balance, err := r.store.WithTx(tx).SettleReservedBalance(ctx, gen.SettleReservedBalanceParams{
+		ReservedCents: sql.NullInt64{Int64: reservedCents, Valid: true},
+		ChargedCents:  sql.NullInt64{Int64: chargedCents, Valid: true},
+		DebitedUnits:  sql.NullString{String: debitedUnits, Valid: true},
+		UserID:        userUUID,
+		CurrencyCode:  currencyCode,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrInsufficientBalance
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to settle reserved balance: %v", err)
+	}
+	return mapDBBalance(balance), nil
+}
+
+func mapDBBalance(balance gen.Balance) *domain.Balance {
 	reservedUnits := "0"
 	if balance.ReservedUnits.Valid {
 		reservedUnits = balance.ReservedUnits.String
@@ -93,13 +139,31 @@ func (r *BalanceRepository) GetBalance(ctx context.Context, userID string) (*dom
 	return &domain.Balance{
 		ID:            balance.ID.String(),
 		UserID:        balance.UserID.String(),
-		CurrencyCode:  "USD",
+		CurrencyCode:  balance.CurrencyCode,
 		AmountCents:   amountCents,
 		AmountUnits:   balance.AmountUnits.String,
 		ReservedCents: reservedCents,
 		ReservedUnits: reservedUnits,
 		UpdatedAt:     balance.UpdatedAt.Time,
-	}, nil
+	}
+}
+
+// EnsureBalance creates an empty balance row for the currency if the user
+// does not hold one yet.
+func (r *BalanceRepository) EnsureBalance(ctx context.Context, userID, currencyCode string) error {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return fmt.Errorf("invalid user_id format: %v", err)
+	}
+
+	err = r.store.CreateBalanceIfMissing(ctx, gen.CreateBalanceIfMissingParams{
+		UserID:       userUUID,
+		CurrencyCode: currencyCode,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create balance: %v", err)
+	}
+	return nil
 }
 
 func (r *BalanceRepository) ReserveBalance(ctx context.Context, userID, currencyCode string, amountCents int64) error {
@@ -0,0 +1,11 @@
+package configrepo
+
+import (
+	"context"
+	"encoding/json"
+)
+
+type IConfigRepository interface {
+	// GetConfig returns the raw JSON value stored under key in system_config.
+	GetConfig(ctx context.Context, key string) (json.RawMessage, error)
+}
@@ -0,0 +1,33 @@
+package configrepo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/internal/repositories/configrepo/gen"
+)
+
+type ConfigRepository struct {
+	db      *sql.DB
+	queries *gen.Queries
+	logger  zerolog.Logger
+}
+
+func New(db *sql.DB, logger zerolog.Logger) IConfigRepository {
+	return &ConfigRepository{
+		db:      db,
+		queries: gen.New(db),
+		logger:  logger,
+	}
+}
+
+func (r *ConfigRepository) GetConfig(ctx context.Context, key string) (json.RawMessage, error) {
+	row, err := r.queries.GetSystemConfig(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get system config %s: %w", key, err)
+	}
+	return row.ConfigValue, nil
+}
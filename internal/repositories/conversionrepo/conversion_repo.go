@@ -0,0 +1,13 @@
+package conversionrepo
+
+import (
+	"context"
+	"time"
+
+	"github.com/tuncanbit/tvs/internal/domain"
+)
+
+type IConversionRepository interface {
+	SaveRemainder(ctx context.Context, transactionID string, conversionType domain.ConversionType, conversion domain.Conversion) error
+	GetRoundingDrift(ctx context.Context, from, to time.Time) ([]domain.RoundingDrift, error)
+}
@@ -0,0 +1,78 @@
+package conversionrepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/internal/domain"
+	"github.com/tuncanbit/tvs/internal/repositories/conversionrepo/gen"
+)
+
+type ConversionRepository struct {
+	db      *sql.DB
+	queries *gen.Queries
+	logger  zerolog.Logger
+}
+
+func New(db *sql.DB, logger zerolog.Logger) IConversionRepository {
+	return &ConversionRepository{
+		db:      db,
+		queries: gen.New(db),
+		logger:  logger,
+	}
+}
+
+func (r *ConversionRepository) SaveRemainder(ctx context.Context, transactionID string, conversionType domain.ConversionType, conversion domain.Conversion) error {
+	txUUID, err := uuid.Parse(transactionID)
+	if err != nil {
+		return fmt.Errorf("invalid transaction_id format: %w", err)
+	}
+
+	err = r.queries.CreateConversionRemainder(ctx, gen.CreateConversionRemainderParams{
+		TransactionID:   txUUID,
+		OriginalAmount:  strconv.FormatFloat(conversion.Exact, 'f', 18, 64),
+		ConvertedAmount: conversion.Converted,
+		RemainderAmount: strconv.FormatFloat(conversion.Remainder, 'f', 8, 64),
+		CurrencyCode:    conversion.CurrencyCode,
+		ConversionType:  gen.ConversionType(conversionType),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save conversion remainder for transaction %s: %w", transactionID, err)
+	}
+	return nil
+}
+
+func (r *ConversionRepository) GetRoundingDrift(ctx context.Context, from, to time.Time) ([]domain.RoundingDrift, error) {
+	rows, err := r.queries.GetRoundingDrift(ctx, gen.GetRoundingDriftParams{
+		CreatedAt:   sql.NullTime{Time: from, Valid: true},
+		CreatedAt_2: sql.NullTime{Time: to, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rounding drift: %w", err)
+	}
+
+	drift := make([]domain.RoundingDrift, len(rows))
+	for i, row := range rows {
+		net, err := strconv.ParseFloat(row.NetRemainder, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid net remainder %s: %w", row.NetRemainder, err)
+		}
+		absolute, err := strconv.ParseFloat(row.AbsoluteRemainder, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid absolute remainder %s: %w", row.AbsoluteRemainder, err)
+		}
+		drift[i] = domain.RoundingDrift{
+			CurrencyCode:      row.CurrencyCode,
+			ConversionType:    domain.ConversionType(row.ConversionType),
+			Conversions:       row.Conversions,
+			NetRemainder:      net,
+			AbsoluteRemainder: absolute,
+		}
+	}
+	return drift, nil
+}
@@ -0,0 +1,12 @@
+package exchangeraterepo
+
+import (
+	"context"
+
+	"github.com/tuncanbit/tvs/internal/domain"
+)
+
+type IExchangeRateRepository interface {
+	SaveExchangeRate(ctx context.Context, rate domain.ExchangeRate) error
+	GetExchangeRate(ctx context.Context, fromCurrency, toCurrency string) (*domain.ExchangeRate, error)
+}
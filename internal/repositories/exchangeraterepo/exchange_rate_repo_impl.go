@@ -0,0 +1,65 @@
+package exchangeraterepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/internal/domain"
+	"github.com/tuncanbit/tvs/internal/repositories/exchangeraterepo/gen"
+)
+
+type ExchangeRateRepository struct {
+	db      *sql.DB
+	queries *gen.Queries
+	logger  zerolog.Logger
+}
+
+func New(db *sql.DB, logger zerolog.Logger) IExchangeRateRepository {
+	return &ExchangeRateRepository{
+		db:      db,
+		queries: gen.New(db),
+		logger:  logger,
+	}
+}
+
+func (r *ExchangeRateRepository) SaveExchangeRate(ctx context.Context, rate domain.ExchangeRate) error {
+	err := r.queries.UpsertExchangeRate(ctx, gen.UpsertExchangeRateParams{
+		FromCurrency: rate.FromCurrency,
+		ToCurrency:   rate.ToCurrency,
+		Rate:         strconv.FormatFloat(rate.Rate, 'f', 6, 64),
+		UpdatedAt:    sql.NullTime{Time: rate.UpdatedAt, Valid: !rate.UpdatedAt.IsZero()},
+	})
+	if err != nil {
+		r.logger.Err(err).
+			Str("from_currency", rate.FromCurrency).
+			Str("to_currency", rate.ToCurrency).
+			Msg("Failed to save exchange rate")
+		return fmt.Errorf("failed to save exchange rate: %w", err)
+	}
+	return nil
+}
+
+func (r *ExchangeRateRepository) GetExchangeRate(ctx context.Context, fromCurrency, toCurrency string) (*domain.ExchangeRate, error) {
+	row, err := r.queries.GetExchangeRate(ctx, gen.GetExchangeRateParams{
+		FromCurrency: fromCurrency,
+		ToCurrency:   toCurrency,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exchange rate %s/%s: %w", fromCurrency, toCurrency, err)
+	}
+
+	rate, err := strconv.ParseFloat(row.Rate, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid stored exchange rate %s: %w", row.Rate, err)
+	}
+
+	return &domain.ExchangeRate{
+		FromCurrency: row.FromCurrency,
+		ToCurrency:   row.ToCurrency,
+		Rate:         rate,
+		UpdatedAt:    row.UpdatedAt.Time,
+	}, nil
+}
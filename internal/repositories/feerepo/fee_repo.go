@@ -0,0 +1,17 @@
+package feerepo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/tuncanbit/tvs/internal/domain"
+)
+
+type IFeeRepository interface {
+	// SaveNetworkFee posts a network fee to the fee account. A fee already
+	// posted for the same transaction is left as is.
+	SaveNetworkFee(ctx context.Context, fee domain.NetworkFee) error
+	SaveNetworkFeeTx(ctx context.Context, tx *sql.Tx, fee domain.NetworkFee) error
+	GetNetworkFeeSummary(ctx context.Context, from, to time.Time) ([]domain.NetworkFeeSummary, error)
+}
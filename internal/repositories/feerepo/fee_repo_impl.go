@@ -0,0 +1,83 @@
+package feerepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/internal/domain"
+	"github.com/tuncanbit/tvs/internal/repositories/feerepo/gen"
+)
+
+type FeeRepository struct {
+	db      *sql.DB
+	queries *gen.Queries
+	logger  zerolog.Logger
+}
+
+func New(db *sql.DB, logger zerolog.Logger) IFeeRepository {
+	return &FeeRepository{
+		db:      db,
+		queries: gen.New(db),
+		logger:  logger,
+	}
+}
+
+func (r *FeeRepository) SaveNetworkFee(ctx context.Context, fee domain.NetworkFee) error {
+	return saveNetworkFee(ctx, r.queries, fee)
+}
+
+func (r *FeeRepository) SaveNetworkFeeTx(ctx context.Context, tx *sql.Tx, fee domain.NetworkFee) error {
+	return saveNetworkFee(ctx, r.queries.WithTx(tx), fee)
+}
+
+func saveNetworkFee(ctx context.Context, queries *gen.Queries, fee domain.NetworkFee) error {
+	err := queries.CreateNetworkFee(ctx, gen.CreateNetworkFeeParams{
+		ChainID:          fee.ChainID,
+		TxHash:           fee.TxHash,
+		TransactionType:  string(fee.TransactionType),
+		WithdrawalID:     sql.NullString{String: fee.WithdrawalID, Valid: fee.WithdrawalID != ""},
+		DepositSessionID: sql.NullString{String: fee.DepositSessionID, Valid: fee.DepositSessionID != ""},
+		FeeCurrency:      fee.Currency,
+		FeeBaseUnits:     fee.BaseUnits,
+		FeePayer:         fee.Payer,
+		PaidBy:           string(fee.PaidBy),
+		UsdCents:         fee.USDCents,
+		PriceUsd:         sql.NullString{String: strconv.FormatFloat(fee.PriceUSD, 'f', 8, 64), Valid: fee.PriceUSD > 0},
+		ChargedCurrency:  sql.NullString{String: fee.ChargedCurrency, Valid: fee.ChargedCurrency != ""},
+		ChargedCents:     fee.ChargedCents,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save network fee of transaction %s: %w", fee.TxHash, err)
+	}
+	return nil
+}
+
+func (r *FeeRepository) GetNetworkFeeSummary(ctx context.Context, from, to time.Time) ([]domain.NetworkFeeSummary, error) {
+	rows, err := r.queries.GetNetworkFeeSummary(ctx, gen.GetNetworkFeeSummaryParams{
+		CreatedAt:   sql.NullTime{Time: from, Valid: true},
+		CreatedAt_2: sql.NullTime{Time: to, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network fee summary: %w", err)
+	}
+
+	summary := make([]domain.NetworkFeeSummary, len(rows))
+	for i, row := range rows {
+		summary[i] = domain.NetworkFeeSummary{
+			ChainID:         row.ChainID,
+			Currency:        row.FeeCurrency,
+			TransactionType: domain.TransactionType(row.TransactionType),
+			PaidBy:          domain.NetworkFeePaidBy(row.PaidBy),
+			ChargedCurrency: row.ChargedCurrency,
+			Transactions:    row.Transactions,
+			BaseUnits:       row.FeeBaseUnits,
+			USDCents:        row.UsdCents,
+			ChargedCents:    row.ChargedCents,
+		}
+	}
+	return summary, nil
+}
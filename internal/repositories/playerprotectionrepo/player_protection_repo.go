@@ -0,0 +1,19 @@
+package playerprotectionrepo
+
+import (
+	"context"
+	"time"
+
+	"github.com/tuncanbit/tvs/internal/domain"
+)
+
+type IPlayerProtectionRepository interface {
+	// ListDepositLimits returns the user's stored limits, pending changes
+	// included and not yet applied.
+	ListDepositLimits(ctx context.Context, userID string) ([]domain.DepositLimit, error)
+	SaveDepositLimit(ctx context.Context, limit domain.DepositLimit) (*domain.DepositLimit, error)
+	CreateSelfExclusion(ctx context.Context, exclusion domain.SelfExclusion) (*domain.SelfExclusion, error)
+	// GetActiveSelfExclusion returns the self-exclusion in force at now that
+	// ends last, or nil if there is none.
+	GetActiveSelfExclusion(ctx context.Context, userID string, now time.Time) (*domain.SelfExclusion, error)
+}
@@ -0,0 +1,148 @@
+package playerprotectionrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/internal/domain"
+	"github.com/tuncanbit/tvs/internal/repositories/playerprotectionrepo/gen"
+)
+
+type PlayerProtectionRepository struct {
+	db      *sql.DB
+	queries *gen.Queries
+	logger  zerolog.Logger
+}
+
+func New(db *sql.DB, logger zerolog.Logger) IPlayerProtectionRepository {
+	return &PlayerProtectionRepository{
+		db:      db,
+		queries: gen.New(db),
+		logger:  logger,
+	}
+}
+
+func (r *PlayerProtectionRepository) ListDepositLimits(ctx context.Context, userID string) ([]domain.DepositLimit, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user_id format: %w", err)
+	}
+
+	rows, err := r.queries.ListDepositLimits(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deposit limits of user %s: %w", userID, err)
+	}
+	limits := make([]domain.DepositLimit, len(rows))
+	for i, row := range rows {
+		limits[i] = *mapDBLimit(row)
+	}
+	return limits, nil
+}
+
+func (r *PlayerProtectionRepository) SaveDepositLimit(ctx context.Context, limit domain.DepositLimit) (*domain.DepositLimit, error) {
+	userUUID, err := uuid.Parse(limit.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user_id format: %w", err)
+	}
+
+	params := gen.UpsertDepositLimitParams{
+		UserID:        userUUID,
+		Period:        string(limit.Period),
+		LimitUsdCents: limit.LimitUSDCents,
+		SetBy:         nullUUID(limit.SetBy),
+	}
+	if limit.PendingLimitUSDCents != nil && limit.PendingEffectiveAt != nil {
+		params.PendingLimitUsdCents = sql.NullInt64{Int64: *limit.PendingLimitUSDCents, Valid: true}
+		params.PendingEffectiveAt = sql.NullTime{Time: *limit.PendingEffectiveAt, Valid: true}
+	}
+	row, err := r.queries.UpsertDepositLimit(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save %s deposit limit of user %s: %w", limit.Period, limit.UserID, err)
+	}
+	return mapDBLimit(row), nil
+}
+
+func (r *PlayerProtectionRepository) CreateSelfExclusion(ctx context.Context, exclusion domain.SelfExclusion) (*domain.SelfExclusion, error) {
+	userUUID, err := uuid.Parse(exclusion.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user_id format: %w", err)
+	}
+
+	row, err := r.queries.CreateSelfExclusion(ctx, gen.CreateSelfExclusionParams{
+		UserID:    userUUID,
+		StartsAt:  exclusion.StartsAt,
+		EndsAt:    exclusion.EndsAt,
+		CreatedBy: nullUUID(exclusion.CreatedBy),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create self-exclusion for user %s: %w", exclusion.UserID, err)
+	}
+	return mapDBExclusion(row), nil
+}
+
+func (r *PlayerProtectionRepository) GetActiveSelfExclusion(ctx context.Context, userID string, now time.Time) (*domain.SelfExclusion, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user_id format: %w", err)
+	}
+
+	row, err := r.queries.GetActiveSelfExclusion(ctx, gen.GetActiveSelfExclusionParams{
+		UserID:   userUUID,
+		StartsAt: now,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get self-exclusion of user %s: %w", userID, err)
+	}
+	return mapDBExclusion(row), nil
+}
+
+// nullUUID converts an optional actor ID. IDs that do not parse are stored
+// as NULL.
+func nullUUID(id string) uuid.NullUUID {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: parsed, Valid: true}
+}
+
+func mapDBLimit(row gen.DepositLimit) *domain.DepositLimit {
+	limit := &domain.DepositLimit{
+		UserID:        row.UserID.String(),
+		Period:        domain.DepositLimitPeriod(row.Period),
+		LimitUSDCents: row.LimitUsdCents,
+		UpdatedAt:     row.UpdatedAt.Time,
+	}
+	if row.PendingLimitUsdCents.Valid && row.PendingEffectiveAt.Valid {
+		pending := row.PendingLimitUsdCents.Int64
+		effectiveAt := row.PendingEffectiveAt.Time
+		limit.PendingLimitUSDCents = &pending
+		limit.PendingEffectiveAt = &effectiveAt
+	}
+	if row.SetBy.Valid {
+		limit.SetBy = row.SetBy.UUID.String()
+	}
+	return limit
+}
+
+func mapDBExclusion(row gen.SelfExclusion) *domain.SelfExclusion {
+	exclusion := &domain.SelfExclusion{
+		ID:        row.ID.String(),
+		UserID:    row.UserID.String(),
+		StartsAt:  row.StartsAt,
+		EndsAt:    row.EndsAt,
+		CreatedAt: row.CreatedAt.Time,
+	}
+	if row.CreatedBy.Valid {
+		exclusion.CreatedBy = row.CreatedBy.UUID.String()
+	}
+	return exclusion
+}
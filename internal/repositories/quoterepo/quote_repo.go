@@ -0,0 +1,16 @@
+package quoterepo
+
+import (
+	"context"
+
+	"github.com/tuncanbit/tvs/internal/domain"
+)
+
+type IQuoteRepository interface {
+	// SaveQuote creates the session's quote or replaces it while it is still
+	// active. It returns sql.ErrNoRows if the existing quote is settled.
+	SaveQuote(ctx context.Context, quote domain.DepositQuote) (*domain.DepositQuote, error)
+	GetQuoteBySessionID(ctx context.Context, sessionID string) (*domain.DepositQuote, error)
+	// SettleQuote moves an active quote to status and reports whether it did.
+	SettleQuote(ctx context.Context, sessionID string, status domain.QuoteStatus) (bool, error)
+}
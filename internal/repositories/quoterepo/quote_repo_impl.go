@@ -0,0 +1,102 @@
+package quoterepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/internal/domain"
+	"github.com/tuncanbit/tvs/internal/repositories/quoterepo/gen"
+)
+
+type QuoteRepository struct {
+	db      *sql.DB
+	queries *gen.Queries
+	logger  zerolog.Logger
+}
+
+func New(db *sql.DB, logger zerolog.Logger) IQuoteRepository {
+	return &QuoteRepository{
+		db:      db,
+		queries: gen.New(db),
+		logger:  logger,
+	}
+}
+
+func (r *QuoteRepository) SaveQuote(ctx context.Context, quote domain.DepositQuote) (*domain.DepositQuote, error) {
+	userUUID, err := uuid.Parse(quote.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user_id format: %w", err)
+	}
+
+	row, err := r.queries.UpsertDepositQuote(ctx, gen.UpsertDepositQuoteParams{
+		SessionID:       quote.SessionID,
+		UserID:          userUUID,
+		CryptoCurrency:  quote.CryptoCurrency,
+		FiatCurrency:    quote.FiatCurrency,
+		FiatAmountCents: quote.FiatAmountCents,
+		CryptoAmount:    strconv.FormatFloat(quote.CryptoAmount, 'f', 18, 64),
+		ExchangeRate:    strconv.FormatFloat(quote.ExchangeRate, 'f', 8, 64),
+		PriceUsd:        strconv.FormatFloat(quote.PriceUSD, 'f', 8, 64),
+		SlippageBps:     int32(quote.SlippageBps),
+		ExpiresAt:       quote.ExpiresAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save quote for session %s: %w", quote.SessionID, err)
+	}
+	return r.convertFromDB(row)
+}
+
+func (r *QuoteRepository) GetQuoteBySessionID(ctx context.Context, sessionID string) (*domain.DepositQuote, error) {
+	row, err := r.queries.GetDepositQuoteBySessionID(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quote for session %s: %w", sessionID, err)
+	}
+	return r.convertFromDB(row)
+}
+
+func (r *QuoteRepository) SettleQuote(ctx context.Context, sessionID string, status domain.QuoteStatus) (bool, error) {
+	rows, err := r.queries.SettleDepositQuote(ctx, gen.SettleDepositQuoteParams{
+		SessionID: sessionID,
+		Status:    string(status),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to settle quote for session %s: %w", sessionID, err)
+	}
+	return rows > 0, nil
+}
+
+func (r *QuoteRepository) convertFromDB(row gen.DepositQuote) (*domain.DepositQuote, error) {
+	cryptoAmount, err := strconv.ParseFloat(row.CryptoAmount, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid quote crypto amount %s: %w", row.CryptoAmount, err)
+	}
+	exchangeRate, err := strconv.ParseFloat(row.ExchangeRate, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid quote exchange rate %s: %w", row.ExchangeRate, err)
+	}
+	priceUSD, err := strconv.ParseFloat(row.PriceUsd, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid quote USD price %s: %w", row.PriceUsd, err)
+	}
+
+	return &domain.DepositQuote{
+		ID:              row.ID.String(),
+		SessionID:       row.SessionID,
+		UserID:          row.UserID.String(),
+		CryptoCurrency:  row.CryptoCurrency,
+		FiatCurrency:    row.FiatCurrency,
+		FiatAmountCents: row.FiatAmountCents,
+		CryptoAmount:    cryptoAmount,
+		ExchangeRate:    exchangeRate,
+		PriceUSD:        priceUSD,
+		SlippageBps:     int(row.SlippageBps),
+		Status:          domain.QuoteStatus(row.Status),
+		ExpiresAt:       row.ExpiresAt,
+		SettledAt:       row.SettledAt.Time,
+		CreatedAt:       row.CreatedAt.Time,
+	}, nil
+}
@@ -3,15 +3,30 @@ package sessionrepo
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"time"
 
 	"github.com/tuncanbit/tvs/internal/domain"
 )
 
 type ISessionRepository interface {
 	LoadPendingDepositSessions(ctx context.Context, limit, offset int) ([]domain.DepositSession, error)
+	GetBySessionID(ctx context.Context, sessionID string) (domain.DepositSession, error)
 	GetBySessionIDTx(ctx context.Context, tx *sql.Tx, sessionID string) (domain.DepositSession, error)
 	UpdateDepositSessionStatusTx(ctx context.Context, tx *sql.Tx, sessionId string, status string) error
 	UpdateDepositSessionStatus(ctx context.Context, sessionId string, status string, errorMessage string) error
 	CompleteSession(ctx context.Context, session domain.DepositSession, errorMessage string) error
 	BeginTx(ctx context.Context) (*sql.Tx, error)
+	// ListHeldSessions returns sessions in a held status, held or
+	// limit_held, longest held first.
+	ListHeldSessions(ctx context.Context, status domain.SessionStatus, limit, offset int) ([]domain.DepositSession, error)
+	// ListUserSessions returns the user's sessions in status, most recently
+	// updated first.
+	ListUserSessions(ctx context.Context, userID string, status domain.SessionStatus) ([]domain.DepositSession, error)
+	// ResolveHold moves a session from the held status from to status to with
+	// the given metadata and reports false if it was no longer in from.
+	ResolveHold(ctx context.Context, sessionID string, from, to domain.SessionStatus, metadata json.RawMessage, message string) (bool, error)
+	// SumCreditedDeposits returns the USD value in cents of the deposits
+	// credited to the user since the given time.
+	SumCreditedDeposits(ctx context.Context, userID string, since time.Time) (int64, error)
 }
@@ -3,9 +3,12 @@ package sessionrepo
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"strconv"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 	"github.com/sqlc-dev/pqtype"
 
@@ -83,6 +86,16 @@ func (r *sessionRepositoryImpl) BeginTx(ctx context.Context) (*sql.Tx, error) {
 	return r.db.BeginTx(ctx, nil)
 }
 
+func (r *sessionRepositoryImpl) GetBySessionID(ctx context.Context, sessionID string) (domain.DepositSession, error) {
+	session, err := r.store.GetDepositSessionByID(ctx, sessionID)
+	if err != nil {
+		r.logger.Error().Err(err).Str("session_id", sessionID).Msg("Failed to get deposit session")
+		return domain.DepositSession{}, fmt.Errorf("failed to get deposit session: %w", err)
+	}
+
+	return r.convertFromDB(&session), nil
+}
+
 func (r *sessionRepositoryImpl) GetBySessionIDTx(ctx context.Context, tx *sql.Tx, sessionID string) (domain.DepositSession, error) {
 	txStore := r.store.WithTx(tx)
 	session, err := txStore.GetDepositSessionByID(ctx, sessionID)
@@ -107,6 +120,72 @@ func (r *sessionRepositoryImpl) UpdateDepositSessionStatusTx(ctx context.Context
 	return nil
 }
 
+func (r *sessionRepositoryImpl) ListHeldSessions(ctx context.Context, status domain.SessionStatus, limit, offset int) ([]domain.DepositSession, error) {
+	sessions, err := r.store.ListDepositSessionsByStatus(ctx, sessionRepo.ListDepositSessionsByStatusParams{
+		Status: sessionRepo.DepositSessionStatus(status),
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s sessions: %w", status, err)
+	}
+
+	result := make([]domain.DepositSession, len(sessions))
+	for i, session := range sessions {
+		result[i] = r.convertFromDB(&session)
+	}
+	return result, nil
+}
+
+func (r *sessionRepositoryImpl) ListUserSessions(ctx context.Context, userID string, status domain.SessionStatus) ([]domain.DepositSession, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user_id format: %w", err)
+	}
+	sessions, err := r.store.ListUserDepositSessionsByStatus(ctx, sessionRepo.ListUserDepositSessionsByStatusParams{
+		UserID: userUUID,
+		Status: sessionRepo.DepositSessionStatus(status),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s sessions of user %s: %w", status, userID, err)
+	}
+
+	result := make([]domain.DepositSession, len(sessions))
+	for i, session := range sessions {
+		result[i] = r.convertFromDB(&session)
+	}
+	return result, nil
+}
+
+func (r *sessionRepositoryImpl) ResolveHold(ctx context.Context, sessionID string, from, to domain.SessionStatus, metadata json.RawMessage, message string) (bool, error) {
+	rows, err := r.store.ResolveHeldDepositSession(ctx, sessionRepo.ResolveHeldDepositSessionParams{
+		SessionID:    sessionID,
+		Status:       sessionRepo.DepositSessionStatus(to),
+		Metadata:     pqtype.NullRawMessage{RawMessage: metadata, Valid: metadata != nil},
+		ErrorMessage: sql.NullString{String: message, Valid: message != ""},
+		Status_2:     sessionRepo.DepositSessionStatus(from),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve hold on session %s: %w", sessionID, err)
+	}
+	return rows > 0, nil
+}
+
+func (r *sessionRepositoryImpl) SumCreditedDeposits(ctx context.Context, userID string, since time.Time) (int64, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return 0, fmt.Errorf("invalid user_id format: %w", err)
+	}
+	total, err := r.store.SumUserCreditedDeposits(ctx, sessionRepo.SumUserCreditedDepositsParams{
+		UserID:    userUUID,
+		CreatedAt: sql.NullTime{Time: since, Valid: true},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to sum credited deposits for user %s: %w", userID, err)
+	}
+	return total, nil
+}
+
 func (r *sessionRepositoryImpl) convertFromDB(session *sessionRepo.DepositSession) domain.DepositSession {
 	amtFloat := float64(0)
 	var err error
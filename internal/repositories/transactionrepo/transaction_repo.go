@@ -2,12 +2,16 @@ package transactionrepo
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/tuncanbit/tvs/internal/domain"
 )
 
 type ITransactionRepository interface {
 	Create(ctx context.Context, tx domain.Transaction) error
+	// CreateTx records the transaction inside dbTx, so it is written
+	// together with the balance and status changes it accounts for.
+	CreateTx(ctx context.Context, dbTx *sql.Tx, tx domain.Transaction) error
 	GetByHash(ctx context.Context, chainID, txHash string) (domain.Transaction, error)
 	GetByID(ctx context.Context, id string) (domain.Transaction, error)
 	GetByDepositSessionID(ctx context.Context, sessionID string) (domain.Transaction, error)
@@ -32,6 +32,14 @@ func New(db *database.DBManager, logger zerolog.Logger) ITransactionRepository {
 }
 
 func (r *transactionRepository) Create(ctx context.Context, tx domain.Transaction) error {
+	return r.create(ctx, r.store, tx)
+}
+
+func (r *transactionRepository) CreateTx(ctx context.Context, dbTx *sql.Tx, tx domain.Transaction) error {
+	return r.create(ctx, r.store.WithTx(dbTx), tx)
+}
+
+func (r *transactionRepository) create(ctx context.Context, store *transactionRepo.Queries, tx domain.Transaction) error {
 	params := transactionRepo.CreateTransactionParams{
 		ChainID:          tx.ChainID,
 		CryptoCurrency:   tx.CryptoCurrency,
@@ -56,7 +64,7 @@ func (r *transactionRepository) Create(ctx context.Context, tx domain.Transactio
 		Metadata:         pqtype.NullRawMessage{RawMessage: tx.Metadata, Valid: tx.Metadata != nil},
 	}
 
-	err := r.store.CreateTransaction(ctx, params)
+	err := store.CreateTransaction(ctx, params)
 	if err != nil {
 		r.logger.Error().Err(err).Str("tx_hash", tx.TxHash).Msg("Failed to create transaction")
 		return err
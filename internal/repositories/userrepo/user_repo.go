@@ -0,0 +1,15 @@
+package userrepo
+
+import (
+	"context"
+
+	"github.com/tuncanbit/tvs/internal/domain"
+)
+
+type IUserRepository interface {
+	// GetDefaultCurrency returns the user's configured fiat currency, or an
+	// empty string when none is set.
+	GetDefaultCurrency(ctx context.Context, userID string) (string, error)
+	GetProfile(ctx context.Context, userID string) (*domain.UserProfile, error)
+	GetAccountStatus(ctx context.Context, userID string) (*domain.AccountStatus, error)
+}
@@ -0,0 +1,77 @@
+package userrepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/internal/domain"
+	"github.com/tuncanbit/tvs/internal/repositories/userrepo/gen"
+)
+
+type UserRepository struct {
+	db      *sql.DB
+	queries *gen.Queries
+	logger  zerolog.Logger
+}
+
+func New(db *sql.DB, logger zerolog.Logger) IUserRepository {
+	return &UserRepository{
+		db:      db,
+		queries: gen.New(db),
+		logger:  logger,
+	}
+}
+
+func (r *UserRepository) GetDefaultCurrency(ctx context.Context, userID string) (string, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return "", fmt.Errorf("invalid user_id format: %w", err)
+	}
+
+	currency, err := r.queries.GetUserDefaultCurrency(ctx, userUUID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get default currency for user %s: %w", userID, err)
+	}
+	return currency.String, nil
+}
+
+func (r *UserRepository) GetProfile(ctx context.Context, userID string) (*domain.UserProfile, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user_id format: %w", err)
+	}
+
+	row, err := r.queries.GetUserProfile(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get profile for user %s: %w", userID, err)
+	}
+	return &domain.UserProfile{
+		UserID:        row.ID.String(),
+		FirstName:     row.FirstName.String,
+		LastName:      row.LastName.String,
+		StreetAddress: row.StreetAddress.String,
+		City:          row.City.String,
+		State:         row.State.String,
+		PostalCode:    row.PostalCode.String,
+		Country:       row.Country.String,
+	}, nil
+}
+
+func (r *UserRepository) GetAccountStatus(ctx context.Context, userID string) (*domain.AccountStatus, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user_id format: %w", err)
+	}
+
+	row, err := r.queries.GetUserAccountStatus(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account status for user %s: %w", userID, err)
+	}
+	return &domain.AccountStatus{
+		KYCStatus: row.KycStatus.String,
+		Status:    row.Status.String,
+	}, nil
+}
@@ -0,0 +1,91 @@
+package withdrawalrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/tuncanbit/tvs/internal/domain"
+	"github.com/tuncanbit/tvs/internal/repositories/withdrawalrepo/gen"
+	"github.com/tuncanbit/tvs/pkg/db"
+)
+
+const uniqueViolation = "23505"
+
+func (r *WithdrawalRepository) AddApproval(ctx context.Context, approval domain.WithdrawalApproval) (*domain.WithdrawalApproval, error) {
+	adminID, err := uuid.Parse(approval.AdminID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid admin ID %s: %w", approval.AdminID, err)
+	}
+
+	row, err := r.queries.CreateWithdrawalApproval(ctx, gen.CreateWithdrawalApprovalParams{
+		WithdrawalID: approval.WithdrawalID,
+		AdminID:      adminID,
+		Note:         sql.NullString{String: approval.Note, Valid: approval.Note != ""},
+		IpAddress:    db.Inet(approval.IPAddress),
+		UserAgent:    sql.NullString{String: approval.UserAgent, Valid: approval.UserAgent != ""},
+	})
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return nil, ErrApprovalExists
+		}
+		return nil, fmt.Errorf("failed to record approval of withdrawal %s: %w", approval.WithdrawalID, err)
+	}
+	return mapDBApproval(row), nil
+}
+
+func (r *WithdrawalRepository) RevokeApproval(ctx context.Context, withdrawalID, adminID string) (bool, error) {
+	adminUUID, err := uuid.Parse(adminID)
+	if err != nil {
+		return false, fmt.Errorf("invalid admin ID %s: %w", adminID, err)
+	}
+
+	rows, err := r.queries.RevokeWithdrawalApproval(ctx, gen.RevokeWithdrawalApprovalParams{
+		WithdrawalID: withdrawalID,
+		AdminID:      adminUUID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke approval of withdrawal %s: %w", withdrawalID, err)
+	}
+	return rows > 0, nil
+}
+
+func (r *WithdrawalRepository) ListActiveApprovals(ctx context.Context, withdrawalID string) ([]domain.WithdrawalApproval, error) {
+	rows, err := r.queries.ListActiveWithdrawalApprovals(ctx, withdrawalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list approvals of withdrawal %s: %w", withdrawalID, err)
+	}
+
+	approvals := make([]domain.WithdrawalApproval, len(rows))
+	for i, row := range rows {
+		approvals[i] = *mapDBApproval(row)
+	}
+	return approvals, nil
+}
+
+func (r *WithdrawalRepository) CountQuorumApprovals(ctx context.Context, withdrawalID string) (int, error) {
+	count, err := r.queries.CountQuorumApprovals(ctx, withdrawalID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count approvals of withdrawal %s: %w", withdrawalID, err)
+	}
+	return int(count), nil
+}
+
+func mapDBApproval(row gen.WithdrawalApproval) *domain.WithdrawalApproval {
+	approval := &domain.WithdrawalApproval{
+		ID:           row.ID.String(),
+		WithdrawalID: row.WithdrawalID,
+		AdminID:      row.AdminID.String(),
+		Note:         row.Note.String,
+		UserAgent:    row.UserAgent.String,
+		ApprovedAt:   row.ApprovedAt.Time,
+	}
+	if row.IpAddress.Valid {
+		approval.IPAddress = row.IpAddress.IPNet.IP.String()
+	}
+	return approval
+}
@@ -3,15 +3,77 @@ package withdrawalrepo
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
 
 	"github.com/tuncanbit/tvs/internal/domain"
 )
 
+// ErrApprovalExists is returned when an admin already holds an active
+// approval of the withdrawal.
+var ErrApprovalExists = errors.New("admin has already approved this withdrawal")
+
+var (
+	ErrInsufficientBalance = errors.New("insufficient balance for withdrawal")
+	ErrWithdrawalExists    = errors.New("withdrawal already exists")
+)
+
 type IWithdrawalRepository interface {
 	UpdateWithdrawal(ctx context.Context, withdrawal domain.Withdrawal) error
+	UpdateWithdrawalTx(ctx context.Context, tx *sql.Tx, withdrawal domain.Withdrawal) error
 	BeginTx(ctx context.Context) (*sql.Tx, error)
 	GetByWithdrawalIDTx(ctx context.Context, tx *sql.Tx, withdrawalId string) (*domain.Withdrawal, error)
 	UpdateWithdrawalStatusTx(ctx context.Context, tx *sql.Tx, withdrawalId string, status domain.WithdrawalStatus) error
 	UpdateWithdrawalStatus(ctx context.Context, withdrawalId string, status domain.WithdrawalStatus, errorMessage string) error
 	LoadPendingWithdrawals(ctx context.Context, limit, offset int) ([]domain.Withdrawal, error)
+	// SumUserWithdrawalsSince totals the USD value of the user's withdrawals
+	// created since the given time, excluding failed and cancelled ones and
+	// the withdrawal being evaluated.
+	SumUserWithdrawalsSince(ctx context.Context, userID, excludeWithdrawalID string, since time.Time) (int64, error)
+	SetLimitCheck(ctx context.Context, withdrawalID string, limitCheck json.RawMessage) error
+	SetNetworkFeeTx(ctx context.Context, tx *sql.Tx, withdrawalID string, networkFee json.RawMessage) error
+	// FlagForAdminReview moves a pending withdrawal to awaiting_admin_review.
+	// It reports false if the withdrawal was no longer pending.
+	FlagForAdminReview(ctx context.Context, withdrawalID string, deadline time.Time, limitCheck json.RawMessage) (bool, error)
+	// FlagVerificationMismatch sends a pending or processing withdrawal whose
+	// transaction did not match it to admin review. Any earlier review and its
+	// approvals are cleared so the mismatch is decided on its own. It reports
+	// false if the withdrawal had already left those statuses.
+	FlagVerificationMismatch(ctx context.Context, withdrawalID string, deadline time.Time, verification json.RawMessage) (bool, error)
+	GetByWithdrawalID(ctx context.Context, withdrawalID string) (*domain.Withdrawal, error)
+	ListAwaitingReview(ctx context.Context, limit, offset int) ([]domain.Withdrawal, error)
+	ListOverdueReviews(ctx context.Context, now time.Time) ([]domain.Withdrawal, error)
+	// UpdateReview stores the review document of a withdrawal still awaiting
+	// review and moves its deadline when one is given.
+	UpdateReview(ctx context.Context, withdrawalID string, review json.RawMessage, deadline time.Time) (bool, error)
+	// ResolveReview takes a withdrawal out of review into status. It reports
+	// false if the withdrawal was no longer awaiting review.
+	ResolveReview(ctx context.Context, withdrawalID string, status domain.WithdrawalStatus, adminID, errorMessage string, review json.RawMessage) (bool, error)
+	// RejectReview cancels a withdrawal awaiting review and, in the same
+	// transaction, returns releaseCents of its reservation to the user's
+	// balance in currencyCode and marks the reservation released. A zero
+	// releaseCents leaves the balance and reservation alone. It reports false
+	// if the withdrawal was no longer awaiting review.
+	RejectReview(ctx context.Context, withdrawalID, userID, adminID, errorMessage string, review json.RawMessage, currencyCode string, releaseCents int64) (bool, error)
+	// CreateWithReservation moves the withdrawal's AmountReservedCents from the
+	// user's available balance in currencyCode to reserved and inserts the
+	// withdrawal in the same transaction, so neither happens without the other.
+	CreateWithReservation(ctx context.Context, withdrawal domain.Withdrawal, currencyCode string) (*domain.Withdrawal, error)
+	MarkReservationReleased(ctx context.Context, withdrawalID string) error
+	// ListAwaitingBroadcast lists pending API withdrawals that have no
+	// transaction hash yet, oldest first.
+	ListAwaitingBroadcast(ctx context.Context, limit int) ([]domain.Withdrawal, error)
+	// ClaimBroadcast records a signed transaction for a withdrawal before it
+	// is sent. It succeeds only while the withdrawal's current broadcast
+	// signature is previousSignature, so two executors cannot both pay out.
+	ClaimBroadcast(ctx context.Context, withdrawalID, previousSignature string, broadcast json.RawMessage) (bool, error)
+	// SetTxHash writes the transaction hash of a withdrawal that has none.
+	SetTxHash(ctx context.Context, withdrawalID, txHash string) (bool, error)
+	AddApproval(ctx context.Context, approval domain.WithdrawalApproval) (*domain.WithdrawalApproval, error)
+	RevokeApproval(ctx context.Context, withdrawalID, adminID string) (bool, error)
+	ListActiveApprovals(ctx context.Context, withdrawalID string) ([]domain.WithdrawalApproval, error)
+	// CountQuorumApprovals counts the distinct admins, other than the
+	// requesting user, holding an active approval of the withdrawal.
+	CountQuorumApprovals(ctx context.Context, withdrawalID string) (int, error)
 }
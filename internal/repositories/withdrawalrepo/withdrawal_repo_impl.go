@@ -3,9 +3,15 @@ package withdrawalrepo
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/rs/zerolog"
+	"github.com/sqlc-dev/pqtype"
 	"github.com/tuncanbit/tvs/internal/domain"
 	"github.com/tuncanbit/tvs/internal/repositories/withdrawalrepo/gen"
 )
@@ -25,7 +31,15 @@ func New(db *sql.DB, logger zerolog.Logger) IWithdrawalRepository {
 }
 
 func (r *WithdrawalRepository) UpdateWithdrawal(ctx context.Context, withdrawal domain.Withdrawal) error {
-	err := r.queries.UpdateWithdrawal(ctx, gen.UpdateWithdrawalParams{
+	return r.updateWithdrawal(ctx, r.queries, withdrawal)
+}
+
+func (r *WithdrawalRepository) UpdateWithdrawalTx(ctx context.Context, tx *sql.Tx, withdrawal domain.Withdrawal) error {
+	return r.updateWithdrawal(ctx, r.queries.WithTx(tx), withdrawal)
+}
+
+func (r *WithdrawalRepository) updateWithdrawal(ctx context.Context, queries *gen.Queries, withdrawal domain.Withdrawal) error {
+	err := queries.UpdateWithdrawal(ctx, gen.UpdateWithdrawalParams{
 		TxHash:       sql.NullString{String: withdrawal.TxHash, Valid: withdrawal.TxHash != ""},
 		Status:       gen.WithdrawalStatus(withdrawal.Status),
 		WithdrawalID: withdrawal.WithdrawalID,
@@ -94,6 +108,7 @@ func (r *WithdrawalRepository) LoadPendingWithdrawals(ctx context.Context, limit
 			AmountReservedCents:   w.AmountReservedCents,
 			ReservationReleased:   w.ReservationReleased.Bool,
 			ReservationReleasedAt: reservationReleasedAt,
+			Metadata:              w.Metadata.RawMessage,
 			CreatedAt:             createdAt,
 			UpdatedAt:             updatedAt,
 		}
@@ -116,10 +131,15 @@ func (r *WithdrawalRepository) GetByWithdrawalIDTx(ctx context.Context, tx *sql.
 }
 
 func mapDBWithdrawalToDomain(dbWithdrawal gen.Withdrawal) *domain.Withdrawal {
+	var adminID string
+	if dbWithdrawal.AdminID.Valid {
+		adminID = dbWithdrawal.AdminID.UUID.String()
+	}
+
 	return &domain.Withdrawal{
 		ID:                    dbWithdrawal.ID.String(),
 		UserID:                dbWithdrawal.UserID.String(),
-		AdminID:               dbWithdrawal.AdminID.UUID.String(),
+		AdminID:               adminID,
 		WithdrawalID:          dbWithdrawal.WithdrawalID,
 		ChainID:               dbWithdrawal.ChainID,
 		Network:               dbWithdrawal.Network,
@@ -138,6 +158,7 @@ func mapDBWithdrawalToDomain(dbWithdrawal gen.Withdrawal) *domain.Withdrawal {
 		AmountReservedCents:   dbWithdrawal.AmountReservedCents,
 		ReservationReleased:   dbWithdrawal.ReservationReleased.Bool,
 		ReservationReleasedAt: dbWithdrawal.ReservationReleasedAt.Time,
+		Metadata:              dbWithdrawal.Metadata.RawMessage,
 		CreatedAt:             dbWithdrawal.CreatedAt.Time,
 		UpdatedAt:             dbWithdrawal.UpdatedAt.Time,
 	}
@@ -158,3 +179,300 @@ func (r *WithdrawalRepository) UpdateWithdrawalStatus(ctx context.Context, withd
 		ErrorMessage: sql.NullString{String: errorMessage, Valid: errorMessage != ""},
 	})
 }
+
+func (r *WithdrawalRepository) SumUserWithdrawalsSince(ctx context.Context, userID, excludeWithdrawalID string, since time.Time) (int64, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return 0, fmt.Errorf("invalid user ID %s: %w", userID, err)
+	}
+
+	total, err := r.queries.SumUserWithdrawalsSince(ctx, gen.SumUserWithdrawalsSinceParams{
+		UserID:       userUUID,
+		WithdrawalID: excludeWithdrawalID,
+		CreatedAt:    since,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to sum withdrawals for user %s: %w", userID, err)
+	}
+	return total, nil
+}
+
+func (r *WithdrawalRepository) SetLimitCheck(ctx context.Context, withdrawalID string, limitCheck json.RawMessage) error {
+	if err := r.queries.SetWithdrawalLimitCheck(ctx, gen.SetWithdrawalLimitCheckParams{
+		LimitCheck:   limitCheck,
+		WithdrawalID: withdrawalID,
+	}); err != nil {
+		return fmt.Errorf("failed to record limit check for withdrawal %s: %w", withdrawalID, err)
+	}
+	return nil
+}
+
+func (r *WithdrawalRepository) SetNetworkFeeTx(ctx context.Context, tx *sql.Tx, withdrawalID string, networkFee json.RawMessage) error {
+	if err := r.queries.WithTx(tx).SetWithdrawalNetworkFee(ctx, gen.SetWithdrawalNetworkFeeParams{
+		NetworkFee:   networkFee,
+		WithdrawalID: withdrawalID,
+	}); err != nil {
+		return fmt.Errorf("failed to record network fee for withdrawal %s: %w", withdrawalID, err)
+	}
+	return nil
+}
+
+func (r *WithdrawalRepository) FlagForAdminReview(ctx context.Context, withdrawalID string, deadline time.Time, limitCheck json.RawMessage) (bool, error) {
+	rows, err := r.queries.FlagWithdrawalForReview(ctx, gen.FlagWithdrawalForReviewParams{
+		AdminReviewDeadline: deadline,
+		LimitCheck:          limitCheck,
+		WithdrawalID:        withdrawalID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to flag withdrawal %s for review: %w", withdrawalID, err)
+	}
+	return rows > 0, nil
+}
+
+func (r *WithdrawalRepository) FlagVerificationMismatch(ctx context.Context, withdrawalID string, deadline time.Time, verification json.RawMessage) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to start transaction for withdrawal %s: %w", withdrawalID, err)
+	}
+	defer tx.Rollback()
+	txStore := r.queries.WithTx(tx)
+
+	rows, err := txStore.FlagWithdrawalVerificationMismatch(ctx, gen.FlagWithdrawalVerificationMismatchParams{
+		AdminReviewDeadline: deadline,
+		Verification:        verification,
+		WithdrawalID:        withdrawalID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to flag withdrawal %s for verification review: %w", withdrawalID, err)
+	}
+	if rows == 0 {
+		return false, nil
+	}
+	if err := txStore.RevokeAllWithdrawalApprovals(ctx, withdrawalID); err != nil {
+		return false, fmt.Errorf("failed to revoke approvals of withdrawal %s: %w", withdrawalID, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit verification review of withdrawal %s: %w", withdrawalID, err)
+	}
+	return true, nil
+}
+
+func (r *WithdrawalRepository) GetByWithdrawalID(ctx context.Context, withdrawalID string) (*domain.Withdrawal, error) {
+	dbWithdrawal, err := r.queries.GetWithdrawalByID(ctx, withdrawalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get withdrawal %s: %w", withdrawalID, err)
+	}
+	return mapDBWithdrawalToDomain(dbWithdrawal), nil
+}
+
+func (r *WithdrawalRepository) ListAwaitingReview(ctx context.Context, limit, offset int) ([]domain.Withdrawal, error) {
+	rows, err := r.queries.ListWithdrawalsAwaitingReview(ctx, gen.ListWithdrawalsAwaitingReviewParams{
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list withdrawals awaiting review: %w", err)
+	}
+	return mapDBWithdrawals(rows), nil
+}
+
+func (r *WithdrawalRepository) ListOverdueReviews(ctx context.Context, now time.Time) ([]domain.Withdrawal, error) {
+	rows, err := r.queries.ListOverdueWithdrawalReviews(ctx, sql.NullTime{Time: now, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list overdue withdrawal reviews: %w", err)
+	}
+	return mapDBWithdrawals(rows), nil
+}
+
+func (r *WithdrawalRepository) UpdateReview(ctx context.Context, withdrawalID string, review json.RawMessage, deadline time.Time) (bool, error) {
+	rows, err := r.queries.UpdateWithdrawalReview(ctx, gen.UpdateWithdrawalReviewParams{
+		AdminReviewDeadline: sql.NullTime{Time: deadline, Valid: !deadline.IsZero()},
+		Review:              review,
+		WithdrawalID:        withdrawalID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to update review of withdrawal %s: %w", withdrawalID, err)
+	}
+	return rows > 0, nil
+}
+
+func (r *WithdrawalRepository) ResolveReview(ctx context.Context, withdrawalID string, status domain.WithdrawalStatus, adminID, errorMessage string, review json.RawMessage) (bool, error) {
+	var adminUUID uuid.NullUUID
+	if adminID != "" {
+		parsed, err := uuid.Parse(adminID)
+		if err != nil {
+			return false, fmt.Errorf("invalid admin ID %s: %w", adminID, err)
+		}
+		adminUUID = uuid.NullUUID{UUID: parsed, Valid: true}
+	}
+
+	rows, err := r.queries.ResolveWithdrawalReview(ctx, gen.ResolveWithdrawalReviewParams{
+		Status:       gen.WithdrawalStatus(status),
+		AdminID:      adminUUID,
+		ErrorMessage: sql.NullString{String: errorMessage, Valid: errorMessage != ""},
+		Review:       review,
+		WithdrawalID: withdrawalID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve review of withdrawal %s: %w", withdrawalID, err)
+	}
+	return rows > 0, nil
+}
+
+func (r *WithdrawalRepository) RejectReview(ctx context.Context, withdrawalID, userID, adminID, errorMessage string, review json.RawMessage, currencyCode string, releaseCents int64) (bool, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return false, fmt.Errorf("invalid user ID %s: %w", userID, err)
+	}
+	var adminUUID uuid.NullUUID
+	if adminID != "" {
+		parsed, err := uuid.Parse(adminID)
+		if err != nil {
+			return false, fmt.Errorf("invalid admin ID %s: %w", adminID, err)
+		}
+		adminUUID = uuid.NullUUID{UUID: parsed, Valid: true}
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to start transaction for withdrawal %s: %w", withdrawalID, err)
+	}
+	defer tx.Rollback()
+	txStore := r.queries.WithTx(tx)
+
+	rows, err := txStore.ResolveWithdrawalReview(ctx, gen.ResolveWithdrawalReviewParams{
+		Status:       gen.WithdrawalStatusCancelled,
+		AdminID:      adminUUID,
+		ErrorMessage: sql.NullString{String: errorMessage, Valid: errorMessage != ""},
+		Review:       review,
+		WithdrawalID: withdrawalID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to reject withdrawal %s: %w", withdrawalID, err)
+	}
+	if rows == 0 {
+		return false, nil
+	}
+
+	if releaseCents > 0 {
+		released, err := txStore.ReleaseBalanceForWithdrawal(ctx, gen.ReleaseBalanceForWithdrawalParams{
+			AmountCents:  releaseCents,
+			UserID:       userUUID,
+			CurrencyCode: currencyCode,
+		})
+		if err != nil {
+			return false, fmt.Errorf("failed to release reservation of withdrawal %s: %w", withdrawalID, err)
+		}
+		if released == 0 {
+			return false, fmt.Errorf("reserved balance of user %s in %s is below the %d held for withdrawal %s", userID, currencyCode, releaseCents, withdrawalID)
+		}
+		if err := txStore.MarkWithdrawalReservationReleased(ctx, withdrawalID); err != nil {
+			return false, fmt.Errorf("failed to mark reservation released for withdrawal %s: %w", withdrawalID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit rejection of withdrawal %s: %w", withdrawalID, err)
+	}
+	return true, nil
+}
+
+func (r *WithdrawalRepository) CreateWithReservation(ctx context.Context, withdrawal domain.Withdrawal, currencyCode string) (*domain.Withdrawal, error) {
+	userUUID, err := uuid.Parse(withdrawal.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID %s: %w", withdrawal.UserID, err)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction for withdrawal %s: %w", withdrawal.WithdrawalID, err)
+	}
+	defer tx.Rollback()
+	txStore := r.queries.WithTx(tx)
+
+	rows, err := txStore.ReserveBalanceForWithdrawal(ctx, gen.ReserveBalanceForWithdrawalParams{
+		AmountCents:  withdrawal.AmountReservedCents,
+		UserID:       userUUID,
+		CurrencyCode: currencyCode,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve balance for withdrawal %s: %w", withdrawal.WithdrawalID, err)
+	}
+	if rows == 0 {
+		return nil, ErrInsufficientBalance
+	}
+
+	dbWithdrawal, err := txStore.CreateWithdrawal(ctx, gen.CreateWithdrawalParams{
+		UserID:              userUUID,
+		WithdrawalID:        withdrawal.WithdrawalID,
+		ChainID:             withdrawal.ChainID,
+		Network:             withdrawal.Network,
+		CryptoCurrency:      withdrawal.CryptoCurrency,
+		UsdAmountCents:      withdrawal.USDAmountCents,
+		CryptoAmount:        withdrawal.CryptoAmount,
+		ExchangeRate:        withdrawal.ExchangeRate,
+		FeeCents:            withdrawal.FeeCents,
+		ToAddress:           withdrawal.ToAddress,
+		SourceWalletAddress: withdrawal.SourceWalletAddress,
+		AmountReservedCents: withdrawal.AmountReservedCents,
+		Metadata:            pqtype.NullRawMessage{RawMessage: withdrawal.Metadata, Valid: len(withdrawal.Metadata) > 0},
+	})
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return nil, ErrWithdrawalExists
+		}
+		return nil, fmt.Errorf("failed to create withdrawal %s: %w", withdrawal.WithdrawalID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit withdrawal %s: %w", withdrawal.WithdrawalID, err)
+	}
+	return mapDBWithdrawalToDomain(dbWithdrawal), nil
+}
+
+func (r *WithdrawalRepository) MarkReservationReleased(ctx context.Context, withdrawalID string) error {
+	if err := r.queries.MarkWithdrawalReservationReleased(ctx, withdrawalID); err != nil {
+		return fmt.Errorf("failed to mark reservation released for withdrawal %s: %w", withdrawalID, err)
+	}
+	return nil
+}
+
+func (r *WithdrawalRepository) ListAwaitingBroadcast(ctx context.Context, limit int) ([]domain.Withdrawal, error) {
+	rows, err := r.queries.ListWithdrawalsAwaitingBroadcast(ctx, int32(limit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list withdrawals awaiting broadcast: %w", err)
+	}
+	return mapDBWithdrawals(rows), nil
+}
+
+func (r *WithdrawalRepository) ClaimBroadcast(ctx context.Context, withdrawalID, previousSignature string, broadcast json.RawMessage) (bool, error) {
+	rows, err := r.queries.ClaimWithdrawalBroadcast(ctx, gen.ClaimWithdrawalBroadcastParams{
+		Broadcast:         broadcast,
+		WithdrawalID:      withdrawalID,
+		PreviousSignature: previousSignature,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to claim broadcast of withdrawal %s: %w", withdrawalID, err)
+	}
+	return rows > 0, nil
+}
+
+func (r *WithdrawalRepository) SetTxHash(ctx context.Context, withdrawalID, txHash string) (bool, error) {
+	rows, err := r.queries.SetWithdrawalTxHash(ctx, gen.SetWithdrawalTxHashParams{
+		WithdrawalID: withdrawalID,
+		TxHash:       sql.NullString{String: txHash, Valid: true},
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to set tx hash of withdrawal %s: %w", withdrawalID, err)
+	}
+	return rows > 0, nil
+}
+
+func mapDBWithdrawals(rows []gen.Withdrawal) []domain.Withdrawal {
+	withdrawals := make([]domain.Withdrawal, len(rows))
+	for i, row := range rows {
+		withdrawals[i] = *mapDBWithdrawalToDomain(row)
+	}
+	return withdrawals
+}
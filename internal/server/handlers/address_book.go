@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/internal/application/addressbookservice"
+	"github.com/tuncanbit/tvs/internal/domain"
+)
+
+type AddressBookHandler struct {
+	addressBookSvc addressbookservice.IAddressBookService
+	logger         zerolog.Logger
+}
+
+func NewAddressBookHandler(addressBookSvc addressbookservice.IAddressBookService, logger zerolog.Logger) *AddressBookHandler {
+	return &AddressBookHandler{
+		addressBookSvc: addressBookSvc,
+		logger:         logger,
+	}
+}
+
+// GetAddressBook returns the caller's saved withdrawal addresses and whether
+// strict mode is on.
+func (h *AddressBookHandler) GetAddressBook(c *gin.Context) {
+	book, err := h.addressBookSvc.GetAddressBook(c.Request.Context(), c.GetString("user_id"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.ApiResponse{
+		Message: "Address book retrieved",
+		Success: true,
+		Status:  http.StatusOK,
+		Data:    book,
+	})
+}
+
+func (h *AddressBookHandler) AddAddress(c *gin.Context) {
+	var req domain.AddWithdrawalAddressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondBadRequest(c, err)
+		return
+	}
+
+	entry, err := h.addressBookSvc.AddAddress(c.Request.Context(), c.GetString("user_id"), req, requestInfo(c))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, domain.ApiResponse{
+		Message: "Address added",
+		Success: true,
+		Status:  http.StatusCreated,
+		Data:    entry,
+	})
+}
+
+func (h *AddressBookHandler) UpdateAddress(c *gin.Context) {
+	var req domain.UpdateWithdrawalAddressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondBadRequest(c, err)
+		return
+	}
+
+	entry, err := h.addressBookSvc.UpdateLabel(c.Request.Context(), c.GetString("user_id"), c.Param("address_id"), req, requestInfo(c))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.ApiResponse{
+		Message: "Address updated",
+		Success: true,
+		Status:  http.StatusOK,
+		Data:    entry,
+	})
+}
+
+func (h *AddressBookHandler) RemoveAddress(c *gin.Context) {
+	if err := h.addressBookSvc.RemoveAddress(c.Request.Context(), c.GetString("user_id"), c.Param("address_id"), requestInfo(c)); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.ApiResponse{
+		Message: "Address removed",
+		Success: true,
+		Status:  http.StatusOK,
+	})
+}
+
+// UpdateSettings turns strict mode on at once, or schedules it to turn off
+// after the cool-down.
+func (h *AddressBookHandler) UpdateSettings(c *gin.Context) {
+	var req domain.AddressBookSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondBadRequest(c, err)
+		return
+	}
+
+	settings, err := h.addressBookSvc.SetStrictMode(c.Request.Context(), c.GetString("user_id"), *req.StrictMode, requestInfo(c))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.ApiResponse{
+		Message: "Address book settings updated",
+		Success: true,
+		Status:  http.StatusOK,
+		Data:    settings,
+	})
+}
+
+func (h *AddressBookHandler) respondBadRequest(c *gin.Context, err error) {
+	c.JSON(http.StatusBadRequest, domain.ApiResponse{
+		Message: "Invalid request: " + err.Error(),
+		Success: false,
+		Status:  http.StatusBadRequest,
+	})
+}
+
+func (h *AddressBookHandler) respondError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, addressbookservice.ErrAddressNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, addressbookservice.ErrAddressExists):
+		status = http.StatusConflict
+	case errors.Is(err, addressbookservice.ErrUnsupportedChain),
+		errors.Is(err, addressbookservice.ErrInvalidAddress):
+		status = http.StatusBadRequest
+	}
+
+	message := err.Error()
+	if status == http.StatusInternalServerError {
+		h.logger.Error().Err(err).Msg("Address book request failed")
+		message = "failed to process address book request"
+	}
+	c.JSON(status, domain.ApiResponse{
+		Message: message,
+		Success: false,
+		Status:  status,
+	})
+}
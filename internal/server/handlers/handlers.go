@@ -3,28 +3,56 @@ package handlers
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/internal/application/addressbookservice"
 	authservice "github.com/tuncanbit/tvs/internal/application/auth"
+	"github.com/tuncanbit/tvs/internal/application/conversionservice"
+	"github.com/tuncanbit/tvs/internal/application/feeservice"
+	"github.com/tuncanbit/tvs/internal/application/playerprotectionservice"
+	"github.com/tuncanbit/tvs/internal/application/quoteservice"
+	"github.com/tuncanbit/tvs/internal/application/reviewservice"
+	"github.com/tuncanbit/tvs/internal/application/travelruleservice"
 	"github.com/tuncanbit/tvs/internal/application/verificationservice"
+	"github.com/tuncanbit/tvs/internal/application/withdrawalservice"
 	"github.com/tuncanbit/tvs/internal/server/middleware"
 	"github.com/tuncanbit/tvs/internal/server/websocket"
+	"github.com/tuncanbit/tvs/pkg/circuitbreaker"
 	"github.com/tuncanbit/tvs/pkg/config"
+	"github.com/tuncanbit/tvs/pkg/metrics"
 )
 
 type Handlers struct {
-	VerificationSvc verificationservice.IVerificationService
-	AuthSvc         authservice.IAuthService
-	Logger          zerolog.Logger
-	Config          *config.Config
-	WsHub           *websocket.WsHub
+	VerificationSvc     verificationservice.IVerificationService
+	AuthSvc             authservice.IAuthService
+	ConversionSvc       conversionservice.IConversionService
+	FeeSvc              feeservice.IFeeService
+	QuoteSvc            quoteservice.IQuoteService
+	ReviewSvc           reviewservice.IReviewService
+	WithdrawalSvc       withdrawalservice.IWithdrawalService
+	AddressBookSvc      addressbookservice.IAddressBookService
+	TravelRuleSvc       travelruleservice.ITravelRuleService
+	PlayerProtectionSvc playerprotectionservice.IPlayerProtectionService
+	Logger              zerolog.Logger
+	Config              *config.Config
+	WsHub               *websocket.WsHub
+	Breakers            *circuitbreaker.Registry
 }
 
-func New(verificationSvc verificationservice.IVerificationService, AuthSvc authservice.IAuthService, logger zerolog.Logger, config *config.Config, wsHub *websocket.WsHub) *Handlers {
+func New(verificationSvc verificationservice.IVerificationService, AuthSvc authservice.IAuthService, conversionSvc conversionservice.IConversionService, feeSvc feeservice.IFeeService, quoteSvc quoteservice.IQuoteService, reviewSvc reviewservice.IReviewService, withdrawalSvc withdrawalservice.IWithdrawalService, addressBookSvc addressbookservice.IAddressBookService, travelRuleSvc travelruleservice.ITravelRuleService, playerProtectionSvc playerprotectionservice.IPlayerProtectionService, logger zerolog.Logger, config *config.Config, wsHub *websocket.WsHub, breakers *circuitbreaker.Registry) *Handlers {
 	return &Handlers{
-		VerificationSvc: verificationSvc,
-		AuthSvc:         AuthSvc,
-		Logger:          logger,
-		Config:          config,
-		WsHub:           wsHub,
+		VerificationSvc:     verificationSvc,
+		AuthSvc:             AuthSvc,
+		ConversionSvc:       conversionSvc,
+		FeeSvc:              feeSvc,
+		QuoteSvc:            quoteSvc,
+		ReviewSvc:           reviewSvc,
+		WithdrawalSvc:       withdrawalSvc,
+		AddressBookSvc:      addressBookSvc,
+		TravelRuleSvc:       travelRuleSvc,
+		PlayerProtectionSvc: playerProtectionSvc,
+		Logger:              logger,
+		Config:              config,
+		WsHub:               wsHub,
+		Breakers:            breakers,
 	}
 }
 
@@ -32,16 +60,26 @@ func (h *Handlers) SetupHandlers(router *gin.Engine) {
 	m := middleware.NewMiddleware(h.AuthSvc, h.Logger)
 	m.SetupMiddleware(router)
 
-	healthHandler := NewHealthHandler()
+	healthHandler := NewHealthHandler(h.Breakers)
 	sessionStatusHandler := NewSessionStatusHandler(h.WsHub, h.Logger)
 	webhookHandler := NewWebhookHandler(h.VerificationSvc, h.Logger)
 	messageHandler := NewMessageHandler(h.WsHub)
+	reportHandler := NewReportHandler(h.ConversionSvc, h.FeeSvc, h.Logger)
+	quoteHandler := NewQuoteHandler(h.QuoteSvc, h.Logger)
+	reviewHandler := NewReviewHandler(h.ReviewSvc, h.Logger)
+	withdrawalHandler := NewWithdrawalHandler(h.WithdrawalSvc, h.Logger)
+	addressBookHandler := NewAddressBookHandler(h.AddressBookSvc, h.Logger)
+	travelRuleHandler := NewTravelRuleHandler(h.TravelRuleSvc, h.Logger)
+	playerProtectionHandler := NewPlayerProtectionHandler(h.PlayerProtectionSvc, h.ReviewSvc, h.Logger)
 
 	router.GET("/health", healthHandler.Health)
+	router.GET("/metrics", gin.WrapH(metrics.Handler()))
 
 	esRoute := router.Group("/tvs/api/es").Use(m.APIKeyMiddleware())
 	{
 		esRoute.POST("/messages/send", messageHandler.HandleMessage)
+		esRoute.GET("/reports/rounding-drift", reportHandler.RoundingDrift)
+		esRoute.GET("/reports/network-fees", reportHandler.NetworkFees)
 	}
 
 	v1 := router.Group("/tvs/api/v1").Use(m.AuthMiddleware())
@@ -49,5 +87,38 @@ func (h *Handlers) SetupHandlers(router *gin.Engine) {
 		v1.GET("/status/ws", sessionStatusHandler.HandleWebSocket)
 		v1.GET("/webhook/verify", webhookHandler.HandlePDMWebhook)
 		v1.GET("/test", sessionStatusHandler.TestWebSocket)
+		v1.POST("/quotes", quoteHandler.CreateQuote)
+		v1.GET("/quotes/:session_id", quoteHandler.GetQuote)
+		v1.POST("/withdrawals", withdrawalHandler.CreateWithdrawal)
+		v1.GET("/withdrawals/fee-estimate", withdrawalHandler.EstimateFee)
+		v1.GET("/withdrawals/:withdrawal_id", withdrawalHandler.GetWithdrawal)
+		v1.GET("/withdrawal-addresses", addressBookHandler.GetAddressBook)
+		v1.POST("/withdrawal-addresses", addressBookHandler.AddAddress)
+		v1.PATCH("/withdrawal-addresses/:address_id", addressBookHandler.UpdateAddress)
+		v1.DELETE("/withdrawal-addresses/:address_id", addressBookHandler.RemoveAddress)
+		v1.PUT("/withdrawal-addresses/settings", addressBookHandler.UpdateSettings)
+		v1.GET("/player-protection", playerProtectionHandler.GetProtection)
+		v1.PUT("/player-protection/deposit-limits", playerProtectionHandler.SetDepositLimit)
+		v1.POST("/player-protection/self-exclusion", playerProtectionHandler.SelfExclude)
+		v1.GET("/deposits/refundable", playerProtectionHandler.ListRefundableDeposits)
+		v1.POST("/deposits/:session_id/refund", playerProtectionHandler.RequestRefund)
+	}
+
+	admin := router.Group("/tvs/api/v1/admin").Use(m.AuthMiddleware(), m.AdminMiddleware())
+	{
+		admin.GET("/withdrawals/review", reviewHandler.ListQueue)
+		admin.POST("/withdrawals/:withdrawal_id/approve", reviewHandler.Approve)
+		admin.DELETE("/withdrawals/:withdrawal_id/approve", reviewHandler.RevokeApproval)
+		admin.POST("/withdrawals/:withdrawal_id/reject", reviewHandler.Reject)
+		admin.POST("/withdrawals/:withdrawal_id/notes", reviewHandler.AddNote)
+		admin.GET("/withdrawals/:withdrawal_id/travel-rule", travelRuleHandler.Export)
+		admin.GET("/deposits/review", reviewHandler.ListHeldDeposits)
+		admin.POST("/deposits/:session_id/release", reviewHandler.ReleaseDeposit)
+		admin.POST("/deposits/:session_id/reject", reviewHandler.RejectDeposit)
+		admin.GET("/deposits/refunds", reviewHandler.ListRefundableDeposits)
+		admin.POST("/deposits/:session_id/refunded", reviewHandler.MarkDepositRefunded)
+		admin.GET("/users/:user_id/player-protection", playerProtectionHandler.GetProtection)
+		admin.PUT("/users/:user_id/deposit-limits", playerProtectionHandler.SetDepositLimit)
+		admin.POST("/users/:user_id/self-exclusion", playerProtectionHandler.SelfExclude)
 	}
 }
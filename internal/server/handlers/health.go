@@ -5,19 +5,35 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/tuncanbit/tvs/pkg/circuitbreaker"
 )
 
-type HealthHandler struct{}
+type HealthHandler struct {
+	breakers *circuitbreaker.Registry
+}
 
-func NewHealthHandler() *HealthHandler {
-	return &HealthHandler{}
+func NewHealthHandler(breakers *circuitbreaker.Registry) *HealthHandler {
+	return &HealthHandler{
+		breakers: breakers,
+	}
 }
 
 func (h *HealthHandler) Health(c *gin.Context) {
+	providers := h.breakers.Statuses()
+
+	status := "healthy"
+	for _, provider := range providers {
+		if provider.State != circuitbreaker.StateClosed {
+			status = "degraded"
+			break
+		}
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"status":    "healthy",
+		"status":    status,
 		"service":   "tvs",
 		"version":   "1.0.0",
+		"providers": providers,
 		"timestamp": time.Now(),
 	})
 }
@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/internal/application/playerprotectionservice"
+	"github.com/tuncanbit/tvs/internal/application/reviewservice"
+	"github.com/tuncanbit/tvs/internal/domain"
+)
+
+// PlayerProtectionHandler serves deposit limits, self-exclusion and refunds
+// of the deposits they hold. Under the admin routes the user is taken from
+// the :user_id parameter and the caller acts for them.
+type PlayerProtectionHandler struct {
+	playerProtectionSvc playerprotectionservice.IPlayerProtectionService
+	reviewSvc           reviewservice.IReviewService
+	logger              zerolog.Logger
+}
+
+func NewPlayerProtectionHandler(playerProtectionSvc playerprotectionservice.IPlayerProtectionService, reviewSvc reviewservice.IReviewService, logger zerolog.Logger) *PlayerProtectionHandler {
+	return &PlayerProtectionHandler{
+		playerProtectionSvc: playerProtectionSvc,
+		reviewSvc:           reviewSvc,
+		logger:              logger,
+	}
+}
+
+func (h *PlayerProtectionHandler) GetProtection(c *gin.Context) {
+	protection, err := h.playerProtectionSvc.GetProtection(c.Request.Context(), targetUser(c))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.ApiResponse{
+		Message: "Player protection retrieved",
+		Success: true,
+		Status:  http.StatusOK,
+		Data:    protection,
+	})
+}
+
+// SetDepositLimit lowers a limit at once, or schedules an increase or
+// removal for after the cool-off.
+func (h *PlayerProtectionHandler) SetDepositLimit(c *gin.Context) {
+	var req domain.SetDepositLimitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondBadRequest(c, err)
+		return
+	}
+
+	limit, err := h.playerProtectionSvc.SetDepositLimit(c.Request.Context(), c.GetString("user_id"), targetUser(c), req, requestInfo(c))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.ApiResponse{
+		Message: "Deposit limit updated",
+		Success: true,
+		Status:  http.StatusOK,
+		Data:    limit,
+	})
+}
+
+func (h *PlayerProtectionHandler) SelfExclude(c *gin.Context) {
+	var req domain.SelfExclusionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondBadRequest(c, err)
+		return
+	}
+
+	exclusion, err := h.playerProtectionSvc.SelfExclude(c.Request.Context(), c.GetString("user_id"), targetUser(c), req, requestInfo(c))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, domain.ApiResponse{
+		Message: "Self-exclusion started",
+		Success: true,
+		Status:  http.StatusCreated,
+		Data:    exclusion,
+	})
+}
+
+// ListRefundableDeposits returns the caller's deposits held by a deposit
+// limit or self-exclusion.
+func (h *PlayerProtectionHandler) ListRefundableDeposits(c *gin.Context) {
+	sessions, err := h.reviewSvc.ListUserRefundableDeposits(c.Request.Context(), c.GetString("user_id"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.ApiResponse{
+		Message: "Refundable deposits retrieved",
+		Success: true,
+		Status:  http.StatusOK,
+		Data:    sessions,
+	})
+}
+
+func (h *PlayerProtectionHandler) RequestRefund(c *gin.Context) {
+	var req domain.RequestDepositRefundRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.respondBadRequest(c, err)
+			return
+		}
+	}
+
+	session, err := h.reviewSvc.RequestDepositRefund(c.Request.Context(), c.GetString("user_id"), c.Param("session_id"), req, requestInfo(c))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.ApiResponse{
+		Message: "Refund requested",
+		Success: true,
+		Status:  http.StatusOK,
+		Data:    session,
+	})
+}
+
+func (h *PlayerProtectionHandler) respondBadRequest(c *gin.Context, err error) {
+	c.JSON(http.StatusBadRequest, domain.ApiResponse{
+		Message: "Invalid request: " + err.Error(),
+		Success: false,
+		Status:  http.StatusBadRequest,
+	})
+}
+
+func (h *PlayerProtectionHandler) respondError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, playerprotectionservice.ErrDepositLimitNotFound),
+		errors.Is(err, playerprotectionservice.ErrPlayerProtectionOff),
+		errors.Is(err, reviewservice.ErrDepositNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, playerprotectionservice.ErrSelfExclusionInForce),
+		errors.Is(err, reviewservice.ErrDepositNotRefundable),
+		errors.Is(err, reviewservice.ErrRefundRequested):
+		status = http.StatusConflict
+	case errors.Is(err, playerprotectionservice.ErrInvalidSelfExclusion),
+		errors.Is(err, reviewservice.ErrInvalidRefundAddress):
+		status = http.StatusBadRequest
+	}
+
+	message := err.Error()
+	if status == http.StatusInternalServerError {
+		h.logger.Error().Err(err).Msg("Player protection request failed")
+		message = "failed to process player protection request"
+	}
+	c.JSON(status, domain.ApiResponse{
+		Message: message,
+		Success: false,
+		Status:  status,
+	})
+}
+
+// targetUser is the user a request is about: the :user_id parameter under
+// the admin routes, otherwise the caller.
+func targetUser(c *gin.Context) string {
+	if userID := c.Param("user_id"); userID != "" {
+		return userID
+	}
+	return c.GetString("user_id")
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/internal/application/quoteservice"
+	"github.com/tuncanbit/tvs/internal/domain"
+)
+
+type QuoteHandler struct {
+	quoteSvc quoteservice.IQuoteService
+	logger   zerolog.Logger
+}
+
+func NewQuoteHandler(quoteSvc quoteservice.IQuoteService, logger zerolog.Logger) *QuoteHandler {
+	return &QuoteHandler{
+		quoteSvc: quoteSvc,
+		logger:   logger,
+	}
+}
+
+// CreateQuote locks the crypto amount for a fiat-denominated deposit into
+// one of the caller's pending sessions.
+func (h *QuoteHandler) CreateQuote(c *gin.Context) {
+	var req domain.CreateQuoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ApiResponse{
+			Message: "Invalid request: " + err.Error(),
+			Success: false,
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	quote, err := h.quoteSvc.CreateQuote(c.Request.Context(), c.GetString("user_id"), req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, domain.ApiResponse{
+		Message: "Quote created",
+		Success: true,
+		Status:  http.StatusCreated,
+		Data:    quote,
+	})
+}
+
+func (h *QuoteHandler) GetQuote(c *gin.Context) {
+	quote, err := h.quoteSvc.GetQuote(c.Request.Context(), c.GetString("user_id"), c.Param("session_id"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.ApiResponse{
+		Message: "Quote retrieved",
+		Success: true,
+		Status:  http.StatusOK,
+		Data:    quote,
+	})
+}
+
+func (h *QuoteHandler) respondError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, quoteservice.ErrSessionNotFound),
+		errors.Is(err, quoteservice.ErrQuoteNotFound),
+		errors.Is(err, quoteservice.ErrNotSessionOwner):
+		status = http.StatusNotFound
+	case errors.Is(err, quoteservice.ErrSessionNotPending),
+		errors.Is(err, quoteservice.ErrQuoteSettled):
+		status = http.StatusConflict
+	case errors.Is(err, quoteservice.ErrUnsupportedCurrency),
+		errors.Is(err, quoteservice.ErrInvalidSlippage):
+		status = http.StatusBadRequest
+	case errors.Is(err, quoteservice.ErrPriceUnavailable):
+		status = http.StatusServiceUnavailable
+	}
+
+	message := err.Error()
+	if status == http.StatusInternalServerError {
+		h.logger.Error().Err(err).Msg("Quote request failed")
+		message = "failed to process quote"
+	}
+	c.JSON(status, domain.ApiResponse{
+		Message: message,
+		Success: false,
+		Status:  status,
+	})
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/internal/application/conversionservice"
+	"github.com/tuncanbit/tvs/internal/application/feeservice"
+)
+
+const defaultReportWindow = 30 * 24 * time.Hour
+
+type ReportHandler struct {
+	conversionSvc conversionservice.IConversionService
+	feeSvc        feeservice.IFeeService
+	logger        zerolog.Logger
+}
+
+func NewReportHandler(conversionSvc conversionservice.IConversionService, feeSvc feeservice.IFeeService, logger zerolog.Logger) *ReportHandler {
+	return &ReportHandler{
+		conversionSvc: conversionSvc,
+		feeSvc:        feeSvc,
+		logger:        logger,
+	}
+}
+
+// RoundingDrift reports the cumulative conversion remainders per currency
+// between the optional RFC 3339 "from" and "to" query parameters, defaulting
+// to the last 30 days.
+func (h *ReportHandler) RoundingDrift(c *gin.Context) {
+	from, to, err := reportWindow(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	drift, err := h.conversionSvc.GetRoundingDrift(c.Request.Context(), from, to)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to build rounding drift report")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to build rounding drift report"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"from":  from,
+		"to":    to,
+		"unit":  "minor_units",
+		"drift": drift,
+	})
+}
+
+// NetworkFees reports the network fees posted to the fee account per chain,
+// transaction type and payer, over the same window as RoundingDrift.
+func (h *ReportHandler) NetworkFees(c *gin.Context) {
+	from, to, err := reportWindow(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	fees, err := h.feeSvc.GetNetworkFeeReport(c.Request.Context(), from, to)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to build network fee report")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to build network fee report"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"from": from,
+		"to":   to,
+		"fees": fees,
+	})
+}
+
+func reportWindow(c *gin.Context) (time.Time, time.Time, error) {
+	to := time.Now()
+	if raw := c.Query("to"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		to = parsed
+	}
+
+	from := to.Add(-defaultReportWindow)
+	if raw := c.Query("from"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		from = parsed
+	}
+	return from, to, nil
+}
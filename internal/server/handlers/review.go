@@ -0,0 +1,278 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/internal/application/reviewservice"
+	"github.com/tuncanbit/tvs/internal/domain"
+)
+
+const (
+	defaultReviewQueueLimit = 50
+	maxReviewQueueLimit     = 200
+)
+
+type ReviewHandler struct {
+	reviewSvc reviewservice.IReviewService
+	logger    zerolog.Logger
+}
+
+func NewReviewHandler(reviewSvc reviewservice.IReviewService, logger zerolog.Logger) *ReviewHandler {
+	return &ReviewHandler{
+		reviewSvc: reviewSvc,
+		logger:    logger,
+	}
+}
+
+// ListQueue returns withdrawals awaiting admin review, closest deadline first.
+func (h *ReviewHandler) ListQueue(c *gin.Context) {
+	limit, offset := reviewPage(c)
+	withdrawals, err := h.reviewSvc.ListQueue(c.Request.Context(), limit, offset)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.ApiResponse{
+		Message: "Review queue retrieved",
+		Success: true,
+		Status:  http.StatusOK,
+		Data:    withdrawals,
+	})
+}
+
+// Approve records the caller's approval. The response reports whether the
+// withdrawal's approval quorum has been reached.
+func (h *ReviewHandler) Approve(c *gin.Context) {
+	var req domain.ApproveWithdrawalRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.respondBadRequest(c, err)
+			return
+		}
+	}
+
+	status, err := h.reviewSvc.Approve(c.Request.Context(), c.GetString("user_id"), c.Param("withdrawal_id"), req, requestInfo(c))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	h.respondApprovalStatus(c, status)
+}
+
+func (h *ReviewHandler) RevokeApproval(c *gin.Context) {
+	status, err := h.reviewSvc.RevokeApproval(c.Request.Context(), c.GetString("user_id"), c.Param("withdrawal_id"), requestInfo(c))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	h.respondApprovalStatus(c, status)
+}
+
+func (h *ReviewHandler) respondApprovalStatus(c *gin.Context, status *domain.ApprovalStatus) {
+	message := "Withdrawal approved"
+	if !status.QuorumReached {
+		message = fmt.Sprintf("Approval quorum pending: %d of %d", len(status.Approvals), status.RequiredApprovals)
+	}
+	c.JSON(http.StatusOK, domain.ApiResponse{
+		Message: message,
+		Success: true,
+		Status:  http.StatusOK,
+		Data:    status,
+	})
+}
+
+func (h *ReviewHandler) Reject(c *gin.Context) {
+	var req domain.RejectWithdrawalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondBadRequest(c, err)
+		return
+	}
+
+	withdrawal, err := h.reviewSvc.Reject(c.Request.Context(), c.GetString("user_id"), c.Param("withdrawal_id"), req, requestInfo(c))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	h.respondWithdrawal(c, "Withdrawal rejected", withdrawal)
+}
+
+func (h *ReviewHandler) AddNote(c *gin.Context) {
+	var req domain.ReviewNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondBadRequest(c, err)
+		return
+	}
+
+	withdrawal, err := h.reviewSvc.AddNote(c.Request.Context(), c.GetString("user_id"), c.Param("withdrawal_id"), req, requestInfo(c))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	h.respondWithdrawal(c, "Note added", withdrawal)
+}
+
+// ListHeldDeposits returns deposit sessions held for admin review, oldest
+// first.
+func (h *ReviewHandler) ListHeldDeposits(c *gin.Context) {
+	limit, offset := reviewPage(c)
+	sessions, err := h.reviewSvc.ListHeldDeposits(c.Request.Context(), limit, offset)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.ApiResponse{
+		Message: "Held deposits retrieved",
+		Success: true,
+		Status:  http.StatusOK,
+		Data:    sessions,
+	})
+}
+
+// ReleaseDeposit returns a held deposit to verification to be credited.
+func (h *ReviewHandler) ReleaseDeposit(c *gin.Context) {
+	var req domain.ReleaseDepositRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.respondBadRequest(c, err)
+			return
+		}
+	}
+
+	session, err := h.reviewSvc.ReleaseDeposit(c.Request.Context(), c.GetString("user_id"), c.Param("session_id"), req, requestInfo(c))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	h.respondDeposit(c, "Deposit released", session)
+}
+
+func (h *ReviewHandler) RejectDeposit(c *gin.Context) {
+	var req domain.RejectDepositRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondBadRequest(c, err)
+		return
+	}
+
+	session, err := h.reviewSvc.RejectDeposit(c.Request.Context(), c.GetString("user_id"), c.Param("session_id"), req, requestInfo(c))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	h.respondDeposit(c, "Deposit rejected", session)
+}
+
+// ListRefundableDeposits returns deposit sessions held by a deposit limit or
+// self-exclusion and awaiting refund, oldest first.
+func (h *ReviewHandler) ListRefundableDeposits(c *gin.Context) {
+	limit, offset := reviewPage(c)
+	sessions, err := h.reviewSvc.ListRefundableDeposits(c.Request.Context(), limit, offset)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.ApiResponse{
+		Message: "Refundable deposits retrieved",
+		Success: true,
+		Status:  http.StatusOK,
+		Data:    sessions,
+	})
+}
+
+// MarkDepositRefunded records the transaction a limit-held deposit was sent
+// back with.
+func (h *ReviewHandler) MarkDepositRefunded(c *gin.Context) {
+	var req domain.MarkDepositRefundedRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.respondBadRequest(c, err)
+		return
+	}
+
+	session, err := h.reviewSvc.MarkDepositRefunded(c.Request.Context(), c.GetString("user_id"), c.Param("session_id"), req, requestInfo(c))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	h.respondDeposit(c, "Deposit refunded", session)
+}
+
+func (h *ReviewHandler) respondDeposit(c *gin.Context, message string, session *domain.DepositSession) {
+	c.JSON(http.StatusOK, domain.ApiResponse{
+		Message: message,
+		Success: true,
+		Status:  http.StatusOK,
+		Data:    session,
+	})
+}
+
+func (h *ReviewHandler) respondWithdrawal(c *gin.Context, message string, withdrawal *domain.Withdrawal) {
+	c.JSON(http.StatusOK, domain.ApiResponse{
+		Message: message,
+		Success: true,
+		Status:  http.StatusOK,
+		Data:    withdrawal,
+	})
+}
+
+func (h *ReviewHandler) respondBadRequest(c *gin.Context, err error) {
+	c.JSON(http.StatusBadRequest, domain.ApiResponse{
+		Message: "Invalid request: " + err.Error(),
+		Success: false,
+		Status:  http.StatusBadRequest,
+	})
+}
+
+func (h *ReviewHandler) respondError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	message := err.Error()
+	switch {
+	case errors.Is(err, reviewservice.ErrWithdrawalNotFound),
+		errors.Is(err, reviewservice.ErrDepositNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, reviewservice.ErrNotAwaitingReview),
+		errors.Is(err, reviewservice.ErrAlreadyApproved),
+		errors.Is(err, reviewservice.ErrNoApproval),
+		errors.Is(err, reviewservice.ErrDepositNotHeld),
+		errors.Is(err, reviewservice.ErrDepositNotRefundable):
+		status = http.StatusConflict
+	case errors.Is(err, reviewservice.ErrInvalidRefundAddress):
+		status = http.StatusBadRequest
+	case errors.Is(err, reviewservice.ErrSelfApproval):
+		status = http.StatusForbidden
+	default:
+		h.logger.Error().Err(err).Msg("Withdrawal review request failed")
+		message = "failed to process review request"
+	}
+
+	c.JSON(status, domain.ApiResponse{
+		Message: message,
+		Success: false,
+		Status:  status,
+	})
+}
+
+func reviewPage(c *gin.Context) (int, int) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultReviewQueueLimit)))
+	if err != nil || limit <= 0 || limit > maxReviewQueueLimit {
+		limit = defaultReviewQueueLimit
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
+
+func requestInfo(c *gin.Context) domain.RequestInfo {
+	return domain.RequestInfo{
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+}
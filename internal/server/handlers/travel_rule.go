@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/internal/application/travelruleservice"
+	"github.com/tuncanbit/tvs/internal/domain"
+)
+
+type TravelRuleHandler struct {
+	travelRuleSvc travelruleservice.ITravelRuleService
+	logger        zerolog.Logger
+}
+
+func NewTravelRuleHandler(travelRuleSvc travelruleservice.ITravelRuleService, logger zerolog.Logger) *TravelRuleHandler {
+	return &TravelRuleHandler{
+		travelRuleSvc: travelRuleSvc,
+		logger:        logger,
+	}
+}
+
+// Export returns a withdrawal's decrypted Travel Rule record with the
+// originator and beneficiary in IVMS101 form.
+func (h *TravelRuleHandler) Export(c *gin.Context) {
+	export, err := h.travelRuleSvc.Export(c.Request.Context(), c.GetString("user_id"), c.Param("withdrawal_id"), requestInfo(c))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.ApiResponse{
+		Message: "Travel rule data exported",
+		Success: true,
+		Status:  http.StatusOK,
+		Data:    export,
+	})
+}
+
+func (h *TravelRuleHandler) respondError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, travelruleservice.ErrWithdrawalNotFound),
+		errors.Is(err, travelruleservice.ErrNoTravelRule):
+		status = http.StatusNotFound
+	}
+
+	message := err.Error()
+	if status == http.StatusInternalServerError {
+		h.logger.Error().Err(err).Msg("Travel rule export failed")
+		message = "failed to export travel rule data"
+	}
+	c.JSON(status, domain.ApiResponse{
+		Message: message,
+		Success: false,
+		Status:  status,
+	})
+}
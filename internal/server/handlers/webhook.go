@@ -1,9 +1,13 @@
 package handlers
 
 import (
+	"errors"
+	"net/http"
+
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog"
 	"github.com/tuncanbit/tvs/internal/application/verificationservice"
+	"github.com/tuncanbit/tvs/internal/domain"
 )
 
 type WebhookHandler struct {
@@ -19,5 +23,34 @@ func NewWebhookHandler(verificationSvc verificationservice.IVerificationService,
 }
 
 func (h *WebhookHandler) HandlePDMWebhook(c *gin.Context) {
+	var req domain.PDMWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ApiResponse{
+			Message: "Invalid request: " + err.Error(),
+			Success: false,
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	if err := h.verificationSvc.VerifyTransactionFromPDMWebhook(c.Request.Context(), req); err != nil {
+		status, message := http.StatusInternalServerError, "failed to process webhook"
+		if errors.Is(err, verificationservice.ErrInvalidWebhookPayload) {
+			status, message = http.StatusBadRequest, err.Error()
+		} else {
+			h.logger.Error().Err(err).Str("event_type", string(req.EventType)).Msg("PDM webhook failed")
+		}
+		c.JSON(status, domain.ApiResponse{
+			Message: message,
+			Success: false,
+			Status:  status,
+		})
+		return
+	}
 
+	c.JSON(http.StatusOK, domain.ApiResponse{
+		Message: "Webhook accepted",
+		Success: true,
+		Status:  http.StatusOK,
+	})
 }
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/internal/application/withdrawalservice"
+	"github.com/tuncanbit/tvs/internal/domain"
+)
+
+type WithdrawalHandler struct {
+	withdrawalSvc withdrawalservice.IWithdrawalService
+	logger        zerolog.Logger
+}
+
+func NewWithdrawalHandler(withdrawalSvc withdrawalservice.IWithdrawalService, logger zerolog.Logger) *WithdrawalHandler {
+	return &WithdrawalHandler{
+		withdrawalSvc: withdrawalSvc,
+		logger:        logger,
+	}
+}
+
+// CreateWithdrawal requests a payout from the caller's balance. A request
+// replayed with the same idempotency key returns the original withdrawal
+// with 200 instead of 201.
+func (h *WithdrawalHandler) CreateWithdrawal(c *gin.Context) {
+	var req domain.CreateWithdrawalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ApiResponse{
+			Message: "Invalid request: " + err.Error(),
+			Success: false,
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+	if req.IdempotencyKey == "" {
+		req.IdempotencyKey = c.GetHeader("Idempotency-Key")
+	}
+
+	withdrawal, created, err := h.withdrawalSvc.CreateWithdrawal(c.Request.Context(), c.GetString("user_id"), req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	status, message := http.StatusCreated, "Withdrawal created"
+	if !created {
+		status, message = http.StatusOK, "Withdrawal already exists for this idempotency key"
+	}
+	c.JSON(status, domain.ApiResponse{
+		Message: message,
+		Success: true,
+		Status:  status,
+		Data:    withdrawal,
+	})
+}
+
+// EstimateFee returns what a withdrawal would cost before it is submitted.
+func (h *WithdrawalHandler) EstimateFee(c *gin.Context) {
+	var req domain.WithdrawalFeeEstimateRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ApiResponse{
+			Message: "Invalid request: " + err.Error(),
+			Success: false,
+			Status:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	estimate, err := h.withdrawalSvc.EstimateFees(c.Request.Context(), c.GetString("user_id"), req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.ApiResponse{
+		Message: "Withdrawal fee estimated",
+		Success: true,
+		Status:  http.StatusOK,
+		Data:    estimate,
+	})
+}
+
+func (h *WithdrawalHandler) GetWithdrawal(c *gin.Context) {
+	withdrawal, err := h.withdrawalSvc.GetWithdrawal(c.Request.Context(), c.GetString("user_id"), c.Param("withdrawal_id"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.ApiResponse{
+		Message: "Withdrawal retrieved",
+		Success: true,
+		Status:  http.StatusOK,
+		Data:    withdrawal,
+	})
+}
+
+func (h *WithdrawalHandler) respondError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, withdrawalservice.ErrWithdrawalNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, withdrawalservice.ErrIdempotencyConflict):
+		status = http.StatusConflict
+	case errors.Is(err, withdrawalservice.ErrDestinationNotAllowed),
+		errors.Is(err, withdrawalservice.ErrAccountRestricted):
+		status = http.StatusForbidden
+	case errors.Is(err, withdrawalservice.ErrInvalidIdempotencyKey),
+		errors.Is(err, withdrawalservice.ErrUnsupportedChain),
+		errors.Is(err, withdrawalservice.ErrUnsupportedCurrency),
+		errors.Is(err, withdrawalservice.ErrInvalidAddress),
+		errors.Is(err, withdrawalservice.ErrAmountTooSmall),
+		errors.Is(err, withdrawalservice.ErrInvalidTravelRule):
+		status = http.StatusBadRequest
+	case errors.Is(err, withdrawalservice.ErrInsufficientBalance),
+		errors.Is(err, withdrawalservice.ErrTravelRuleRequired):
+		status = http.StatusUnprocessableEntity
+	case errors.Is(err, withdrawalservice.ErrPriceUnavailable):
+		status = http.StatusServiceUnavailable
+	}
+
+	message := err.Error()
+	if status == http.StatusInternalServerError {
+		h.logger.Error().Err(err).Msg("Withdrawal request failed")
+		message = "failed to process withdrawal"
+	}
+	c.JSON(status, domain.ApiResponse{
+		Message: message,
+		Success: false,
+		Status:  status,
+	})
+}
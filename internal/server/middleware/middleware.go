@@ -137,3 +137,30 @@ func (m *Middleware) APIKeyMiddleware() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// AdminMiddleware only admits users whose user_type is ADMIN. It must run
+// after AuthMiddleware, which sets user_id.
+func (m *Middleware) AdminMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.GetString("user_id")
+		isAdmin, err := m.AuthSvc.IsAdmin(c.Request.Context(), userID)
+		if err != nil {
+			m.logger.Error().Err(err).Str("user_id", userID).Msg("Failed to check admin role")
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "Unable to verify admin role",
+			})
+			c.Abort()
+			return
+		}
+		if !isAdmin {
+			m.logger.Warn().Str("user_id", userID).Msg("Non-admin user attempted admin access")
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "Admin access required",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -11,35 +11,62 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog"
 
+	"github.com/tuncanbit/tvs/internal/application/addressbookservice"
 	authservice "github.com/tuncanbit/tvs/internal/application/auth"
+	"github.com/tuncanbit/tvs/internal/application/conversionservice"
+	"github.com/tuncanbit/tvs/internal/application/feeservice"
+	"github.com/tuncanbit/tvs/internal/application/playerprotectionservice"
+	"github.com/tuncanbit/tvs/internal/application/quoteservice"
+	"github.com/tuncanbit/tvs/internal/application/reviewservice"
+	"github.com/tuncanbit/tvs/internal/application/travelruleservice"
 	"github.com/tuncanbit/tvs/internal/application/verificationservice"
+	"github.com/tuncanbit/tvs/internal/application/withdrawalservice"
 	"github.com/tuncanbit/tvs/internal/server/handlers"
 	"github.com/tuncanbit/tvs/internal/server/websocket"
+	"github.com/tuncanbit/tvs/pkg/circuitbreaker"
 	"github.com/tuncanbit/tvs/pkg/config"
 )
 
 type Server struct {
-	VerificationSvc verificationservice.IVerificationService
-	AuthSvc         authservice.IAuthService
-	Cfg             *config.Config
-	Logger          zerolog.Logger
-	Router          *gin.Engine
-	httpServer      *http.Server
-	WsHub           *websocket.WsHub
+	VerificationSvc     verificationservice.IVerificationService
+	AuthSvc             authservice.IAuthService
+	ConversionSvc       conversionservice.IConversionService
+	FeeSvc              feeservice.IFeeService
+	QuoteSvc            quoteservice.IQuoteService
+	ReviewSvc           reviewservice.IReviewService
+	WithdrawalSvc       withdrawalservice.IWithdrawalService
+	AddressBookSvc      addressbookservice.IAddressBookService
+	TravelRuleSvc       travelruleservice.ITravelRuleService
+	PlayerProtectionSvc playerprotectionservice.IPlayerProtectionService
+	Cfg                 *config.Config
+	Logger              zerolog.Logger
+	Router              *gin.Engine
+	httpServer          *http.Server
+	WsHub               *websocket.WsHub
+	Breakers            *circuitbreaker.Registry
 }
 
-func New(cfg *config.Config, verificationService verificationservice.IVerificationService, AuthSvc authservice.IAuthService, logger zerolog.Logger, WsHub *websocket.WsHub) *Server {
+func New(cfg *config.Config, verificationService verificationservice.IVerificationService, AuthSvc authservice.IAuthService, conversionSvc conversionservice.IConversionService, feeSvc feeservice.IFeeService, quoteSvc quoteservice.IQuoteService, reviewSvc reviewservice.IReviewService, withdrawalSvc withdrawalservice.IWithdrawalService, addressBookSvc addressbookservice.IAddressBookService, travelRuleSvc travelruleservice.ITravelRuleService, playerProtectionSvc playerprotectionservice.IPlayerProtectionService, logger zerolog.Logger, WsHub *websocket.WsHub, breakers *circuitbreaker.Registry) *Server {
 	gin.SetMode(gin.ReleaseMode)
 
 	router := gin.New()
 
 	return &Server{
-		Cfg:             cfg,
-		VerificationSvc: verificationService,
-		AuthSvc:         AuthSvc,
-		Logger:          logger,
-		Router:          router,
-		WsHub:           WsHub,
+		Cfg:                 cfg,
+		VerificationSvc:     verificationService,
+		AuthSvc:             AuthSvc,
+		ConversionSvc:       conversionSvc,
+		FeeSvc:              feeSvc,
+		QuoteSvc:            quoteSvc,
+		ReviewSvc:           reviewSvc,
+		WithdrawalSvc:       withdrawalSvc,
+		AddressBookSvc:      addressBookSvc,
+		TravelRuleSvc:       travelRuleSvc,
+		PlayerProtectionSvc: playerProtectionSvc,
+		Logger:              logger,
+		Router:              router,
+		WsHub:               WsHub,
+		Breakers:            breakers,
 	}
 }
 
@@ -47,9 +74,18 @@ func (s *Server) SetupRouter() {
 	handler := handlers.New(
 		s.VerificationSvc,
 		s.AuthSvc,
+		s.ConversionSvc,
+		s.FeeSvc,
+		s.QuoteSvc,
+		s.ReviewSvc,
+		s.WithdrawalSvc,
+		s.AddressBookSvc,
+		s.TravelRuleSvc,
+		s.PlayerProtectionSvc,
 		s.Logger,
 		s.Cfg,
 		s.WsHub,
+		s.Breakers,
 	)
 	handler.SetupHandlers(s.Router)
 }
@@ -20,10 +20,11 @@ type WsClient struct {
 }
 
 type WsMessage struct {
-	Type       string                 `json:"type"`
-	Deposit    *domain.DepositSession `json:"deposit,omitempty"`
-	Withdrawal *domain.Withdrawal     `json:"withdrawal,omitempty"`
-	Balance    *domain.Balance        `json:"balance,omitempty"`
+	Type        string                   `json:"type"`
+	Deposit     *domain.DepositSession   `json:"deposit,omitempty"`
+	Withdrawal  *domain.Withdrawal       `json:"withdrawal,omitempty"`
+	Balance     *domain.Balance          `json:"balance,omitempty"`
+	AddressBook *domain.AddressBookEvent `json:"address_book,omitempty"`
 }
 
 type Balance struct {
@@ -92,6 +93,12 @@ func (h *WsHub) Run() {
 					logID = message.Balance.CurrencyCode
 					logType = "balance_update"
 				}
+			case "address_book":
+				if message.AddressBook != nil {
+					userID = message.AddressBook.UserID
+					logID = string(message.AddressBook.Action)
+					logType = "address_book"
+				}
 			}
 
 			h.Logger.Info().
@@ -195,3 +202,14 @@ func (h *WsHub) BroadcastBalance(balance domain.Balance) {
 		Balance: &balance,
 	}
 }
+
+func (h *WsHub) BroadcastAddressBook(event domain.AddressBookEvent) {
+	h.Logger.Info().
+		Str("user_id", event.UserID).
+		Str("action", string(event.Action)).
+		Msg("Preparing to broadcast address book update")
+	h.Broadcast <- WsMessage{
+		Type:        "address_book",
+		AddressBook: &event,
+	}
+}
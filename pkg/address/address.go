@@ -0,0 +1,40 @@
+// Package address validates and normalizes the blockchain addresses tvs
+// accepts: Solana public keys, EVM addresses with EIP-55 checksums, Tron
+// base58check addresses and Bitcoin base58check, bech32 and bech32m
+// addresses. Normalized addresses are canonical, so two spellings of the same
+// address compare equal as strings.
+package address
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrUnsupportedChain = errors.New("no address format known for chain")
+	ErrInvalid          = errors.New("invalid address")
+	ErrChecksum         = errors.New("address checksum mismatch")
+)
+
+// Validate checks that addr is well formed for chainID and returns its
+// normalized form.
+func Validate(chainID, addr string) (string, error) {
+	switch chainID {
+	case "sol-mainnet", "sol-testnet":
+		return Solana(addr)
+	case "eth-mainnet", "eth-testnet":
+		return EVM(addr)
+	case "tron-mainnet", "tron-testnet":
+		return Tron(addr)
+	case "btc-mainnet":
+		return Bitcoin(addr, BitcoinMainnet)
+	case "btc-testnet":
+		return Bitcoin(addr, BitcoinTestnet)
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedChain, chainID)
+	}
+}
+
+func invalid(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrInvalid, fmt.Sprintf(format, args...))
+}
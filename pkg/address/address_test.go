@@ -0,0 +1,179 @@
+package address
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// Vectors from EIP-55.
+func TestEVM(t *testing.T) {
+	checksummed := []string{
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+		"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+		"0x52908400098527886E0F7030069857D2E4169EE7",
+		"0x8617E340B3D01FA5F11F306F4090FD50E238070D",
+		"0xde709f2102306220921060314715629080e2fb77",
+		"0x27b1fdb04752bbc536007a920d24acb045561c26",
+	}
+	for _, addr := range checksummed {
+		got, err := EVM(addr)
+		if err != nil {
+			t.Errorf("EVM(%s) error = %v", addr, err)
+			continue
+		}
+		if got != addr {
+			t.Errorf("EVM(%s) = %s, want it unchanged", addr, got)
+		}
+	}
+
+	normalized := map[string]string{
+		"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed": "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0xFB6916095CA1DF60BB79CE92CE3EA74C37C5D359": "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+	}
+	for addr, want := range normalized {
+		if got, err := EVM(addr); err != nil || got != want {
+			t.Errorf("EVM(%s) = %s, %v, want %s", addr, got, err, want)
+		}
+	}
+
+	invalid := map[string]error{
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD": ErrChecksum,
+		"5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed":   ErrInvalid,
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeA":   ErrInvalid,
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeg": ErrInvalid,
+	}
+	for addr, wantErr := range invalid {
+		if _, err := EVM(addr); !errors.Is(err, wantErr) {
+			t.Errorf("EVM(%s) error = %v, want %v", addr, err, wantErr)
+		}
+	}
+}
+
+// Valid addresses from BIP-173 and BIP-350, normalized to lowercase.
+func TestBitcoinSegwit(t *testing.T) {
+	tests := []struct {
+		addr    string
+		network BitcoinNetwork
+	}{
+		{"BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4", BitcoinMainnet},
+		{"tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3q0sl5k7", BitcoinTestnet},
+		{"tb1qqqqqp399et2xygdj5xreqhjjvcmzhxw4aywxecjdzew6hylgvsesrxh6hy", BitcoinTestnet},
+		{"bc1pw508d6qejxtdg4y5r3zarvary0c5xw7kw508d6qejxtdg4y5r3zarvary0c5xw7kt5nd6y", BitcoinMainnet},
+		{"BC1SW50QGDZ25J", BitcoinMainnet},
+		{"bc1zw508d6qejxtdg4y5r3zarvaryvaxxpcs", BitcoinMainnet},
+		{"tb1pqqqqp399et2xygdj5xreqhjjvcmzhxw4aywxecjdzew6hylgvsesf3hn0c", BitcoinTestnet},
+		{"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0", BitcoinMainnet},
+	}
+	for _, tt := range tests {
+		got, err := Bitcoin(tt.addr, tt.network)
+		if err != nil {
+			t.Errorf("Bitcoin(%s) error = %v", tt.addr, err)
+			continue
+		}
+		if want := strings.ToLower(tt.addr); got != want {
+			t.Errorf("Bitcoin(%s) = %s, want %s", tt.addr, got, want)
+		}
+	}
+}
+
+// Invalid addresses from BIP-173 and BIP-350.
+func TestBitcoinSegwitInvalid(t *testing.T) {
+	tests := []struct {
+		addr    string
+		network BitcoinNetwork
+		reason  string
+	}{
+		{"tb1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vq47Zagq", BitcoinTestnet, "mixed case"},
+		{"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqh2y7hd", BitcoinMainnet, "bech32 checksum for version 1"},
+		{"tb1z0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqglt7rf", BitcoinTestnet, "bech32 checksum for version 2"},
+		{"BC1S0XLXVLHEMJA6C4DQV22UAPCTQUPFHLXM9H8Z3K2E72Q4K9HCZ7VQ54WELL", BitcoinMainnet, "bech32 checksum for version 16"},
+		{"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kemeawh", BitcoinMainnet, "bech32m checksum for version 0"},
+		{"tb1q0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vq24jc47", BitcoinTestnet, "bech32m checksum for version 0"},
+		{"bc1p38j9r5y49hruaue7wxjce0updqjuyyx0kh56v8s25huc6995vvpql3jow4", BitcoinMainnet, "invalid character"},
+		{"BC130XLXVLHEMJA6C4DQV22UAPCTQUPFHLXM9H8Z3K2E72Q4K9HCZ7VQ7ZWS8R", BitcoinMainnet, "witness version 17"},
+		{"bc1pw5dgrnzv", BitcoinMainnet, "1-byte witness program"},
+		{"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7v8n0nx0muaewav253zgeav", BitcoinMainnet, "41-byte witness program"},
+		{"BC1QR508D6QEJXTDG4Y5R3ZARVARYV98GJ9P", BitcoinMainnet, "16-byte version 0 witness program"},
+		{"tb1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vq47Zagq", BitcoinTestnet, "mixed case"},
+		{"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7v07qwwzcrf", BitcoinMainnet, "more than 4 padding bits"},
+		{"tb1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vpggkg4j", BitcoinTestnet, "non-zero padding"},
+		{"bc1gmk9yu", BitcoinMainnet, "empty data"},
+		{"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", BitcoinTestnet, "mainnet address on testnet"},
+	}
+	for _, tt := range tests {
+		if got, err := Bitcoin(tt.addr, tt.network); err == nil {
+			t.Errorf("Bitcoin(%s) = %s, want an error for %s", tt.addr, got, tt.reason)
+		}
+	}
+}
+
+func TestBitcoinBase58(t *testing.T) {
+	valid := []struct {
+		addr    string
+		network BitcoinNetwork
+	}{
+		{"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", BitcoinMainnet},
+		{"3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy", BitcoinMainnet},
+		{"mipcBbFg9gMiCh81Kj8tqqdgoZub1ZJRfn", BitcoinTestnet},
+		{"2MzQwSSnBHWHqSAqtTVQ6v47XtaisrJa1Vc", BitcoinTestnet},
+	}
+	for _, tt := range valid {
+		if got, err := Bitcoin(tt.addr, tt.network); err != nil || got != tt.addr {
+			t.Errorf("Bitcoin(%s) = %s, %v, want it unchanged", tt.addr, got, err)
+		}
+	}
+
+	invalid := []struct {
+		addr    string
+		network BitcoinNetwork
+	}{
+		{"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN3", BitcoinMainnet},
+		{"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", BitcoinTestnet},
+		{"mipcBbFg9gMiCh81Kj8tqqdgoZub1ZJRfn", BitcoinMainnet},
+		{"TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", BitcoinMainnet},
+	}
+	for _, tt := range invalid {
+		if got, err := Bitcoin(tt.addr, tt.network); err == nil {
+			t.Errorf("Bitcoin(%s) = %s, want an error", tt.addr, got)
+		}
+	}
+}
+
+func TestTron(t *testing.T) {
+	if got, err := Tron("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"); err != nil || got != "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t" {
+		t.Errorf("Tron() = %s, %v", got, err)
+	}
+	for _, addr := range []string{"TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6u", "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2"} {
+		if got, err := Tron(addr); err == nil {
+			t.Errorf("Tron(%s) = %s, want an error", addr, got)
+		}
+	}
+}
+
+func TestSolana(t *testing.T) {
+	for _, addr := range []string{
+		"EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v",
+		"11111111111111111111111111111111",
+	} {
+		if got, err := Solana(addr); err != nil || got != addr {
+			t.Errorf("Solana(%s) = %s, %v, want it unchanged", addr, got, err)
+		}
+	}
+	for _, addr := range []string{"", "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGk", "0PjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"} {
+		if got, err := Solana(addr); !errors.Is(err, ErrInvalid) {
+			t.Errorf("Solana(%q) = %s, %v, want ErrInvalid", addr, got, err)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if got, err := Validate("eth-mainnet", "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"); err != nil || got != "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed" {
+		t.Errorf("Validate(eth-mainnet) = %s, %v", got, err)
+	}
+	if _, err := Validate("doge-mainnet", "DH5yaieqoZN36fDVciNyRueRGvGLR3mr7L"); !errors.Is(err, ErrUnsupportedChain) {
+		t.Errorf("Validate(doge-mainnet) error = %v, want ErrUnsupportedChain", err)
+	}
+}
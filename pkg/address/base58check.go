@@ -0,0 +1,29 @@
+package address
+
+import (
+	"bytes"
+	"crypto/sha256"
+
+	"github.com/tuncanbit/tvs/pkg/base58"
+)
+
+const checksumLength = 4
+
+// decodeBase58Check decodes addr and verifies its trailing double-SHA-256
+// checksum, returning the version byte and payload together.
+func decodeBase58Check(addr string) ([]byte, error) {
+	decoded, err := base58.Decode(addr)
+	if err != nil {
+		return nil, invalid("%v", err)
+	}
+	if len(decoded) <= checksumLength {
+		return nil, invalid("too short")
+	}
+	payload := decoded[:len(decoded)-checksumLength]
+	first := sha256.Sum256(payload)
+	second := sha256.Sum256(first[:])
+	if !bytes.Equal(second[:checksumLength], decoded[len(payload):]) {
+		return nil, ErrChecksum
+	}
+	return payload, nil
+}
@@ -0,0 +1,117 @@
+package address
+
+import "strings"
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+type bech32Encoding int
+
+const (
+	bech32 bech32Encoding = iota + 1
+	bech32m
+)
+
+const (
+	bech32Constant       = 1
+	bech32mConstant      = 0x2bc830a3
+	bech32MaxLength      = 90
+	bech32ChecksumLength = 6
+)
+
+// decodeBech32 splits a bech32 or bech32m string into its human-readable
+// part and 5-bit data values, without the checksum, and reports which
+// checksum it carries.
+func decodeBech32(s string) (string, []byte, bech32Encoding, error) {
+	if len(s) > bech32MaxLength {
+		return "", nil, 0, invalid("longer than %d characters", bech32MaxLength)
+	}
+	if s != strings.ToLower(s) && s != strings.ToUpper(s) {
+		return "", nil, 0, invalid("mixed case")
+	}
+	s = strings.ToLower(s)
+
+	separator := strings.LastIndexByte(s, '1')
+	if separator < 1 || separator+bech32ChecksumLength+1 > len(s) {
+		return "", nil, 0, invalid("malformed bech32 string")
+	}
+	hrp := s[:separator]
+	for i := 0; i < len(hrp); i++ {
+		if hrp[i] < 33 || hrp[i] > 126 {
+			return "", nil, 0, invalid("invalid prefix character")
+		}
+	}
+	data := make([]byte, 0, len(s)-separator-1)
+	for i := separator + 1; i < len(s); i++ {
+		value := strings.IndexByte(bech32Charset, s[i])
+		if value < 0 {
+			return "", nil, 0, invalid("invalid bech32 character %q", s[i])
+		}
+		data = append(data, byte(value))
+	}
+
+	var encoding bech32Encoding
+	switch bech32Polymod(append(hrpExpand(hrp), data...)) {
+	case bech32Constant:
+		encoding = bech32
+	case bech32mConstant:
+		encoding = bech32m
+	default:
+		return "", nil, 0, ErrChecksum
+	}
+	return hrp, data[:len(data)-bech32ChecksumLength], encoding, nil
+}
+
+func bech32Polymod(values []byte) uint32 {
+	generator := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>i)&1 == 1 {
+				chk ^= generator[i]
+			}
+		}
+	}
+	return chk
+}
+
+func hrpExpand(hrp string) []byte {
+	out := make([]byte, 0, 2*len(hrp)+1)
+	for i := 0; i < len(hrp); i++ {
+		out = append(out, hrp[i]>>5)
+	}
+	out = append(out, 0)
+	for i := 0; i < len(hrp); i++ {
+		out = append(out, hrp[i]&31)
+	}
+	return out
+}
+
+// convertBits regroups a sequence of fromBits-wide values into toBits-wide
+// ones. Without padding, leftover bits must be zero and fewer than fromBits.
+func convertBits(data []byte, fromBits, toBits uint, pad bool) ([]byte, error) {
+	var acc uint32
+	var bits uint
+	maxValue := uint32(1)<<toBits - 1
+	var out []byte
+	for _, value := range data {
+		if uint32(value)>>fromBits != 0 {
+			return nil, invalid("data value out of range")
+		}
+		acc = acc<<fromBits | uint32(value)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			out = append(out, byte(acc>>bits&maxValue))
+		}
+	}
+	if pad {
+		if bits > 0 {
+			out = append(out, byte(acc<<(toBits-bits)&maxValue))
+		}
+	} else if bits >= fromBits || acc<<(toBits-bits)&maxValue != 0 {
+		return nil, invalid("invalid padding")
+	}
+	return out, nil
+}
@@ -0,0 +1,73 @@
+package address
+
+import "strings"
+
+// BitcoinNetwork holds the version bytes and bech32 prefix of a Bitcoin
+// network's addresses.
+type BitcoinNetwork struct {
+	PubKeyHashVersion byte
+	ScriptHashVersion byte
+	HRP               string
+}
+
+var (
+	BitcoinMainnet = BitcoinNetwork{PubKeyHashVersion: 0x00, ScriptHashVersion: 0x05, HRP: "bc"}
+	BitcoinTestnet = BitcoinNetwork{PubKeyHashVersion: 0x6f, ScriptHashVersion: 0xc4, HRP: "tb"}
+)
+
+const bitcoinHashLength = 20
+
+// Bitcoin validates a legacy base58check (P2PKH or P2SH) or segwit address
+// on network. Segwit addresses are bech32 for witness version 0 and bech32m
+// for later versions, and are normalized to lowercase.
+func Bitcoin(addr string, network BitcoinNetwork) (string, error) {
+	if hrp, _, ok := strings.Cut(strings.ToLower(addr), "1"); ok && hrp == network.HRP {
+		return segwit(addr, network.HRP)
+	}
+
+	payload, err := decodeBase58Check(addr)
+	if err != nil {
+		return "", err
+	}
+	if len(payload) != 1+bitcoinHashLength {
+		return "", invalid("decodes to %d bytes, expected %d", len(payload), 1+bitcoinHashLength)
+	}
+	if payload[0] != network.PubKeyHashVersion && payload[0] != network.ScriptHashVersion {
+		return "", invalid("version byte 0x%02x is not used on this network", payload[0])
+	}
+	return addr, nil
+}
+
+// segwit decodes a segwit address and checks its witness program against
+// BIP-141, BIP-173 and BIP-350.
+func segwit(addr, hrp string) (string, error) {
+	decodedHRP, data, encoding, err := decodeBech32(addr)
+	if err != nil {
+		return "", err
+	}
+	if decodedHRP != hrp {
+		return "", invalid("prefix %q, expected %q", decodedHRP, hrp)
+	}
+	if len(data) == 0 {
+		return "", invalid("missing witness version")
+	}
+
+	version := data[0]
+	if version > 16 {
+		return "", invalid("witness version %d", version)
+	}
+	program, err := convertBits(data[1:], 5, 8, false)
+	if err != nil {
+		return "", err
+	}
+	if len(program) < 2 || len(program) > 40 {
+		return "", invalid("witness program of %d bytes", len(program))
+	}
+	if version == 0 && len(program) != 20 && len(program) != 32 {
+		return "", invalid("version 0 witness program of %d bytes", len(program))
+	}
+	if (version == 0) != (encoding == bech32) {
+		return "", invalid("witness version %d with the wrong checksum encoding", version)
+	}
+	return strings.ToLower(addr), nil
+}
@@ -0,0 +1,54 @@
+package address
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+const evmAddressLength = 20
+
+// EVM validates a 0x-prefixed 20-byte hex address and returns it with its
+// EIP-55 checksum. All-lowercase and all-uppercase addresses carry no
+// checksum and are accepted; mixed-case ones must match theirs.
+func EVM(addr string) (string, error) {
+	if !strings.HasPrefix(addr, "0x") {
+		return "", invalid("expected a 0x-prefixed EVM address")
+	}
+	digits := addr[2:]
+	if len(digits) != 2*evmAddressLength {
+		return "", invalid("expected %d hex digits, got %d", 2*evmAddressLength, len(digits))
+	}
+	if _, err := hex.DecodeString(digits); err != nil {
+		return "", invalid("%v", err)
+	}
+
+	checksummed := ChecksumEVM(digits)
+	if digits != strings.ToLower(digits) && digits != strings.ToUpper(digits) && "0x"+digits != checksummed {
+		return "", ErrChecksum
+	}
+	return checksummed, nil
+}
+
+// ChecksumEVM applies the EIP-55 checksum to 40 hex digits: each letter is
+// uppercased when the matching nibble of the Keccak-256 hash of the
+// lowercase address is 8 or more.
+func ChecksumEVM(digits string) string {
+	lower := strings.ToLower(strings.TrimPrefix(digits, "0x"))
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write([]byte(lower))
+	sum := hash.Sum(nil)
+
+	out := []byte(lower)
+	for i, c := range out {
+		nibble := sum[i/2] >> 4
+		if i%2 == 1 {
+			nibble = sum[i/2] & 0x0f
+		}
+		if c >= 'a' && c <= 'f' && nibble >= 8 {
+			out[i] = c - 'a' + 'A'
+		}
+	}
+	return "0x" + string(out)
+}
@@ -0,0 +1,19 @@
+package address
+
+import "github.com/tuncanbit/tvs/pkg/base58"
+
+const solanaKeyLength = 32
+
+// Solana validates a base58 public key. Program derived addresses are
+// accepted, since they can own token accounts. Base58 spellings are unique,
+// so the address is already normalized.
+func Solana(addr string) (string, error) {
+	decoded, err := base58.Decode(addr)
+	if err != nil {
+		return "", invalid("%v", err)
+	}
+	if len(decoded) != solanaKeyLength {
+		return "", invalid("decodes to %d bytes, expected a %d-byte Solana public key", len(decoded), solanaKeyLength)
+	}
+	return addr, nil
+}
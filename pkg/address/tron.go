@@ -0,0 +1,20 @@
+package address
+
+const (
+	tronVersion       = 0x41
+	tronPayloadLength = 21
+)
+
+// Tron validates a base58check Tron address: a 0x41 version byte followed
+// by a 20-byte account hash. Base58 spellings are unique, so the address is
+// already normalized.
+func Tron(addr string) (string, error) {
+	payload, err := decodeBase58Check(addr)
+	if err != nil {
+		return "", err
+	}
+	if len(payload) != tronPayloadLength || payload[0] != tronVersion {
+		return "", invalid("not a Tron address")
+	}
+	return addr, nil
+}
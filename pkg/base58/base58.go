@@ -0,0 +1,69 @@
+// Package base58 implements the Bitcoin-alphabet base58 encoding used by
+// Solana, Tron and legacy Bitcoin addresses.
+package base58
+
+import (
+	"errors"
+	"math/big"
+)
+
+const alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+var ErrInvalidCharacter = errors.New("invalid base58 character")
+
+var decodeMap = func() [256]int {
+	var m [256]int
+	for i := range m {
+		m[i] = -1
+	}
+	for i := 0; i < len(alphabet); i++ {
+		m[alphabet[i]] = i
+	}
+	return m
+}()
+
+// Decode returns the bytes encoded by s. Each leading '1' is a leading zero
+// byte.
+func Decode(s string) ([]byte, error) {
+	zeros := 0
+	for zeros < len(s) && s[zeros] == alphabet[0] {
+		zeros++
+	}
+
+	n := new(big.Int)
+	radix := big.NewInt(58)
+	for i := zeros; i < len(s); i++ {
+		digit := decodeMap[s[i]]
+		if digit < 0 {
+			return nil, ErrInvalidCharacter
+		}
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(digit)))
+	}
+
+	return append(make([]byte, zeros), n.Bytes()...), nil
+}
+
+// Encode returns the base58 encoding of b.
+func Encode(b []byte) string {
+	zeros := 0
+	for zeros < len(b) && b[zeros] == 0 {
+		zeros++
+	}
+
+	n := new(big.Int).SetBytes(b)
+	radix := big.NewInt(58)
+	mod := new(big.Int)
+	var out []byte
+	for n.Sign() > 0 {
+		n.DivMod(n, radix, mod)
+		out = append(out, alphabet[mod.Int64()])
+	}
+	for i := 0; i < zeros; i++ {
+		out = append(out, alphabet[0])
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
@@ -0,0 +1,56 @@
+package base58
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+// Vectors from Bitcoin Core's base58_encode_decode.json.
+var vectors = []struct {
+	hex     string
+	encoded string
+}{
+	{"", ""},
+	{"61", "2g"},
+	{"626262", "a3gV"},
+	{"636363", "aPEr"},
+	{"73696d706c792061206c6f6e6720737472696e67", "2cFupjhnEsSn59qHXstmK2ffpLv2"},
+	{"00eb15231dfceb60925886b67d065299925915aeb172c06647", "1NS17iag9jJgTHD1VXjvLCEnZuQ3rJDE9L"},
+	{"516b6fcd0f", "ABnLTmg"},
+	{"bf4f89001e670274dd", "3SEo3LWLoPntC"},
+	{"572e4794", "3EFU7m"},
+	{"ecac89cad93923c02321", "EJDM8drfXA6uyA"},
+	{"10c8511e", "Rt5zm"},
+	{"00000000000000000000", "1111111111"},
+}
+
+func TestEncode(t *testing.T) {
+	for _, v := range vectors {
+		raw, _ := hex.DecodeString(v.hex)
+		if got := Encode(raw); got != v.encoded {
+			t.Errorf("Encode(%s) = %q, want %q", v.hex, got, v.encoded)
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	for _, v := range vectors {
+		got, err := Decode(v.encoded)
+		if err != nil {
+			t.Errorf("Decode(%q) failed: %v", v.encoded, err)
+			continue
+		}
+		if hex.EncodeToString(got) != v.hex {
+			t.Errorf("Decode(%q) = %x, want %s", v.encoded, got, v.hex)
+		}
+	}
+}
+
+func TestDecodeRejectsCharactersOutsideAlphabet(t *testing.T) {
+	for _, s := range []string{"0", "O", "I", "l", "3SEo3LWL0PntC", "abc!"} {
+		if _, err := Decode(s); !errors.Is(err, ErrInvalidCharacter) {
+			t.Errorf("Decode(%q) error = %v, want ErrInvalidCharacter", s, err)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package circuitbreaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/pkg/config"
+	"github.com/tuncanbit/tvs/pkg/metrics"
+)
+
+type State string
+
+const (
+	StateClosed   State = "closed"
+	StateOpen     State = "open"
+	StateHalfOpen State = "half_open"
+)
+
+const (
+	defaultFailureThreshold    = 5
+	defaultOpenTimeout         = 30 * time.Second
+	defaultHalfOpenMaxRequests = 1
+)
+
+var ErrOpen = errors.New("circuit breaker is open")
+
+// Breaker trips to open after FailureThreshold consecutive failures, rejects
+// calls for OpenTimeout, then lets HalfOpenMaxRequests probes through before
+// deciding whether to close again.
+type Breaker struct {
+	name   string
+	cfg    config.CircuitBreakerConfig
+	logger zerolog.Logger
+
+	mu               sync.Mutex
+	state            State
+	failures         int
+	openedAt         time.Time
+	halfOpenInFlight int
+	lastError        string
+	lastChange       time.Time
+}
+
+type Status struct {
+	Name       string    `json:"name"`
+	State      State     `json:"state"`
+	Failures   int       `json:"failures"`
+	LastError  string    `json:"last_error,omitempty"`
+	LastChange time.Time `json:"last_change"`
+}
+
+type Registry struct {
+	mu       sync.Mutex
+	configs  map[string]config.CircuitBreakerConfig
+	breakers map[string]*Breaker
+	logger   zerolog.Logger
+}
+
+func NewRegistry(cfg map[string]config.CircuitBreakerConfig, logger zerolog.Logger) *Registry {
+	return &Registry{
+		configs:  cfg,
+		breakers: make(map[string]*Breaker),
+		logger:   logger.With().Str("component", "circuit_breaker").Logger(),
+	}
+}
+
+// For returns the breaker guarding provider, creating it on first use.
+func (r *Registry) For(provider string) *Breaker {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if b, exists := r.breakers[provider]; exists {
+		return b
+	}
+
+	cfg := r.configs[provider]
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = defaultFailureThreshold
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = defaultOpenTimeout
+	}
+	if cfg.HalfOpenMaxRequests <= 0 {
+		cfg.HalfOpenMaxRequests = defaultHalfOpenMaxRequests
+	}
+
+	b := &Breaker{
+		name:       provider,
+		cfg:        cfg,
+		logger:     r.logger.With().Str("provider", provider).Logger(),
+		state:      StateClosed,
+		lastChange: time.Now(),
+	}
+	metrics.Gauge("circuit_breaker."+provider+".state", func() interface{} {
+		return string(b.State())
+	})
+	r.breakers[provider] = b
+	return b
+}
+
+// Statuses reports the current state of every breaker.
+func (r *Registry) Statuses() []Status {
+	r.mu.Lock()
+	breakers := make([]*Breaker, 0, len(r.breakers))
+	for _, b := range r.breakers {
+		breakers = append(breakers, b)
+	}
+	r.mu.Unlock()
+
+	statuses := make([]Status, 0, len(breakers))
+	for _, b := range breakers {
+		statuses = append(statuses, b.Status())
+	}
+	return statuses
+}
+
+// Execute runs fn if the breaker allows it and records the outcome. It
+// returns ErrOpen without calling fn while the breaker is open.
+func (b *Breaker) Execute(fn func() error) error {
+	if err := b.before(); err != nil {
+		return err
+	}
+	err := fn()
+	b.after(err)
+	return err
+}
+
+// Available reports whether a call would currently be let through.
+func (b *Breaker) Available() bool {
+	return b.State() != StateOpen
+}
+
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refresh()
+	return b.state
+}
+
+func (b *Breaker) Status() Status {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refresh()
+	return Status{
+		Name:       b.name,
+		State:      b.state,
+		Failures:   b.failures,
+		LastError:  b.lastError,
+		LastChange: b.lastChange,
+	}
+}
+
+func (b *Breaker) before() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refresh()
+
+	switch b.state {
+	case StateOpen:
+		metrics.Add("circuit_breaker."+b.name+".rejected", 1)
+		return ErrOpen
+	case StateHalfOpen:
+		if b.halfOpenInFlight >= b.cfg.HalfOpenMaxRequests {
+			metrics.Add("circuit_breaker."+b.name+".rejected", 1)
+			return ErrOpen
+		}
+		b.halfOpenInFlight++
+	}
+	return nil
+}
+
+func (b *Breaker) after(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateHalfOpen && b.halfOpenInFlight > 0 {
+		b.halfOpenInFlight--
+	}
+
+	if err == nil {
+		b.failures = 0
+		if b.state != StateClosed {
+			b.transition(StateClosed)
+		}
+		return
+	}
+
+	b.failures++
+	b.lastError = err.Error()
+	if b.state == StateHalfOpen || b.failures >= b.cfg.FailureThreshold {
+		b.openedAt = time.Now()
+		b.transition(StateOpen)
+	}
+}
+
+// refresh moves an open breaker to half-open once its timeout has elapsed.
+// Callers must hold b.mu.
+func (b *Breaker) refresh() {
+	if b.state == StateOpen && time.Since(b.openedAt) >= b.cfg.OpenTimeout {
+		b.halfOpenInFlight = 0
+		b.transition(StateHalfOpen)
+	}
+}
+
+func (b *Breaker) transition(to State) {
+	from := b.state
+	b.state = to
+	b.lastChange = time.Now()
+	metrics.Add("circuit_breaker."+b.name+".transitions", 1)
+
+	event := b.logger.Info()
+	if to == StateOpen {
+		event = b.logger.Warn()
+	}
+	event.
+		Str("from", string(from)).
+		Str("to", string(to)).
+		Int("failures", b.failures).
+		Str("last_error", b.lastError).
+		Msg("Circuit breaker state changed")
+}
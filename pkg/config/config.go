@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"time"
 
@@ -9,16 +10,37 @@ import (
 )
 
 type Config struct {
-	Server            ServerConfig                 `yaml:"server"`
-	Database          DatabaseConfig               `yaml:"database"`
-	PDM               PDMConfig                    `yaml:"pdm"`
-	Verification      VerificationConfig           `yaml:"verification"`
-	Security          SecurityConfig               `yaml:"security"`
-	ExchangeAPIConfig ExchangeAPIConfig            `yaml:"exchange_api_config"`
-	WebSocket         WebSocketConfig              `yaml:"websocket"`
-	Helius            HeliusConfig                 `yaml:"helius"`
-	MintAddresses     map[string]map[string]string `yaml:"mint_addresses"` // cluster_type -> token_type -> mint_address
-	JWT               JWTConfig                    `yaml:"jwt"`
+	Server            ServerConfig                    `yaml:"server"`
+	Database          DatabaseConfig                  `yaml:"database"`
+	PDM               PDMConfig                       `yaml:"pdm"`
+	Verification      VerificationConfig              `yaml:"verification"`
+	Pricing           PricingConfig                   `yaml:"pricing"`
+	Quotes            QuotesConfig                    `yaml:"quotes"`
+	Withdrawals       WithdrawalsConfig               `yaml:"withdrawals"`
+	Screening         ScreeningConfig                 `yaml:"screening"`
+	TravelRule        TravelRuleConfig                `yaml:"travel_rule"`
+	AccountPolicy     AccountPolicyConfig             `yaml:"account_policy"`
+	PlayerProtection  PlayerProtectionConfig          `yaml:"player_protection"`
+	Security          SecurityConfig                  `yaml:"security"`
+	ExchangeAPIConfig ExchangeAPIConfig               `yaml:"exchange_api_config"`
+	WebSocket         WebSocketConfig                 `yaml:"websocket"`
+	Helius            HeliusConfig                    `yaml:"helius"`
+	Alchemy           AlchemyConfig                   `yaml:"alchemy"`
+	MintAddresses     map[string]map[string]string    `yaml:"mint_addresses"` // cluster_type -> token_type -> mint_address
+	JWT               JWTConfig                       `yaml:"jwt"`
+	RateLimits        map[string]RateLimitConfig      `yaml:"rate_limits"`      // provider -> limit
+	CircuitBreakers   map[string]CircuitBreakerConfig `yaml:"circuit_breakers"` // provider -> breaker
+}
+
+type CircuitBreakerConfig struct {
+	FailureThreshold    int           `yaml:"failure_threshold"`
+	OpenTimeout         time.Duration `yaml:"open_timeout"`
+	HalfOpenMaxRequests int           `yaml:"half_open_max_requests"`
+}
+
+type RateLimitConfig struct {
+	RequestsPerSecond float64 `yaml:"requests_per_second"`
+	Burst             int     `yaml:"burst"`
 }
 
 type JWTConfig struct {
@@ -37,10 +59,17 @@ type ExchangeAPIConfig struct {
 type HeliusConfig struct {
 	APIKey     string            `yaml:"api_key"`
 	BaseURLs   map[string]string `yaml:"base_urls"` // cluster_type -> base_url
+	RPCURLs    map[string]string `yaml:"rpc_urls"`  // cluster_type -> JSON-RPC endpoint, may point at solana-test-validator
 	Timeout    time.Duration     `yaml:"timeout"`
 	MaxRetries int               `yaml:"max_retries"`
 }
 
+type AlchemyConfig struct {
+	APIKey  string            `yaml:"api_key"`
+	RPCURLs map[string]string `yaml:"rpc_urls"` // chain_id -> JSON-RPC endpoint, the API key is appended as a path segment
+	Timeout time.Duration     `yaml:"timeout"`
+}
+
 type DatabaseConfig struct {
 	Host            string `yaml:"host"`
 	Port            string `yaml:"port"`
@@ -74,6 +103,122 @@ type VerificationConfig struct {
 	ConcurrentWorkers   int           `yaml:"concurrent_workers"`
 	CacheEnabled        bool          `yaml:"cache_enabled"`
 	CacheTTL            time.Duration `yaml:"cache_ttl"`
+	// WithdrawalBroadcastTimeout is how long a withdrawal created through the
+	// API may wait for its transaction hash, counted from its review decision
+	// or latest broadcast, before it is failed.
+	WithdrawalBroadcastTimeout time.Duration `yaml:"withdrawal_broadcast_timeout"`
+}
+
+type PricingConfig struct {
+	StaleRateMaxAge     time.Duration                `yaml:"stale_rate_max_age"`
+	MinSources          int                          `yaml:"min_sources"`
+	OutlierThresholdPct float64                      `yaml:"outlier_threshold_pct"`
+	MaxDivergencePct    float64                      `yaml:"max_divergence_pct"`
+	SourceTimeout       time.Duration                `yaml:"source_timeout"`
+	Policy              string                       `yaml:"policy"`      // onchain_time, session_created or verification_time
+	SpotWindow          time.Duration                `yaml:"spot_window"` // moments this recent are priced from spot quotes
+	Sources             map[string]PriceSourceConfig `yaml:"sources"`     // source name -> config, coincap uses exchange_api_config
+	FX                  PriceSourceConfig            `yaml:"fx"`
+	FiatCurrencies      []string                     `yaml:"fiat_currencies"` // currencies balances may be held in
+	Pegs                map[string]PegConfig         `yaml:"pegs"`            // crypto currency -> peg, e.g. USDC
+}
+
+type PegConfig struct {
+	Enabled  bool    `yaml:"enabled"`
+	PriceUSD float64 `yaml:"price_usd"`
+	BandPct  float64 `yaml:"band_pct"` // oracle deviation tolerated before the token is considered depegged
+	OnDepeg  string  `yaml:"on_depeg"` // market or pause
+}
+
+type QuotesConfig struct {
+	TTL                time.Duration `yaml:"ttl"`
+	DefaultSlippageBps int           `yaml:"default_slippage_bps"`
+	MaxSlippageBps     int           `yaml:"max_slippage_bps"`
+	LatePaymentPolicy  string        `yaml:"late_payment_policy"` // market or lower_of
+}
+
+type WithdrawalsConfig struct {
+	AdminReviewWindow    time.Duration     `yaml:"admin_review_window"`    // time admins have to review a flagged withdrawal
+	LimitsReloadInterval time.Duration     `yaml:"limits_reload_interval"` // how often limits are re-read from system_config
+	ReviewDeadlineAction string            `yaml:"review_deadline_action"` // escalate or reject when a review deadline is missed
+	MaxEscalations       int               `yaml:"max_escalations"`        // escalations before a missed deadline rejects; 0 escalates without limit
+	ReviewCheckInterval  time.Duration     `yaml:"review_check_interval"`
+	QuorumThresholdCents int64             `yaml:"quorum_threshold_usd_cents"` // withdrawals above this need QuorumSize admin approvals
+	QuorumSize           int               `yaml:"quorum_size"`
+	FeeBps               int64             `yaml:"fee_bps"`            // platform fee on the requested amount
+	MinFeeUSDCents       int64             `yaml:"min_fee_usd_cents"`  // floor on the platform fee, converted to the withdrawal currency
+	HotWallets           map[string]string `yaml:"hot_wallets"`        // chain_id -> source wallet address
+	NetworkFeePayers     map[string]string `yaml:"network_fee_payers"` // chain_id -> user or house; the house pays when unset
+	FeeEstimateTTL       time.Duration     `yaml:"fee_estimate_ttl"`   // how long a fee estimate is served from cache
+	EVMTokens            []string          `yaml:"evm_tokens"`         // tokens paid out on EVM chains besides the native asset
+	AddressCooldown      time.Duration     `yaml:"address_cooldown"`   // delay before a new address book entry can be withdrawn to, and before strict mode can be turned off
+	Executor             ExecutorConfig    `yaml:"executor"`
+}
+
+// ExecutorConfig controls the optional executor that signs and broadcasts
+// API withdrawals from the hot wallets. When disabled, another system must
+// set the transaction hash.
+type ExecutorConfig struct {
+	Enabled      bool          `yaml:"enabled"`
+	Interval     time.Duration `yaml:"interval"`      // also how often sent transactions are checked for confirmation
+	MaxAttempts  int           `yaml:"max_attempts"`  // signed transactions per withdrawal before giving up
+	Signer       string        `yaml:"signer"`        // local or kms
+	KeystorePath string        `yaml:"keystore_path"` // local keystore, decrypted with security.encryption_key
+}
+
+// ScreeningConfig controls counterparty screening of deposit senders and
+// withdrawal destinations against locally loaded address lists.
+type ScreeningConfig struct {
+	Enabled        bool                  `yaml:"enabled"`
+	ReloadInterval time.Duration         `yaml:"reload_interval"` // how often the list files are re-read
+	Lists          []ScreeningListConfig `yaml:"lists"`
+}
+
+// ScreeningListConfig is an address list file. The default text format has
+// one address per line, optionally followed by a comma and the reason it is
+// listed; blank lines and lines starting with # are skipped. Format
+// "ofac_sdn_xml" reads the OFAC SDN list export (sdn.xml) as published and
+// takes the addresses from its "Digital Currency Address" identifiers.
+type ScreeningListConfig struct {
+	Name   string `yaml:"name"` // recorded as the source of a match, e.g. ofac_sdn
+	Path   string `yaml:"path"`
+	Format string `yaml:"format"` // "text" (default) or "ofac_sdn_xml"
+	Reason string `yaml:"reason"` // reason for entries that do not give one
+}
+
+// TravelRuleConfig identifies this platform as the originating VASP in
+// Travel Rule exports. Thresholds are kept in system_config.
+type TravelRuleConfig struct {
+	VASPName    string `yaml:"vasp_name"`
+	VASPLEI     string `yaml:"vasp_lei"`
+	VASPCountry string `yaml:"vasp_country"` // ISO 3166-1 alpha-2 country of registration
+}
+
+// AccountPolicyConfig gates deposits and withdrawals on the user's KYC and
+// account status. Enabled turns on the rules for pending KYC; suspended and
+// inactive accounts, and rejected KYC, block withdrawals and hold deposits
+// even when it is off.
+type AccountPolicyConfig struct {
+	Enabled                      bool   `yaml:"enabled"`
+	PendingKYCWithdrawals        string `yaml:"pending_kyc_withdrawals"`           // hold (admin review, the default) or block
+	PendingKYCDepositCapUSDCents int64  `yaml:"pending_kyc_deposit_cap_usd_cents"` // total credited before KYC is verified; larger deposits are held
+}
+
+// PlayerProtectionConfig controls responsible-gambling deposit limits and
+// self-exclusion. Deposits over a limit or made while self-excluded are held
+// for refund instead of credited.
+type PlayerProtectionConfig struct {
+	Enabled              bool          `yaml:"enabled"`
+	LimitIncreaseCoolOff time.Duration `yaml:"limit_increase_cool_off"` // delay before a raised or removed deposit limit takes effect
+	MinSelfExclusionDays int           `yaml:"min_self_exclusion_days"`
+	MaxSelfExclusionDays int           `yaml:"max_self_exclusion_days"`
+}
+
+type PriceSourceConfig struct {
+	Enabled bool          `yaml:"enabled"`
+	BaseURL string        `yaml:"base_url"`
+	APIKey  string        `yaml:"api_key"`
+	Timeout time.Duration `yaml:"timeout"`
 }
 
 type SecurityConfig struct {
@@ -104,6 +249,17 @@ func Load() (*Config, error) {
 	if err := yaml.Unmarshal(configData, &config); err != nil {
 		return nil, err
 	}
+	if err := config.AccountPolicy.validate(); err != nil {
+		return nil, err
+	}
 
 	return &config, nil
 }
+
+func (c AccountPolicyConfig) validate() error {
+	switch c.PendingKYCWithdrawals {
+	case "", "hold", "block":
+		return nil
+	}
+	return fmt.Errorf("account_policy.pending_kyc_withdrawals must be hold or block, got %q", c.PendingKYCWithdrawals)
+}
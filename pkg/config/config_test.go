@@ -0,0 +1,14 @@
+package config
+
+import "testing"
+
+func TestAccountPolicyConfigValidate(t *testing.T) {
+	for _, action := range []string{"", "hold", "block"} {
+		if err := (AccountPolicyConfig{PendingKYCWithdrawals: action}).validate(); err != nil {
+			t.Errorf("validate(%q) error = %v", action, err)
+		}
+	}
+	if err := (AccountPolicyConfig{PendingKYCWithdrawals: "blcok"}).validate(); err == nil {
+		t.Error("validate accepted an unknown pending_kyc_withdrawals action")
+	}
+}
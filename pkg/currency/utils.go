@@ -43,3 +43,86 @@ func (u *CurrencyUtils) CentsToDollars(cents int64) float64 {
 func (u *CurrencyUtils) FormatUSD(cents int64) string {
 	return fmt.Sprintf("$%.2f", u.CentsToDollars(cents))
 }
+
+// fiatDecimals lists fiat currencies whose minor unit is not a hundredth.
+var fiatDecimals = map[string]int{
+	"JPY": 0,
+	"KRW": 0,
+	"CLP": 0,
+	"KWD": 3,
+	"BHD": 3,
+}
+
+var fiatSymbols = map[string]string{
+	"USD": "$",
+	"EUR": "€",
+	"GBP": "£",
+	"BRL": "R$",
+	"CAD": "C$",
+	"AUD": "A$",
+	"JPY": "¥",
+	"MXN": "MX$",
+}
+
+// MinorUnitDecimals returns the number of decimal places of the currency's
+// minor unit, e.g. 2 for USD cents and 0 for JPY.
+func (u *CurrencyUtils) MinorUnitDecimals(currencyCode string) int {
+	if decimals, exists := fiatDecimals[currencyCode]; exists {
+		return decimals
+	}
+	return 2
+}
+
+// MinorUnitsToMajor converts minor units to the currency's major unit.
+func (u *CurrencyUtils) MinorUnitsToMajor(minorUnits int64, currencyCode string) float64 {
+	return float64(minorUnits) / math.Pow(10, float64(u.MinorUnitDecimals(currencyCode)))
+}
+
+// MajorToMinorUnits converts an amount in the currency's major unit to whole
+// minor units, rounding half away from zero.
+func (u *CurrencyUtils) MajorToMinorUnits(amount float64, currencyCode string) int64 {
+	return int64(math.Round(amount * math.Pow(10, float64(u.MinorUnitDecimals(currencyCode)))))
+}
+
+// Format formats minor units of any fiat currency for display.
+func (u *CurrencyUtils) Format(minorUnits int64, currencyCode string) string {
+	decimals := u.MinorUnitDecimals(currencyCode)
+	amount := u.MinorUnitsToMajor(minorUnits, currencyCode)
+	if symbol, exists := fiatSymbols[currencyCode]; exists {
+		return fmt.Sprintf("%s%.*f", symbol, decimals, amount)
+	}
+	return fmt.Sprintf("%.*f %s", decimals, amount, currencyCode)
+}
+
+const (
+	RoundingBankers = "bankers"
+	RoundingHalfUp  = "half_up"
+	RoundingFloor   = "floor"
+)
+
+// ConvertToMinorUnits converts a cryptocurrency amount into minor units of
+// currencyCode. The exact value is first trimmed to precision decimal places
+// and then rounded to a whole minor unit with method. It returns the rounded
+// amount together with the exact value, so the caller can account for the
+// difference.
+func (u *CurrencyUtils) ConvertToMinorUnits(cryptoAmount, exchangeRate float64, currencyCode, method string, precision int) (int64, float64) {
+	exact := cryptoAmount * exchangeRate * math.Pow(10, float64(u.MinorUnitDecimals(currencyCode)))
+	if precision > 0 {
+		scale := math.Pow(10, float64(precision))
+		exact = math.Round(exact*scale) / scale
+	}
+	return u.RoundMinorUnits(exact, method), exact
+}
+
+// RoundMinorUnits rounds a fractional amount of minor units to a whole one.
+// Unknown methods fall back to banker's rounding.
+func (u *CurrencyUtils) RoundMinorUnits(value float64, method string) int64 {
+	switch method {
+	case RoundingHalfUp:
+		return int64(math.Round(value))
+	case RoundingFloor:
+		return int64(math.Floor(value))
+	default:
+		return int64(math.RoundToEven(value))
+	}
+}
@@ -0,0 +1,24 @@
+package db
+
+import (
+	"net"
+
+	"github.com/sqlc-dev/pqtype"
+)
+
+// Inet converts an IP address to a single-host inet value. Addresses that do
+// not parse become NULL.
+func Inet(address string) pqtype.Inet {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return pqtype.Inet{}
+	}
+	bits := 128
+	if ip.To4() != nil {
+		ip, bits = ip.To4(), 32
+	}
+	return pqtype.Inet{
+		IPNet: net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)},
+		Valid: true,
+	}
+}
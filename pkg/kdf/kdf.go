@@ -0,0 +1,59 @@
+// Package kdf derives the symmetric keys the service encrypts with at rest.
+package kdf
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	KeySize  = 32
+	SaltSize = 16
+
+	// scrypt cost parameters, the interactive-login recommendation of the
+	// scrypt paper scaled to current hardware.
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+// NewSalt returns a fresh random salt for Passphrase.
+func NewSalt() ([]byte, error) {
+	salt := make([]byte, SaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	return salt, nil
+}
+
+// Passphrase stretches a human-chosen passphrase into a KeySize key with
+// scrypt. The salt must be stored next to whatever the key encrypts.
+func Passphrase(passphrase string, salt []byte) ([]byte, error) {
+	if len(salt) < SaltSize {
+		return nil, fmt.Errorf("salt is %d bytes, want at least %d", len(salt), SaltSize)
+	}
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, KeySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	return key, nil
+}
+
+// Subkey derives a KeySize key for one purpose from a high-entropy secret,
+// such as security.encryption_key, with HKDF-SHA256. Keys for different
+// purposes are independent, so the same secret can serve several of them.
+func Subkey(secret, purpose string) ([]byte, error) {
+	if purpose == "" {
+		return nil, fmt.Errorf("key purpose is empty")
+	}
+	key := make([]byte, KeySize)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, []byte(secret), nil, []byte(purpose)), key); err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	return key, nil
+}
@@ -0,0 +1,64 @@
+package kdf
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+// Expected keys were computed independently from RFC 5869 with an empty
+// salt, checked against its test case 3.
+func TestSubkey(t *testing.T) {
+	tests := []struct {
+		secret, purpose string
+		want            string
+	}{
+		{strings.Repeat("\x0b", 22), "travel_rule", "291667a987754eac079904087cd16f0418693183c86ff077d9e07546943d9830"},
+		{"correct horse battery staple", "travel_rule", "6396ec096be556c95af13b6118ca321d2e67d7d34e4bd96a346aca7548c48b38"},
+	}
+	for _, tt := range tests {
+		key, err := Subkey(tt.secret, tt.purpose)
+		if err != nil {
+			t.Fatalf("Subkey(%q) error = %v", tt.purpose, err)
+		}
+		if got := hex.EncodeToString(key); got != tt.want {
+			t.Errorf("Subkey(%q, %q) = %s, want %s", tt.secret, tt.purpose, got, tt.want)
+		}
+	}
+}
+
+func TestSubkeyPurposesAreIndependent(t *testing.T) {
+	travelRule, err := Subkey("secret", "travel_rule")
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := Subkey("secret", "other")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(travelRule, other) {
+		t.Error("keys for different purposes are equal")
+	}
+	if _, err := Subkey("secret", ""); err == nil {
+		t.Error("Subkey accepted an empty purpose")
+	}
+}
+
+func TestPassphrase(t *testing.T) {
+	salt := bytes.Repeat([]byte{1}, SaltSize)
+	first, err := Passphrase("passphrase", salt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := Passphrase("passphrase", salt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first) != KeySize || !bytes.Equal(first, again) {
+		t.Errorf("Passphrase is not a deterministic %d-byte key", KeySize)
+	}
+	if _, err := Passphrase("passphrase", salt[:SaltSize-1]); err == nil {
+		t.Error("Passphrase accepted a short salt")
+	}
+}
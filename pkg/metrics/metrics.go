@@ -0,0 +1,30 @@
+package metrics
+
+import (
+	"expvar"
+	"net/http"
+)
+
+// registry holds every tvs metric under a single expvar map so they are
+// served together by Handler.
+var registry = expvar.NewMap("tvs")
+
+// Add increments the counter identified by name by delta.
+func Add(name string, delta int64) {
+	registry.Add(name, delta)
+}
+
+// AddFloat increments the float counter identified by name by delta.
+func AddFloat(name string, delta float64) {
+	registry.AddFloat(name, delta)
+}
+
+// Gauge registers fn to be evaluated each time metrics are read.
+func Gauge(name string, fn func() interface{}) {
+	registry.Set(name, expvar.Func(fn))
+}
+
+// Handler serves all registered metrics as JSON.
+func Handler() http.Handler {
+	return expvar.Handler()
+}
@@ -0,0 +1,107 @@
+package ratelimit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tuncanbit/tvs/pkg/config"
+	"github.com/tuncanbit/tvs/pkg/metrics"
+	"golang.org/x/time/rate"
+)
+
+const (
+	defaultRequestsPerSecond = 5
+	defaultBurst             = 5
+)
+
+// Registry hands out one token-bucket limiter per provider and API key so
+// that every caller using the same credentials shares the same budget.
+type Registry struct {
+	mu       sync.Mutex
+	configs  map[string]config.RateLimitConfig
+	limiters map[string]*Limiter
+	logger   zerolog.Logger
+}
+
+type Limiter struct {
+	name    string
+	limiter *rate.Limiter
+	logger  zerolog.Logger
+}
+
+func NewRegistry(cfg map[string]config.RateLimitConfig, logger zerolog.Logger) *Registry {
+	return &Registry{
+		configs:  cfg,
+		limiters: make(map[string]*Limiter),
+		logger:   logger.With().Str("component", "rate_limiter").Logger(),
+	}
+}
+
+// For returns the limiter shared by all callers of provider with apiKey.
+func (r *Registry) For(provider, apiKey string) *Limiter {
+	name := provider
+	if apiKey != "" {
+		sum := sha256.Sum256([]byte(apiKey))
+		name = provider + "." + hex.EncodeToString(sum[:4])
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if l, exists := r.limiters[name]; exists {
+		return l
+	}
+
+	cfg, exists := r.configs[provider]
+	if !exists || cfg.RequestsPerSecond <= 0 {
+		cfg = config.RateLimitConfig{RequestsPerSecond: defaultRequestsPerSecond, Burst: defaultBurst}
+	}
+	if cfg.Burst <= 0 {
+		cfg.Burst = 1
+	}
+
+	l := &Limiter{
+		name:    name,
+		limiter: rate.NewLimiter(rate.Limit(cfg.RequestsPerSecond), cfg.Burst),
+		logger:  r.logger.With().Str("limiter", name).Logger(),
+	}
+	metrics.Gauge("rate_limiter."+name+".tokens", func() interface{} {
+		return l.limiter.Tokens()
+	})
+	r.limiters[name] = l
+
+	r.logger.Info().
+		Str("limiter", name).
+		Float64("requests_per_second", cfg.RequestsPerSecond).
+		Int("burst", cfg.Burst).
+		Msg("Rate limiter created")
+	return l
+}
+
+// Wait blocks until a request is allowed or ctx is done. Time spent waiting
+// is recorded so limiter saturation can be observed.
+func (l *Limiter) Wait(ctx context.Context) error {
+	metrics.Add("rate_limiter."+l.name+".requests", 1)
+
+	if l.limiter.Allow() {
+		return nil
+	}
+
+	metrics.Add("rate_limiter."+l.name+".saturated", 1)
+	start := time.Now()
+	err := l.limiter.Wait(ctx)
+	waited := time.Since(start)
+	metrics.AddFloat("rate_limiter."+l.name+".wait_seconds", waited.Seconds())
+
+	if err != nil {
+		l.logger.Warn().Err(err).Dur("waited", waited).Msg("Gave up waiting for rate limiter")
+		return err
+	}
+
+	l.logger.Debug().Dur("waited", waited).Msg("Request delayed by rate limiter")
+	return nil
+}
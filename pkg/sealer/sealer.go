@@ -0,0 +1,62 @@
+// Package sealer encrypts records at rest with AES-256-GCM under a key
+// derived from a secret, such as security.encryption_key, for one purpose.
+package sealer
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"github.com/tuncanbit/tvs/pkg/kdf"
+)
+
+var ErrEmptyPassphrase = errors.New("encryption passphrase is empty")
+
+type Sealer struct {
+	aead cipher.AEAD
+}
+
+// New returns a sealer keyed for purpose, e.g. "travel_rule". Records sealed
+// for one purpose cannot be opened by a sealer for another.
+func New(passphrase, purpose string) (*Sealer, error) {
+	if passphrase == "" {
+		return nil, ErrEmptyPassphrase
+	}
+	key, err := kdf.Subkey(passphrase, purpose)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return &Sealer{aead: aead}, nil
+}
+
+// Seal encrypts plaintext under a fresh random nonce. associatedData is
+// authenticated but not encrypted, and must be passed to Open unchanged; it
+// binds the ciphertext to the record it belongs to.
+func (s *Sealer) Seal(plaintext, associatedData []byte) (nonce, ciphertext []byte, err error) {
+	nonce = make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return nonce, s.aead.Seal(nil, nonce, plaintext, associatedData), nil
+}
+
+func (s *Sealer) Open(nonce, ciphertext, associatedData []byte) ([]byte, error) {
+	if len(nonce) != s.aead.NonceSize() {
+		return nil, fmt.Errorf("nonce is %d bytes, want %d", len(nonce), s.aead.NonceSize())
+	}
+	plaintext, err := s.aead.Open(nil, nonce, ciphertext, associatedData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
+	}
+	return plaintext, nil
+}
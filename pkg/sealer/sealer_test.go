@@ -0,0 +1,54 @@
+package sealer
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestSealOpen(t *testing.T) {
+	s, err := New("secret", "travel_rule")
+	if err != nil {
+		t.Fatal(err)
+	}
+	plaintext := []byte(`{"beneficiary":"Jane Doe"}`)
+	record := []byte("wd_123")
+
+	nonce, ciphertext, err := s.Seal(plaintext, record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.Open(nonce, ciphertext, record)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if !bytes.Equal(got, plaintext) {
+		t.Errorf("Open() = %q, want %q", got, plaintext)
+	}
+
+	if _, err := s.Open(nonce, ciphertext, []byte("wd_456")); err == nil {
+		t.Error("Open() succeeded for another record")
+	}
+	tampered := append([]byte(nil), ciphertext...)
+	tampered[0] ^= 1
+	if _, err := s.Open(nonce, tampered, record); err == nil {
+		t.Error("Open() succeeded for tampered ciphertext")
+	}
+	if _, err := s.Open(nonce[1:], ciphertext, record); err == nil {
+		t.Error("Open() succeeded with a short nonce")
+	}
+
+	other, err := New("secret", "other")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := other.Open(nonce, ciphertext, record); err == nil {
+		t.Error("a sealer for another purpose opened the record")
+	}
+}
+
+func TestNewRejectsEmptyPassphrase(t *testing.T) {
+	if _, err := New("", "travel_rule"); !errors.Is(err, ErrEmptyPassphrase) {
+		t.Errorf("New() error = %v, want ErrEmptyPassphrase", err)
+	}
+}
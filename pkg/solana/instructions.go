@@ -0,0 +1,60 @@
+package solana
+
+import "encoding/binary"
+
+const (
+	systemInstructionTransfer       = 2
+	tokenInstructionTransferChecked = 12
+	associatedTokenCreateIdempotent = 1
+)
+
+// SystemTransfer moves lamports between two system accounts.
+func SystemTransfer(from, to PublicKey, lamports uint64) Instruction {
+	data := make([]byte, 12)
+	binary.LittleEndian.PutUint32(data, systemInstructionTransfer)
+	binary.LittleEndian.PutUint64(data[4:], lamports)
+	return Instruction{
+		ProgramID: SystemProgramID,
+		Accounts: []AccountMeta{
+			{PublicKey: from, IsSigner: true, IsWritable: true},
+			{PublicKey: to, IsWritable: true},
+		},
+		Data: data,
+	}
+}
+
+// TokenTransferChecked moves amount base units of mint between token
+// accounts; the program rejects it if decimals does not match the mint.
+func TokenTransferChecked(tokenProgramID, source, mint, destination, owner PublicKey, amount uint64, decimals uint8) Instruction {
+	data := make([]byte, 10)
+	data[0] = tokenInstructionTransferChecked
+	binary.LittleEndian.PutUint64(data[1:], amount)
+	data[9] = decimals
+	return Instruction{
+		ProgramID: tokenProgramID,
+		Accounts: []AccountMeta{
+			{PublicKey: source, IsWritable: true},
+			{PublicKey: mint},
+			{PublicKey: destination, IsWritable: true},
+			{PublicKey: owner, IsSigner: true},
+		},
+		Data: data,
+	}
+}
+
+// CreateAssociatedTokenAccountIdempotent creates owner's associated token
+// account for mint, paid by payer, and succeeds if it already exists.
+func CreateAssociatedTokenAccountIdempotent(payer, associatedAccount, owner, mint, tokenProgramID PublicKey) Instruction {
+	return Instruction{
+		ProgramID: AssociatedTokenProgramID,
+		Accounts: []AccountMeta{
+			{PublicKey: payer, IsSigner: true, IsWritable: true},
+			{PublicKey: associatedAccount, IsWritable: true},
+			{PublicKey: owner},
+			{PublicKey: mint},
+			{PublicKey: SystemProgramID},
+			{PublicKey: tokenProgramID},
+		},
+		Data: []byte{associatedTokenCreateIdempotent},
+	}
+}
@@ -0,0 +1,120 @@
+// Package solana builds and serializes Solana transactions for the native
+// SOL and SPL token transfers tvs pays withdrawals out with.
+package solana
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/tuncanbit/tvs/pkg/base58"
+)
+
+const PublicKeyLength = 32
+
+type PublicKey [PublicKeyLength]byte
+
+var (
+	SystemProgramID          = MustParsePublicKey("11111111111111111111111111111111")
+	TokenProgramID           = MustParsePublicKey("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+	Token2022ProgramID       = MustParsePublicKey("TokenzQdBNbLqP5VEhdkAS6EPFLC1PHnBqCXEpPxuEb")
+	AssociatedTokenProgramID = MustParsePublicKey("ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL")
+)
+
+var ErrNoProgramAddress = errors.New("no viable program address bump seed")
+
+func ParsePublicKey(s string) (PublicKey, error) {
+	var key PublicKey
+	decoded, err := base58.Decode(s)
+	if err != nil {
+		return key, fmt.Errorf("invalid public key %q: %w", s, err)
+	}
+	if len(decoded) != PublicKeyLength {
+		return key, fmt.Errorf("invalid public key %q: decodes to %d bytes, want %d", s, len(decoded), PublicKeyLength)
+	}
+	copy(key[:], decoded)
+	return key, nil
+}
+
+func MustParsePublicKey(s string) PublicKey {
+	key, err := ParsePublicKey(s)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+func (k PublicKey) String() string {
+	return base58.Encode(k[:])
+}
+
+// FindProgramAddress derives the program address for seeds the way the
+// runtime does: the first bump seed, counting down from 255, whose hash is
+// not a valid ed25519 point.
+func FindProgramAddress(seeds [][]byte, programID PublicKey) (PublicKey, uint8, error) {
+	for bump := 255; bump >= 0; bump-- {
+		h := sha256.New()
+		for _, seed := range seeds {
+			h.Write(seed)
+		}
+		h.Write([]byte{byte(bump)})
+		h.Write(programID[:])
+		h.Write([]byte("ProgramDerivedAddress"))
+
+		var candidate PublicKey
+		copy(candidate[:], h.Sum(nil))
+		if !isOnCurve(candidate) {
+			return candidate, uint8(bump), nil
+		}
+	}
+	return PublicKey{}, 0, ErrNoProgramAddress
+}
+
+// AssociatedTokenAddress is the associated token account of owner for mint.
+func AssociatedTokenAddress(owner, mint, tokenProgramID PublicKey) (PublicKey, error) {
+	address, _, err := FindProgramAddress([][]byte{owner[:], tokenProgramID[:], mint[:]}, AssociatedTokenProgramID)
+	return address, err
+}
+
+var (
+	fieldPrime = func() *big.Int {
+		p := new(big.Int).Lsh(big.NewInt(1), 255)
+		return p.Sub(p, big.NewInt(19))
+	}()
+	// edwardsD is -121665/121666 mod p.
+	edwardsD = func() *big.Int {
+		d := new(big.Int).ModInverse(big.NewInt(121666), fieldPrime)
+		d.Mul(d, big.NewInt(-121665))
+		return d.Mod(d, fieldPrime)
+	}()
+)
+
+// isOnCurve reports whether key decompresses to a point on edwards25519:
+// x² = (y²-1)/(d·y²+1) must have a square root mod p.
+func isOnCurve(key PublicKey) bool {
+	le := key
+	le[31] &= 0x7f
+	for i, j := 0, len(le)-1; i < j; i, j = i+1, j-1 {
+		le[i], le[j] = le[j], le[i]
+	}
+	y := new(big.Int).SetBytes(le[:])
+	y.Mod(y, fieldPrime)
+
+	y2 := new(big.Int).Mul(y, y)
+	y2.Mod(y2, fieldPrime)
+	u := new(big.Int).Sub(y2, big.NewInt(1))
+	u.Mod(u, fieldPrime)
+	v := new(big.Int).Mul(edwardsD, y2)
+	v.Add(v, big.NewInt(1))
+	v.Mod(v, fieldPrime)
+
+	x2 := new(big.Int).ModInverse(v, fieldPrime)
+	x2.Mul(x2, u)
+	x2.Mod(x2, fieldPrime)
+	if x2.Sign() == 0 {
+		return true
+	}
+	exp := new(big.Int).Rsh(new(big.Int).Sub(fieldPrime, big.NewInt(1)), 1)
+	return new(big.Int).Exp(x2, exp, fieldPrime).Cmp(big.NewInt(1)) == 0
+}
@@ -0,0 +1,114 @@
+package solana
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+// Public keys of RFC 8032 test vectors 1 and 2, and the USDC mint.
+var (
+	rfc8032Key1 = MustParsePublicKey("FVen3X669xLzsi6N2V91DoiyzHzg1uAgqiT8jZ9nS96Z")
+	rfc8032Key2 = MustParsePublicKey("586Z7H2vpX9qNhN2T4e9Utugie3ogjbxzGaMtM3E6HR5")
+	usdcMint    = MustParsePublicKey("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v")
+)
+
+func mustKeyFromHex(t *testing.T, s string) PublicKey {
+	t.Helper()
+	raw, err := hex.DecodeString(s)
+	if err != nil || len(raw) != PublicKeyLength {
+		t.Fatalf("bad key hex %q", s)
+	}
+	var key PublicKey
+	copy(key[:], raw)
+	return key
+}
+
+func TestProgramIDs(t *testing.T) {
+	tests := []struct {
+		name string
+		key  PublicKey
+		hex  string
+	}{
+		{"system", SystemProgramID, "0000000000000000000000000000000000000000000000000000000000000000"},
+		{"token", TokenProgramID, "06ddf6e1d765a193d9cbe146ceeb79ac1cb485ed5f5b37913a8cf5857eff00a9"},
+		{"token-2022", Token2022ProgramID, "06ddf6e1ee758fde18425dbce46ccddab61afc4d83b90d27febdf928d8a18bfc"},
+		{"associated token", AssociatedTokenProgramID, "8c97258f4e2489f1bb3d1029148e0d830b5a1399daff1084048e7bd8dbe9f859"},
+	}
+	for _, tt := range tests {
+		if got := hex.EncodeToString(tt.key[:]); got != tt.hex {
+			t.Errorf("%s program ID = %s, want %s", tt.name, got, tt.hex)
+		}
+	}
+}
+
+func TestParsePublicKeyRejectsWrongLength(t *testing.T) {
+	for _, s := range []string{"", "2g", "1111111111111111111111111111111111"} {
+		if _, err := ParsePublicKey(s); err == nil {
+			t.Errorf("ParsePublicKey(%q) succeeded", s)
+		}
+	}
+}
+
+func TestIsOnCurve(t *testing.T) {
+	tests := []struct {
+		name string
+		key  PublicKey
+		want bool
+	}{
+		{"RFC 8032 key 1", rfc8032Key1, true},
+		{"RFC 8032 key 2", rfc8032Key2, true},
+		{"y = 0", SystemProgramID, true},
+		{"y = 2", mustKeyFromHex(t, "0200000000000000000000000000000000000000000000000000000000000000"), false},
+		{"first PDA candidate of seed0", mustKeyFromHex(t, "f0d64a7921e6b066fbfaf6f3aca5cfda8eef1e326eca573904d89ef2db3c70a2"), true},
+		{"associated token account", MustParsePublicKey("HU2S9ByyqbnCD2SVfvr9qoLtDTtyTnMZoMaw1xpr6cTb"), false},
+	}
+	for _, tt := range tests {
+		if got := isOnCurve(tt.key); got != tt.want {
+			t.Errorf("isOnCurve(%s) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFindProgramAddress(t *testing.T) {
+	tests := []struct {
+		seed    string
+		program PublicKey
+		address string
+		bump    uint8
+	}{
+		{"tvs", TokenProgramID, "8mZ1KDq6wzn5rYuL6GUEPS5hkNnBxy8Yp6PJmipVFKj1", 255},
+		// The bump 255 candidate is on the curve, so the search steps down.
+		{"seed0", SystemProgramID, "Gu6jrJzvaS3wGZk7boG5kfU5GVkh1zTFwkiM2Vnx3gHm", 254},
+	}
+	for _, tt := range tests {
+		address, bump, err := FindProgramAddress([][]byte{[]byte(tt.seed)}, tt.program)
+		if err != nil {
+			t.Fatalf("FindProgramAddress(%q) failed: %v", tt.seed, err)
+		}
+		if address.String() != tt.address || bump != tt.bump {
+			t.Errorf("FindProgramAddress(%q) = %s/%d, want %s/%d", tt.seed, address, bump, tt.address, tt.bump)
+		}
+	}
+}
+
+func TestAssociatedTokenAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		owner   PublicKey
+		program PublicKey
+		want    string
+	}{
+		{"token", rfc8032Key1, TokenProgramID, "HU2S9ByyqbnCD2SVfvr9qoLtDTtyTnMZoMaw1xpr6cTb"},
+		{"token for another owner", rfc8032Key2, TokenProgramID, "HKpJMFu3s2nEZ6WofQc3Xbb4RwGFb9AzTKdNwuZSvGGq"},
+		{"token-2022", rfc8032Key1, Token2022ProgramID, "VM2GEGSS8zhuHTM4KD9BJiNPJx14sJzDcZwfZTm219F"},
+	}
+	for _, tt := range tests {
+		got, err := AssociatedTokenAddress(tt.owner, usdcMint, tt.program)
+		if err != nil {
+			t.Fatalf("%s: AssociatedTokenAddress failed: %v", tt.name, err)
+		}
+		if got.String() != tt.want {
+			t.Errorf("%s: AssociatedTokenAddress = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
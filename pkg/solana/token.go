@@ -0,0 +1,84 @@
+package solana
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// TokenAccountSize is the size of an SPL token account. Token-2022
+	// accounts share the layout and append an account type byte and
+	// extensions after it.
+	TokenAccountSize = 165
+	// Token2022AssociatedAccountSize is the size of a Token-2022 associated
+	// token account without mint-required extensions: the base layout, the
+	// account type and the immutable owner extension.
+	Token2022AssociatedAccountSize = 170
+	// MintSize is the size of an SPL mint account.
+	MintSize = 82
+
+	token2022AccountTypeAccount = 2
+
+	// tokenAccountStateOffset is where the account state follows the mint,
+	// owner, amount and optional delegate.
+	tokenAccountStateOffset   = 108
+	tokenAccountUninitialized = 0
+	tokenAccountInitialized   = 1
+	tokenAccountFrozen        = 2
+)
+
+var (
+	ErrNotTokenAccount    = errors.New("not a token account")
+	ErrTokenAccountFrozen = errors.New("token account is frozen")
+)
+
+// TokenAccount is the mint and owner of an SPL token account.
+type TokenAccount struct {
+	Mint  PublicKey
+	Owner PublicKey
+}
+
+// IsTokenProgram reports whether program is the SPL Token or Token-2022
+// program.
+func IsTokenProgram(program PublicKey) bool {
+	return program == TokenProgramID || program == Token2022ProgramID
+}
+
+// DecodeTokenAccount reads the mint and owner of an account owned by
+// program, whose data is at least the first TokenAccountSize+1 bytes of an
+// account of size bytes. Mints, other token program accounts and
+// uninitialized token accounts return ErrNotTokenAccount; frozen accounts,
+// which cannot receive, return ErrTokenAccountFrozen.
+func DecodeTokenAccount(program PublicKey, data []byte, size uint64) (TokenAccount, error) {
+	if !IsTokenProgram(program) {
+		return TokenAccount{}, fmt.Errorf("%w: owned by %s", ErrNotTokenAccount, program)
+	}
+	switch {
+	case size == TokenAccountSize:
+	case program == Token2022ProgramID && size > TokenAccountSize:
+		// Token-2022 mints with extensions are padded to the account size,
+		// so the account type byte tells the two apart.
+		if len(data) <= TokenAccountSize || data[TokenAccountSize] != token2022AccountTypeAccount {
+			return TokenAccount{}, ErrNotTokenAccount
+		}
+	default:
+		return TokenAccount{}, ErrNotTokenAccount
+	}
+	if len(data) <= tokenAccountStateOffset {
+		return TokenAccount{}, fmt.Errorf("%w: %d bytes of data", ErrNotTokenAccount, len(data))
+	}
+	switch state := data[tokenAccountStateOffset]; state {
+	case tokenAccountInitialized:
+	case tokenAccountFrozen:
+		return TokenAccount{}, ErrTokenAccountFrozen
+	case tokenAccountUninitialized:
+		return TokenAccount{}, fmt.Errorf("%w: uninitialized", ErrNotTokenAccount)
+	default:
+		return TokenAccount{}, fmt.Errorf("%w: unknown state %d", ErrNotTokenAccount, state)
+	}
+
+	var account TokenAccount
+	copy(account.Mint[:], data[:PublicKeyLength])
+	copy(account.Owner[:], data[PublicKeyLength:2*PublicKeyLength])
+	return account, nil
+}
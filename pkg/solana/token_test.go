@@ -0,0 +1,53 @@
+package solana
+
+import (
+	"errors"
+	"testing"
+)
+
+// tokenAccountData lays out the first accountDataPrefix bytes of a token
+// account of mint owned by owner in state.
+func tokenAccountData(mint, owner PublicKey, state byte) []byte {
+	data := make([]byte, TokenAccountSize+1)
+	copy(data, mint[:])
+	copy(data[PublicKeyLength:], owner[:])
+	data[tokenAccountStateOffset] = state
+	return data
+}
+
+func TestDecodeTokenAccount(t *testing.T) {
+	initialized := tokenAccountData(usdcMint, rfc8032Key1, tokenAccountInitialized)
+	token2022 := tokenAccountData(usdcMint, rfc8032Key1, tokenAccountInitialized)
+	token2022[TokenAccountSize] = token2022AccountTypeAccount
+	token2022Mint := make([]byte, TokenAccountSize+1)
+	token2022Mint[TokenAccountSize] = 1
+
+	tests := []struct {
+		name    string
+		program PublicKey
+		data    []byte
+		size    uint64
+		wantErr error
+	}{
+		{"token account", TokenProgramID, initialized, TokenAccountSize, nil},
+		{"token-2022 account with extensions", Token2022ProgramID, token2022, Token2022AssociatedAccountSize, nil},
+		{"token-2022 mint with extensions", Token2022ProgramID, token2022Mint, Token2022AssociatedAccountSize, ErrNotTokenAccount},
+		{"mint", TokenProgramID, make([]byte, MintSize), MintSize, ErrNotTokenAccount},
+		{"other program", SystemProgramID, initialized, TokenAccountSize, ErrNotTokenAccount},
+		{"uninitialized", TokenProgramID, tokenAccountData(usdcMint, rfc8032Key1, tokenAccountUninitialized), TokenAccountSize, ErrNotTokenAccount},
+		{"frozen", TokenProgramID, tokenAccountData(usdcMint, rfc8032Key1, tokenAccountFrozen), TokenAccountSize, ErrTokenAccountFrozen},
+		{"unknown state", TokenProgramID, tokenAccountData(usdcMint, rfc8032Key1, 3), TokenAccountSize, ErrNotTokenAccount},
+		{"truncated data", TokenProgramID, initialized[:tokenAccountStateOffset], TokenAccountSize, ErrNotTokenAccount},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account, err := DecodeTokenAccount(tt.program, tt.data, tt.size)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DecodeTokenAccount() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (account.Mint != usdcMint || account.Owner != rfc8032Key1) {
+				t.Errorf("DecodeTokenAccount() = %s/%s, want %s/%s", account.Mint, account.Owner, usdcMint, rfc8032Key1)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package solana
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tuncanbit/tvs/pkg/base58"
+)
+
+const SignatureLength = 64
+
+type AccountMeta struct {
+	PublicKey  PublicKey
+	IsSigner   bool
+	IsWritable bool
+}
+
+type Instruction struct {
+	ProgramID PublicKey
+	Accounts  []AccountMeta
+	Data      []byte
+}
+
+// Message is a compiled legacy transaction message.
+type Message struct {
+	NumRequiredSignatures       uint8
+	NumReadonlySignedAccounts   uint8
+	NumReadonlyUnsignedAccounts uint8
+	AccountKeys                 []PublicKey
+	RecentBlockhash             PublicKey
+	Instructions                []compiledInstruction
+}
+
+type compiledInstruction struct {
+	programIDIndex uint8
+	accounts       []uint8
+	data           []byte
+}
+
+// Transaction is a message and one signature per required signer, in the
+// order of the message's signer accounts.
+type Transaction struct {
+	Signatures [][]byte
+	Message    Message
+}
+
+// NewMessage compiles instructions into a legacy message paid for by
+// feePayer. Accounts are ordered as the runtime requires: writable signers,
+// read-only signers, writable non-signers, then read-only non-signers, with
+// the fee payer first.
+func NewMessage(feePayer PublicKey, recentBlockhash string, instructions []Instruction) (Message, error) {
+	blockhash, err := ParsePublicKey(recentBlockhash)
+	if err != nil {
+		return Message{}, fmt.Errorf("invalid recent blockhash: %w", err)
+	}
+
+	metas := []AccountMeta{{PublicKey: feePayer, IsSigner: true, IsWritable: true}}
+	index := map[PublicKey]int{feePayer: 0}
+	add := func(meta AccountMeta) {
+		if i, ok := index[meta.PublicKey]; ok {
+			metas[i].IsSigner = metas[i].IsSigner || meta.IsSigner
+			metas[i].IsWritable = metas[i].IsWritable || meta.IsWritable
+			return
+		}
+		index[meta.PublicKey] = len(metas)
+		metas = append(metas, meta)
+	}
+	for _, instruction := range instructions {
+		for _, account := range instruction.Accounts {
+			add(account)
+		}
+		add(AccountMeta{PublicKey: instruction.ProgramID})
+	}
+
+	rank := func(meta AccountMeta) int {
+		switch {
+		case meta.IsSigner && meta.IsWritable:
+			return 0
+		case meta.IsSigner:
+			return 1
+		case meta.IsWritable:
+			return 2
+		default:
+			return 3
+		}
+	}
+	var ordered []AccountMeta
+	for r := 0; r < 4; r++ {
+		for _, meta := range metas {
+			if rank(meta) == r {
+				ordered = append(ordered, meta)
+			}
+		}
+	}
+	if len(ordered) > 256 {
+		return Message{}, errors.New("transaction references more than 256 accounts")
+	}
+
+	msg := Message{RecentBlockhash: blockhash}
+	positions := make(map[PublicKey]uint8, len(ordered))
+	for i, meta := range ordered {
+		positions[meta.PublicKey] = uint8(i)
+		msg.AccountKeys = append(msg.AccountKeys, meta.PublicKey)
+		switch rank(meta) {
+		case 0:
+			msg.NumRequiredSignatures++
+		case 1:
+			msg.NumRequiredSignatures++
+			msg.NumReadonlySignedAccounts++
+		case 3:
+			msg.NumReadonlyUnsignedAccounts++
+		}
+	}
+
+	for _, instruction := range instructions {
+		compiled := compiledInstruction{
+			programIDIndex: positions[instruction.ProgramID],
+			data:           instruction.Data,
+		}
+		for _, account := range instruction.Accounts {
+			compiled.accounts = append(compiled.accounts, positions[account.PublicKey])
+		}
+		msg.Instructions = append(msg.Instructions, compiled)
+	}
+	return msg, nil
+}
+
+// Signers returns the accounts that must sign the message, in signature
+// order.
+func (m Message) Signers() []PublicKey {
+	return m.AccountKeys[:m.NumRequiredSignatures]
+}
+
+// Serialize returns the wire encoding of the message, which is what each
+// signer signs.
+func (m Message) Serialize() []byte {
+	out := []byte{m.NumRequiredSignatures, m.NumReadonlySignedAccounts, m.NumReadonlyUnsignedAccounts}
+	out = appendCompactU16(out, len(m.AccountKeys))
+	for _, key := range m.AccountKeys {
+		out = append(out, key[:]...)
+	}
+	out = append(out, m.RecentBlockhash[:]...)
+	out = appendCompactU16(out, len(m.Instructions))
+	for _, instruction := range m.Instructions {
+		out = append(out, instruction.programIDIndex)
+		out = appendCompactU16(out, len(instruction.accounts))
+		out = append(out, instruction.accounts...)
+		out = appendCompactU16(out, len(instruction.data))
+		out = append(out, instruction.data...)
+	}
+	return out
+}
+
+// Serialize returns the wire encoding of the signed transaction.
+func (t Transaction) Serialize() ([]byte, error) {
+	if len(t.Signatures) != int(t.Message.NumRequiredSignatures) {
+		return nil, fmt.Errorf("transaction has %d signatures, message requires %d", len(t.Signatures), t.Message.NumRequiredSignatures)
+	}
+	out := appendCompactU16(nil, len(t.Signatures))
+	for _, signature := range t.Signatures {
+		if len(signature) != SignatureLength {
+			return nil, fmt.Errorf("signature is %d bytes, want %d", len(signature), SignatureLength)
+		}
+		out = append(out, signature...)
+	}
+	return append(out, t.Message.Serialize()...), nil
+}
+
+// ID is the transaction's first signature, which is the hash explorers and
+// RPC nodes identify it by.
+func (t Transaction) ID() string {
+	if len(t.Signatures) == 0 {
+		return ""
+	}
+	return base58.Encode(t.Signatures[0])
+}
+
+func appendCompactU16(out []byte, n int) []byte {
+	for {
+		b := byte(n & 0x7f)
+		n >>= 7
+		if n == 0 {
+			return append(out, b)
+		}
+		out = append(out, b|0x80)
+	}
+}
@@ -0,0 +1,116 @@
+package solana
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/tuncanbit/tvs/pkg/base58"
+)
+
+const testBlockhash = "EkSnNWid2cvwEVnVx9aBqawnmiCNiDgp3gUdkDPTKN1N"
+
+func TestAppendCompactU16(t *testing.T) {
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{0, "00"},
+		{127, "7f"},
+		{128, "8001"},
+		{16383, "ff7f"},
+		{16384, "808001"},
+	}
+	for _, tt := range tests {
+		if got := hex.EncodeToString(appendCompactU16(nil, tt.n)); got != tt.want {
+			t.Errorf("appendCompactU16(%d) = %s, want %s", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestSerializeSystemTransfer(t *testing.T) {
+	message, err := NewMessage(rfc8032Key1, testBlockhash, []Instruction{
+		SystemTransfer(rfc8032Key1, rfc8032Key2, 1_000_000),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "01000103" +
+		"d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a" +
+		"3d4017c3e843895a92b70aa74d1b7ebc9c982ccf2ec4968cc0cd55f12af4660c" +
+		"0000000000000000000000000000000000000000000000000000000000000000" +
+		"cc490e928cd2e3873bb343fc95da33179ca60f4dbf46c2c36e91299d55d4e6b9" +
+		"01" + "02" + "020001" + "0c" + "0200000040420f0000000000"
+	if got := hex.EncodeToString(message.Serialize()); got != want {
+		t.Errorf("Serialize() =\n%s\nwant\n%s", got, want)
+	}
+	if signers := message.Signers(); len(signers) != 1 || signers[0] != rfc8032Key1 {
+		t.Errorf("Signers() = %v, want the fee payer only", signers)
+	}
+}
+
+func TestSerializeTokenTransferWithAccountCreation(t *testing.T) {
+	source, err := AssociatedTokenAddress(rfc8032Key1, usdcMint, TokenProgramID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	destination, err := AssociatedTokenAddress(rfc8032Key2, usdcMint, TokenProgramID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	message, err := NewMessage(rfc8032Key1, testBlockhash, []Instruction{
+		CreateAssociatedTokenAccountIdempotent(rfc8032Key1, destination, rfc8032Key2, usdcMint, TokenProgramID),
+		TokenTransferChecked(TokenProgramID, source, usdcMint, destination, rfc8032Key1, 2_500_000, 6),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Fee payer, then the writable token accounts, then the read-only owner,
+	// mint and programs.
+	want := "01000508" +
+		"d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a" +
+		"f28ced3aa788fbf754491a5429afcba035e8f82836b523d9ff9b2c0e4c520e8a" +
+		"f4a74b227ecf937d05e03410faec3b608ff1d9a8eef301c8d4665c7ce22154ea" +
+		"3d4017c3e843895a92b70aa74d1b7ebc9c982ccf2ec4968cc0cd55f12af4660c" +
+		"c6fa7af3bedbad3a3d65f36aabc97431b1bbe4c2d2f6e0e47ca60203452f5d61" +
+		"0000000000000000000000000000000000000000000000000000000000000000" +
+		"06ddf6e1d765a193d9cbe146ceeb79ac1cb485ed5f5b37913a8cf5857eff00a9" +
+		"8c97258f4e2489f1bb3d1029148e0d830b5a1399daff1084048e7bd8dbe9f859" +
+		"cc490e928cd2e3873bb343fc95da33179ca60f4dbf46c2c36e91299d55d4e6b9" +
+		"02" +
+		"07" + "06000103040506" + "0101" +
+		"06" + "0402040100" + "0a" + "0ca025260000000000" + "06"
+	if got := hex.EncodeToString(message.Serialize()); got != want {
+		t.Errorf("Serialize() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestTransactionSerialize(t *testing.T) {
+	message, err := NewMessage(rfc8032Key1, testBlockhash, []Instruction{
+		SystemTransfer(rfc8032Key1, rfc8032Key2, 1),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature := bytes.Repeat([]byte{0x01}, SignatureLength)
+
+	if _, err := (Transaction{Message: message}).Serialize(); err == nil {
+		t.Error("Serialize() of an unsigned transaction succeeded")
+	}
+	if _, err := (Transaction{Message: message, Signatures: [][]byte{signature[:63]}}).Serialize(); err == nil {
+		t.Error("Serialize() with a short signature succeeded")
+	}
+
+	transaction := Transaction{Message: message, Signatures: [][]byte{signature}}
+	raw, err := transaction.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := append(append([]byte{0x01}, signature...), message.Serialize()...)
+	if !bytes.Equal(raw, want) {
+		t.Errorf("Serialize() = %x, want %x", raw, want)
+	}
+	if got := transaction.ID(); got != base58.Encode(signature) {
+		t.Errorf("ID() = %s, want %s", got, base58.Encode(signature))
+	}
+}